@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"encoding/binary"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -277,6 +278,15 @@ func doQuery(c *cli.Context) error {
 			log.Fatal(err)
 		}
 	}
+	if q.IsExplain() {
+		plan, err := db.Explain(q)
+		if err != nil {
+			log.Fatal(err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
 	res, err = db.RunQuery(q)
 	if err != nil {
 		log.Fatal(err)
@@ -346,10 +356,10 @@ func classGraph(c *cli.Context) error {
 	fmt.Fprintln(f, "rankdir=\"LR\"")
 	fmt.Fprintln(f, "size=\"7.5,10\"")
 	for node := range nodes {
-		fmt.Fprintf(f, node)
+		fmt.Fprint(f, node)
 	}
 	for edge := range edges {
-		fmt.Fprintf(f, edge)
+		fmt.Fprint(f, edge)
 	}
 	fmt.Fprintln(f, "}")
 	cmd := exec.Command("dot", "-Tpdf", name)
@@ -403,10 +413,10 @@ func dumpGraph(c *cli.Context) error {
 	fmt.Fprintln(f, "rankdir=\"LR\"")
 	fmt.Fprintln(f, "size=\"7.5,10\"")
 	for node := range nodes {
-		fmt.Fprintf(f, node)
+		fmt.Fprint(f, node)
 	}
 	for edge := range edges {
-		fmt.Fprintf(f, edge)
+		fmt.Fprint(f, edge)
 	}
 	fmt.Fprintln(f, "}")
 	cmd := exec.Command("sfdp", "-Tpdf", name)
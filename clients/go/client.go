@@ -114,7 +114,12 @@ func (c *HodClientHTTP) DoQuery(query string, options *Options) (res Result, err
 	b := new(bytes.Buffer)
 	b.WriteString(query)
 
-	resp, err := http.Post(c.url, "application/json", b)
+	url := c.url
+	if options != nil && options.Timeout > 0 {
+		// the server also cancels the query once the timeout has passed
+		url += "?timeout=" + options.Timeout.String()
+	}
+	resp, err := http.Post(url, "application/json", b)
 	defer resp.Body.Close()
 	if err != nil {
 		err = errors.Wrap(err, "Problem posting to HodDB")
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"time"
 
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -14,9 +15,21 @@ type Config struct {
 	DBPath            string
 	ReloadOntologies  bool
 	DisableQueryCache bool
+	// the most rows a query may produce by pairing groups of terms that
+	// share no variables; 0 disables the limit
+	MaxCrossProduct int
+	// how long a query may run before it is canceled; 0 disables the limit
+	QueryTimeout time.Duration
+	// join cyclic patterns pairwise instead of with a leapfrog triejoin
+	DisableLeapfrogJoin bool
+	// how many databases a query runs on at the same time; values below 1
+	// are treated as 1
+	QueryConcurrency int
 
 	// datasets to load
 	Buildings map[string]string
+	// links (key/value pairs on entities) to load for each building
+	Links map[string]string
 
 	// ontologies to load
 	Ontologies []string
@@ -52,7 +65,12 @@ func (cfg *Config) Copy() *Config {
 		DBPath:                 cfg.DBPath,
 		ReloadOntologies:       cfg.ReloadOntologies,
 		DisableQueryCache:      cfg.DisableQueryCache,
+		MaxCrossProduct:        cfg.MaxCrossProduct,
+		QueryTimeout:           cfg.QueryTimeout,
+		DisableLeapfrogJoin:    cfg.DisableLeapfrogJoin,
+		QueryConcurrency:       cfg.QueryConcurrency,
 		Buildings:              cfg.Buildings,
+		Links:                  cfg.Links,
 		Ontologies:             cfg.Ontologies,
 		ShowNamespaces:         cfg.ShowNamespaces,
 		ShowDependencyGraph:    cfg.ShowDependencyGraph,
@@ -78,7 +96,12 @@ func init() {
 	viper.SetDefault("DBPath", "_hoddb")
 	viper.SetDefault("ReloadOntologies", true)
 	viper.SetDefault("DisableQueryCache", true)
+	viper.SetDefault("MaxCrossProduct", 1000000)
+	viper.SetDefault("QueryTimeout", "0s")
+	viper.SetDefault("DisableLeapfrogJoin", false)
+	viper.SetDefault("QueryConcurrency", 8)
 	viper.SetDefault("Buildings", make(map[string]string))
+	viper.SetDefault("Links", make(map[string]string))
 	viper.SetDefault("Ontologies", []string{
 		prefix + "/src/github.com/gtfierro/hod/BrickFrame.ttl",
 		prefix + "/src/github.com/gtfierro/hod/Brick.ttl",
@@ -136,7 +159,12 @@ func ReadConfig(file string) (*Config, error) {
 		EnableHTTP:             viper.GetBool("EnableHTTP"),
 		EnableBOSSWAVE:         viper.GetBool("EnableBOSSWAVE"),
 		DisableQueryCache:      viper.GetBool("DisableQueryCache"),
+		MaxCrossProduct:        viper.GetInt("MaxCrossProduct"),
+		QueryTimeout:           viper.GetDuration("QueryTimeout"),
+		DisableLeapfrogJoin:    viper.GetBool("DisableLeapfrogJoin"),
+		QueryConcurrency:       viper.GetInt("QueryConcurrency"),
 		Buildings:              viper.GetStringMapString("Buildings"),
+		Links:                  viper.GetStringMapString("Links"),
 		Ontologies:             viper.GetStringSlice("Ontologies"),
 		ShowNamespaces:         viper.GetBool("ShowNamespaces"),
 		ShowDependencyGraph:    viper.GetBool("ShowDependencyGraph"),
@@ -0,0 +1,399 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+)
+
+// groupSet holds the groups of an aggregate query and the partial state of
+// their aggregates. It is filled with the solutions of each UNION branch of
+// each database, which may be queried at the same time; groups are keyed by
+// the values of the GROUP BY variables, so solutions from different
+// snapshots end up in the same group
+type groupSet struct {
+	sync.Mutex
+	vars []string
+	// the aggregates of the query; they are looked up once the prefixes in
+	// the query have been expanded
+	aggregates []sparql.AggregateExpression
+	// position of each aggregate in aggregates, by its string form
+	index  map[string]int
+	groups map[string]*group
+}
+
+// a group of solutions with the same values for the GROUP BY variables
+type group struct {
+	// values of the GROUP BY variables; the empty URI if unbound
+	key    []turtle.URI
+	states []*aggregateState
+}
+
+func newGroupSet(q *sparql.Query) *groupSet {
+	return &groupSet{
+		vars:   q.GroupBy,
+		groups: make(map[string]*group),
+	}
+}
+
+func (gs *groupSet) findAggregates(q *sparql.Query) {
+	if gs.index != nil {
+		return
+	}
+	gs.aggregates = q.Aggregates()
+	gs.index = make(map[string]int, len(gs.aggregates))
+	for idx, agg := range gs.aggregates {
+		gs.index[agg.String()] = idx
+	}
+}
+
+// returns the group with the given values of the GROUP BY variables,
+// creating it if needed
+func (gs *groupSet) getGroup(key []turtle.URI) *group {
+	k := termsKey(key)
+	if g, found := gs.groups[k]; found {
+		return g
+	}
+	g := &group{key: key, states: make([]*aggregateState, len(gs.aggregates))}
+	for idx := range g.states {
+		g.states[idx] = newAggregateState()
+	}
+	gs.groups[k] = g
+	return g
+}
+
+// adds the solutions in the relation of the query context to their groups.
+// Returns the number of solutions
+func (gs *groupSet) addSolutions(ctx *queryContext) (int, error) {
+	gs.Lock()
+	defer gs.Unlock()
+	gs.findAggregates(ctx.query)
+	ev := newEvaluator(ctx)
+
+	// the variables that the plan introduces for paths do not distinguish
+	// solutions. Solutions of different UNION branches and databases are
+	// all aggregated, even if they have the same values; only DISTINCT
+	// aggregates skip repeated values
+	var positions []int
+	for _, varname := range ctx.query.Variables {
+		if pos, found := ctx.variablePosition[varname]; found {
+			positions = append(positions, pos)
+		}
+	}
+
+	// solutions are grouped by the keys of the GROUP BY variables first, so
+	// that each distinct key is only looked up once
+	local := make(map[string]*group)
+	seen := make(map[string]struct{}, len(ctx.rel.rows))
+	var rawkey []byte
+	numSolutions := 0
+rowIter:
+	for _, row := range ctx.rel.rows {
+		// rows with an unbound required variable are not solutions, and
+		// duplicate rows are only counted once
+		rawkey = rawkey[:0]
+		for varname, pos := range ctx.variablePosition {
+			if row.valueAt(pos) == emptyKey && !ctx.optionalVars[varname] {
+				continue rowIter
+			}
+		}
+		for _, pos := range positions {
+			key := row.valueAt(pos)
+			rawkey = append(rawkey, key[:]...)
+		}
+		if _, found := seen[string(rawkey)]; found {
+			continue
+		}
+		seen[string(rawkey)] = struct{}{}
+		numSolutions++
+
+		rawkey = rawkey[:0]
+		for _, varname := range gs.vars {
+			key := emptyKey
+			if pos, found := ctx.variablePosition[varname]; found {
+				key = row.valueAt(pos)
+			}
+			rawkey = append(rawkey, key[:]...)
+		}
+		g, found := local[string(rawkey)]
+		if !found {
+			groupkey := make([]turtle.URI, len(gs.vars))
+			for idx := range gs.vars {
+				var key Key
+				copy(key[:], rawkey[idx*8:idx*8+8])
+				if key == emptyKey {
+					continue
+				}
+				uri, err := ctx.t.getURI(key)
+				if err != nil {
+					return numSolutions, err
+				}
+				groupkey[idx] = uri
+			}
+			g = gs.getGroup(groupkey)
+			local[string(rawkey)] = g
+		}
+
+		solution := relationRow{ctx, row}
+		for idx, agg := range gs.aggregates {
+			g.states[idx].add(ev, agg, solution)
+		}
+	}
+	return numSolutions, nil
+}
+
+// returns a row for each group that satisfies the HAVING conditions of the
+// query. The columns of the rows are vars, which are GROUP BY variables or
+// variables bound to an expression in the SELECT clause
+func (gs *groupSet) rows(q *sparql.Query, vars []string) []*ResultRow {
+	gs.findAggregates(q)
+	// without GROUP BY, all solutions form a single group, even if there
+	// are none
+	if len(gs.vars) == 0 && len(gs.groups) == 0 {
+		gs.getGroup(nil)
+	}
+	ev := newEvaluator(nil)
+	var rows []*ResultRow
+groupIter:
+	for _, g := range gs.groups {
+		b := groupBindings{gs: gs, g: g, sel: q.Select, ev: ev}
+		for _, cond := range q.Having {
+			val, err := ev.eval(cond, b)
+			if err != nil {
+				continue groupIter
+			}
+			if pass, err := val.effectiveBool(); err != nil || !pass {
+				continue groupIter
+			}
+		}
+		row := getResultRow(len(vars))
+		for idx, varname := range vars {
+			if uri, err := b.lookup(varname); err == nil {
+				row.row[idx] = uri
+			} else {
+				row.row[idx] = turtle.URI{}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// the bindings of a group: its GROUP BY variables, its aggregates and the
+// variables bound to expressions in the SELECT clause
+type groupBindings struct {
+	gs  *groupSet
+	g   *group
+	sel sparql.SelectClause
+	ev  *evaluator
+}
+
+func (b groupBindings) lookup(varname string) (turtle.URI, error) {
+	for idx, name := range b.gs.vars {
+		if name == varname {
+			if b.g.key[idx] == (turtle.URI{}) {
+				return turtle.URI{}, errUnbound
+			}
+			return b.g.key[idx], nil
+		}
+	}
+	if expr := b.sel.Projection(varname); expr != nil {
+		val, err := b.ev.eval(expr, b)
+		if err != nil {
+			return turtle.URI{}, err
+		}
+		return termFromValue(val), nil
+	}
+	return turtle.URI{}, errUnbound
+}
+
+func (b groupBindings) aggregate(agg sparql.AggregateExpression) (exprValue, error) {
+	idx, found := b.gs.index[agg.String()]
+	if !found {
+		return exprValue{}, fmt.Errorf("Unknown aggregate %s", agg)
+	}
+	uri, err := b.g.states[idx].result(agg)
+	if err != nil {
+		return exprValue{}, err
+	}
+	return valueFromURI(uri), nil
+}
+
+// the partial result of an aggregate over the solutions of a group
+type aggregateState struct {
+	count int
+	sum   float64
+	// false once a non-integer value has been summed
+	integers bool
+	// set if a value could not be summed
+	err error
+	// the current MIN, MAX or SAMPLE
+	best    exprValue
+	hasBest bool
+	strs    []string
+	// the distinct values seen so far, for aggregates with DISTINCT
+	values []exprValue
+	seen   map[string]struct{}
+}
+
+func newAggregateState() *aggregateState {
+	return &aggregateState{integers: true}
+}
+
+// evaluates the argument of the aggregate against a solution and adds it to
+// the state. Solutions for which the argument is unbound or cannot be
+// evaluated are skipped, except that SUM and AVG of a non-number fail
+func (st *aggregateState) add(ev *evaluator, agg sparql.AggregateExpression, solution bindings) {
+	if agg.Arg == nil {
+		// COUNT(*)
+		st.count++
+		return
+	}
+	val, err := ev.eval(agg.Arg, solution)
+	if err != nil {
+		return
+	}
+	if agg.Distinct {
+		if st.seen == nil {
+			st.seen = make(map[string]struct{})
+		}
+		key := termsKey([]turtle.URI{termFromValue(val)})
+		if _, found := st.seen[key]; found {
+			return
+		}
+		st.seen[key] = struct{}{}
+		st.values = append(st.values, val)
+		return
+	}
+	st.addValue(agg.Function, val)
+}
+
+func (st *aggregateState) addValue(function string, val exprValue) {
+	switch function {
+	case "COUNT":
+		st.count++
+	case "SUM", "AVG":
+		num, err := val.number()
+		if err != nil {
+			st.err = fmt.Errorf("Cannot compute %s of %s", function, val)
+			return
+		}
+		st.count++
+		st.sum += num
+		if !isIntegerValue(val) {
+			st.integers = false
+		}
+	case "MIN", "MAX":
+		if !st.hasBest {
+			st.best, st.hasBest = val, true
+			return
+		}
+		cmp := orderCompare(val, st.best)
+		if (function == "MIN" && cmp < 0) || (function == "MAX" && cmp > 0) {
+			st.best = val
+		}
+	case "SAMPLE":
+		if !st.hasBest {
+			st.best, st.hasBest = val, true
+		}
+	case "GROUP_CONCAT":
+		st.strs = append(st.strs, val.String())
+	}
+}
+
+// returns the value of the aggregate over the solutions added to the state
+func (st *aggregateState) result(agg sparql.AggregateExpression) (turtle.URI, error) {
+	if agg.Distinct {
+		distinct := newAggregateState()
+		for _, val := range st.values {
+			distinct.addValue(agg.Function, val)
+		}
+		agg.Distinct = false
+		return distinct.result(agg)
+	}
+	switch agg.Function {
+	case "COUNT":
+		return turtle.TypedLiteral(strconv.Itoa(st.count), turtle.XSDInteger), nil
+	case "SUM":
+		if st.err != nil {
+			return turtle.URI{}, st.err
+		}
+		if st.integers {
+			return turtle.TypedLiteral(strconv.FormatFloat(st.sum, 'f', -1, 64), turtle.XSDInteger), nil
+		}
+		return turtle.TypedLiteral(strconv.FormatFloat(st.sum, 'f', -1, 64), turtle.XSDDecimal), nil
+	case "AVG":
+		if st.err != nil {
+			return turtle.URI{}, st.err
+		}
+		if st.count == 0 {
+			return turtle.TypedLiteral("0", turtle.XSDInteger), nil
+		}
+		return turtle.TypedLiteral(strconv.FormatFloat(st.sum/float64(st.count), 'f', -1, 64), turtle.XSDDecimal), nil
+	case "MIN", "MAX", "SAMPLE":
+		if !st.hasBest {
+			return turtle.URI{}, errUnbound
+		}
+		return termFromValue(st.best), nil
+	case "GROUP_CONCAT":
+		return turtle.URI{Value: strings.Join(st.strs, agg.Separator)}, nil
+	}
+	return turtle.URI{}, fmt.Errorf("Unknown aggregate %s", agg.Function)
+}
+
+// true if the value is an integer literal or an integer computed from them
+func isIntegerValue(val exprValue) bool {
+	switch val.uri.Datatype {
+	case turtle.XSDInteger, turtle.XSDNamespace + "#int", turtle.XSDNamespace + "#long",
+		turtle.XSDNamespace + "#short", turtle.XSDNamespace + "#nonNegativeInteger",
+		turtle.XSDNamespace + "#positiveInteger", turtle.XSDNamespace + "#unsignedInt",
+		turtle.XSDNamespace + "#unsignedLong":
+		return true
+	}
+	return false
+}
+
+// converts the value of an expression back into a term
+func termFromValue(val exprValue) turtle.URI {
+	if val.uri != (turtle.URI{}) {
+		return val.uri
+	}
+	switch val.kind {
+	case valueNumber:
+		return turtle.TypedLiteral(val.String(), turtle.XSDDecimal)
+	case valueBoolean:
+		return turtle.TypedLiteral(val.String(), turtle.XSDBoolean)
+	case valueDateTime:
+		return turtle.TypedLiteral(val.String(), turtle.XSDDateTime)
+	}
+	return turtle.URI{Value: val.String()}
+}
+
+// prepares an aggregate query for execution. ORDER BY conditions that use
+// aggregates cannot be evaluated on the result rows, so they are bound to
+// hidden columns of the SELECT clause; the returned conditions sort by those
+// columns
+func aggregateOrderBy(q *sparql.Query) (*sparql.Query, []sparql.OrderCondition) {
+	runq := q
+	conditions := make([]sparql.OrderCondition, len(q.OrderBy))
+	for idx, cond := range q.OrderBy {
+		conditions[idx] = cond
+		if !sparql.ContainsAggregate(cond.Expression) {
+			continue
+		}
+		if runq == q {
+			runq = q.Copy()
+			runq.Select.Vars = append([]string{}, q.Select.Vars...)
+			runq.Select.Projections = append([]sparql.Projection{}, q.Select.Projections...)
+		}
+		hidden := fmt.Sprintf("?_order%d", idx)
+		runq.Select.Vars = append(runq.Select.Vars, hidden)
+		runq.Select.Projections = append(runq.Select.Projections, sparql.Projection{Var: hidden, Expression: cond.Expression})
+		conditions[idx].Expression = sparql.VarExpression{Name: hidden}
+	}
+	return runq, conditions
+}
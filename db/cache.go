@@ -15,9 +15,11 @@ type dbcache struct {
 	entityObjectCache map[Key]*Entity
 	entityIndexCache  map[Key]*EntityExtendedIndex
 	predCache         map[Key]*PredicateEntity
-	hit               uint64
-	total             uint64
-	pendingEvict      chan Key
+	// sorted adjacency of predicates for leapfrog joins
+	edgeCache    map[Key]*predicateEdges
+	hit          uint64
+	total        uint64
+	pendingEvict chan Key
 	sync.RWMutex
 }
 
@@ -29,6 +31,7 @@ func newCache(maxsize int) *dbcache {
 		entityIndexCache:  make(map[Key]*EntityExtendedIndex),
 		uriCache:          make(map[Key]turtle.URI),
 		predCache:         make(map[Key]*PredicateEntity),
+		edgeCache:         make(map[Key]*predicateEdges),
 		pendingEvict:      make(chan Key, 1e6),
 	}
 
@@ -42,6 +45,7 @@ func newCache(maxsize int) *dbcache {
 				delete(c.entityObjectCache, hash)
 				delete(c.entityIndexCache, hash)
 				delete(c.predCache, hash)
+				delete(c.edgeCache, hash)
 				//for hash := range c.pendingEvict {
 				//	delete(c.entityObjectCache, hash)
 				//	delete(c.entityIndexCache, hash)
@@ -76,6 +80,18 @@ func (cache *dbcache) evict(hash Key) {
 	cache.pendingEvict <- hash
 }
 
+// drops all cached entities, extended indexes and predicates. Adding or
+// removing edges can change the extended index of many entities, so this
+// happens right away instead of through pendingEvict
+func (cache *dbcache) evictGraph() {
+	cache.Lock()
+	cache.entityObjectCache = make(map[Key]*Entity)
+	cache.entityIndexCache = make(map[Key]*EntityExtendedIndex)
+	cache.predCache = make(map[Key]*PredicateEntity)
+	cache.edgeCache = make(map[Key]*predicateEdges)
+	cache.Unlock()
+}
+
 func (cache *dbcache) setHash(uri turtle.URI, hash Key) {
 	cache.Lock()
 	cache.entityHashCache[uri] = hash
@@ -137,3 +153,17 @@ func (cache *dbcache) setPredicateByHash(hash Key, pred *PredicateEntity) {
 	cache.predCache[hash] = pred
 	cache.Unlock()
 }
+
+func (cache *dbcache) getPredicateEdgesByHash(hash Key) (*predicateEdges, bool) {
+	cache.RLock()
+	defer cache.RUnlock()
+	edges, found := cache.edgeCache[hash]
+	cache.markHitOrMiss(found)
+	return edges, found
+}
+
+func (cache *dbcache) setPredicateEdgesByHash(hash Key, edges *predicateEdges) {
+	cache.Lock()
+	cache.edgeCache[hash] = edges
+	cache.Unlock()
+}
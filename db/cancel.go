@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+)
+
+// QueryCanceledError is returned when a query is canceled, or runs past its
+// deadline, before it completes
+type QueryCanceledError struct {
+	// context.Canceled or context.DeadlineExceeded
+	Err error
+}
+
+func (e *QueryCanceledError) Error() string {
+	return "Query canceled: " + e.Err.Error()
+}
+
+// true if the query ran past its deadline
+func (e *QueryCanceledError) Timeout() bool {
+	return e.Err == context.DeadlineExceeded
+}
+
+// returns a QueryCanceledError if the context is done
+func canceled(goctx context.Context) error {
+	if goctx == nil {
+		return nil
+	}
+	select {
+	case <-goctx.Done():
+		return &QueryCanceledError{Err: goctx.Err()}
+	default:
+		return nil
+	}
+}
+
+// bounds the context of a query by the QueryTimeout of the config
+func (hod *HodDB) withQueryTimeout(goctx context.Context) (context.Context, context.CancelFunc) {
+	if hod.cfg.QueryTimeout > 0 {
+		return context.WithTimeout(goctx, hod.cfg.QueryTimeout)
+	}
+	return context.WithCancel(goctx)
+}
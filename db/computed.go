@@ -0,0 +1,49 @@
+package db
+
+import (
+	"encoding/binary"
+
+	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
+)
+
+// computedValues holds the values computed while running a query that are
+// not in the graph, so that they can be stored in a Relation like any other
+// value. Hashes of the graph only use the lower 4 bytes of the key (see
+// hashURI), so computed values get keys with a non-zero upper half
+type computedValues struct {
+	keys map[turtle.URI]Key
+	uris map[Key]turtle.URI
+}
+
+func newComputedValues() *computedValues {
+	return &computedValues{
+		keys: make(map[turtle.URI]Key),
+		uris: make(map[Key]turtle.URI),
+	}
+}
+
+func isComputedKey(key Key) bool {
+	return binary.LittleEndian.Uint32(key[4:]) != 0
+}
+
+// returns the key of the value, assigning it a new one if needed
+func (cv *computedValues) getKey(uri turtle.URI) Key {
+	if key, found := cv.keys[uri]; found {
+		return key
+	}
+	var key Key
+	binary.LittleEndian.PutUint32(key[4:], uint32(len(cv.keys)+1))
+	cv.keys[uri] = key
+	cv.uris[key] = uri
+	return key
+}
+
+func (cv *computedValues) getURI(key Key) (turtle.URI, error) {
+	if cv != nil {
+		if uri, found := cv.uris[key]; found {
+			return uri, nil
+		}
+	}
+	return turtle.URI{}, errors.Errorf("Could not get computed value for %v", key)
+}
@@ -1,7 +1,13 @@
 package db
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gtfierro/btree"
+	sparql "github.com/gtfierro/hod/lang/ast"
 
 	"github.com/pkg/errors"
 )
@@ -26,12 +32,14 @@ type queryContext struct {
 	db *DB
 	// embedded query plan
 	*queryPlan
+	// called after each operation of the plan, for EXPLAIN ANALYZE
+	trace func(idx int, op operation, elapsed time.Duration)
 }
 
-func newQueryContext(plan *queryPlan, db *DB) (*queryContext, error) {
+func newQueryContext(goctx context.Context, plan *queryPlan, db *DB) (*queryContext, error) {
 	variablePosition := make(map[string]int)
 	definitions := make(map[string]*keymap)
-	for idx, variable := range plan.query.Variables {
+	for idx, variable := range plan.variables() {
 		variablePosition[variable] = idx
 	}
 
@@ -44,13 +52,115 @@ func newQueryContext(plan *queryPlan, db *DB) (*queryContext, error) {
 		variablePosition: variablePosition,
 		definitions:      definitions,
 		selectVars:       plan.selectVars,
-		rel:              NewRelation(plan.query.Variables),
+		rel:              NewRelation(plan.variables()),
 		db:               db,
 		queryPlan:        plan,
-		t:                &traversal{snap, db.cache},
+		t:                &traversal{under: snap, cache: db.cache, computed: newComputedValues(), goctx: goctx},
 	}, nil
 }
 
+// creates a context for evaluating a nested group (e.g. an OPTIONAL) of the
+// query that runs on the same snapshot as this one. The variables in [bound]
+// are seeded with the given rows of values
+func (ctx *queryContext) newSubContext(plan *queryPlan, bound []string, rows [][]Key) *queryContext {
+	sub := &queryContext{
+		variablePosition: make(map[string]int),
+		definitions:      make(map[string]*keymap),
+		selectVars:       plan.selectVars,
+		rel:              NewRelation(plan.variables()),
+		db:               ctx.db,
+		queryPlan:        plan,
+		t:                ctx.t,
+	}
+	for idx, variable := range plan.variables() {
+		sub.variablePosition[variable] = idx
+	}
+	if rows == nil {
+		return sub
+	}
+	sub.seed(bound, rows)
+	return sub
+}
+
+// adds the rows of values for vars to the (empty) relation before any
+// operation runs, and defines each variable by the values it has in them.
+// Values that are not in the graph cannot match the triples of the plan, so
+// rows that have one for a variable of the triples are dropped
+func (ctx *queryContext) seed(vars []string, values [][]Key) {
+	kept := make([][]Key, 0, len(values))
+rowLoop:
+	for _, row := range values {
+		for idx, varname := range vars {
+			if _, used := ctx.dg.variables[varname]; used && isComputedKey(row[idx]) {
+				continue rowLoop
+			}
+		}
+		kept = append(kept, row)
+	}
+	values = kept
+	ctx.rel.addRows(vars, values)
+	for idx, varname := range vars {
+		definition := newKeymap()
+		for _, row := range values {
+			definition.Add(row[idx])
+		}
+		ctx.definitions[varname] = definition
+		ctx.markJoined(varname)
+	}
+}
+
+// seeds the context with the rows of a VALUES block. Values that are not in
+// the graph get keys that are only valid for this query, like the values of
+// a BIND
+func (ctx *queryContext) seedValues(values sparql.ValuesClause) error {
+	rows, err := ctx.valuesKeys(values)
+	if err != nil {
+		return err
+	}
+	ctx.seed(values.Vars, rows)
+	return nil
+}
+
+// returns the keys of the rows of a VALUES block
+func (ctx *queryContext) valuesKeys(values sparql.ValuesClause) ([][]Key, error) {
+	rows := make([][]Key, len(values.Rows))
+	for rowIdx, row := range values.Rows {
+		rows[rowIdx] = make([]Key, len(row))
+		for idx, uri := range row {
+			key, err := ctx.t.getValueKey(uri)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Could not resolve %s in VALUES", uri)
+			}
+			rows[rowIdx][idx] = key
+		}
+	}
+	return rows, nil
+}
+
+// adds the rows of a relation of variables that are not in this context's
+// relation yet. The first operation fills the empty relation; an operation
+// after that starts a group of terms that shares no variables with the terms
+// before it, so every existing row is paired with every new row. Cross
+// products larger than the configured limit are an error
+func (ctx *queryContext) extend(other *Relation) error {
+	if !ctx.rel.populated {
+		ctx.rel.union(other)
+		return nil
+	}
+	size := len(ctx.rel.rows) * len(other.rows)
+	if limit := ctx.db.maxCrossProduct; limit > 0 && size > limit {
+		return errors.Errorf("Cross product of %d and %d rows is larger than the limit of %d; the groups of terms in the query share no variables", len(ctx.rel.rows), len(other.rows), limit)
+	}
+	ctx.rel.join(other, nil, ctx)
+	ctx.rel.reindex()
+	return nil
+}
+
+// returns true if the variable has been bound by an operation in this context
+func (ctx *queryContext) bound(varname string) bool {
+	return ctx.defined(varname) || ctx.hasJoined(varname)
+}
+
 func (ctx *queryContext) cardinalityUnique(varname string) int {
 	if tree, found := ctx.definitions[varname]; found {
 		return tree.Len()
@@ -129,6 +239,36 @@ func (ctx *queryContext) restrictToResolved(varname string, values *keymap) {
 	}
 }
 
+// restricts the definitions of the variables in the relation to the values
+// that remain in its rows. Used after rows have been removed from the relation
+func (ctx *queryContext) restrictToRelation() {
+	for varname := range ctx.definitions {
+		if _, found := ctx.rel.vars[varname]; !found {
+			continue
+		}
+		values := newKeymap()
+		for value := range ctx.rel.multiindex[varname] {
+			values.Add(value)
+		}
+		ctx.definitions[varname] = values
+	}
+}
+
+// defines the variables by the values they have in the relation once an
+// operation has joined them into it, so that later operations on the
+// variables only consider those values
+func (ctx *queryContext) defineFromRelation(vars ...string) {
+	ctx.rel.reindex()
+	for _, varname := range vars {
+		values := newKeymap()
+		for value := range ctx.rel.multiindex[varname] {
+			values.Add(value)
+		}
+		ctx.definitions[varname] = values
+		ctx.markJoined(varname)
+	}
+}
+
 func (ctx *queryContext) dumpRows() {
 	for _, row := range ctx.rel.rows {
 		ctx.dumpRow("", row)
@@ -151,14 +291,82 @@ func (ctx *queryContext) dumpRow(prefix string, row *Row) {
 	fmt.Println(s)
 }
 
-func (ctx *queryContext) getResults() (results []*ResultRow) {
-	results = make([]*ResultRow, len(ctx.rel.rows))
-	var jtest = make(map[uint32]struct{})
-	numRows := 0
-	var positions = make([]int, len(ctx.selectVars))
-	for idx, varname := range ctx.selectVars {
-		positions[idx] = ctx.variablePosition[varname]
+// returns the distinct rows of the selected variables. If limit is
+// non-negative, only the first limit rows in the order of ResultRow.Less are
+// kept, so that the rows of each database and UNION branch can be paged
+// together
+func (ctx *queryContext) getResults(limit int) (results []*ResultRow) {
+	if limit == 0 {
+		return nil
 	}
+	if limit < 0 {
+		results = make([]*ResultRow, 0, len(ctx.rel.rows))
+		ctx.eachResult(0, func(row *ResultRow) bool {
+			results = append(results, row)
+			return true
+		})
+		return results
+	}
+	first := btree.New(4, "")
+	ctx.eachResult(0, func(row *ResultRow) bool {
+		if old := first.ReplaceOrInsert(row); old != nil {
+			finishResultRow(old.(*ResultRow))
+		}
+		if first.Len() > limit {
+			finishResultRow(first.DeleteMax().(*ResultRow))
+		}
+		return true
+	})
+	results = make([]*ResultRow, 0, first.Len())
+	first.Ascend(func(i btree.Item) bool {
+		results = append(results, i.(*ResultRow))
+		return true
+	})
+	return results
+}
+
+// returns the distinct rows of the selected variables from offset to
+// offset+limit in the order of their keys. Only the rows of the page are
+// decoded, so the page of a single database and UNION branch is found
+// without decoding the rest of its rows
+func (ctx *queryContext) getPage(offset, limit int) (results []*ResultRow) {
+	if limit == 0 {
+		return nil
+	}
+	positions := ctx.selectPositions()
+	rows := ctx.rel.rows
+	sort.Slice(rows, func(i, j int) bool {
+		for _, pos := range positions {
+			if left, right := rows[i].valueAt(pos), rows[j].valueAt(pos); left != right {
+				return left.LessThan(right)
+			}
+		}
+		return false
+	})
+	ctx.eachResult(offset, func(row *ResultRow) bool {
+		results = append(results, row)
+		return len(results) < limit
+	})
+	return results
+}
+
+// the positions in the rows of the selected variables of this branch
+func (ctx *queryContext) selectPositions() []int {
+	var positions = make([]int, 0, len(ctx.selectVars))
+	for _, varname := range ctx.selectVars {
+		if pos, found := ctx.variablePosition[varname]; found {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// calls fn with each distinct row of the selected variables, which is only
+// decoded once it is reached, until fn returns false. The first skip rows
+// are passed over without being decoded. fn owns the row
+func (ctx *queryContext) eachResult(skip int, fn func(*ResultRow) bool) {
+	var jtest = make(map[uint32]struct{})
+	var positions = ctx.selectPositions()
 rowIter:
 	for _, row := range ctx.rel.rows {
 		hash := hashRowWithPos(row, positions)
@@ -167,21 +375,40 @@ rowIter:
 		}
 		jtest[hash] = struct{}{}
 
+		for _, varname := range ctx.selectVars {
+			pos, found := ctx.variablePosition[varname]
+			// unbound values are only allowed for the variables of an
+			// OPTIONAL group
+			if found && row.valueAt(pos) == emptyKey && !ctx.optionalVars[varname] {
+				continue rowIter
+			}
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+
 		resultrow := getResultRow(len(ctx.selectVars))
 		for idx, varname := range ctx.selectVars {
-			val := row.valueAt(ctx.variablePosition[varname])
+			pos, found := ctx.variablePosition[varname]
+			if !found {
+				// the variable does not occur in this branch of a UNION
+				continue
+			}
+			val := row.valueAt(pos)
 			if val == emptyKey {
-				continue rowIter
+				// unbound value from an OPTIONAL group
+				continue
 			}
 			var err error
-			resultrow.row[idx], err = ctx.t.getURI(row.valueAt(ctx.variablePosition[varname]))
+			resultrow.row[idx], err = ctx.t.getURI(val)
 			if err != nil {
 				panic(err)
 			}
 		}
-		results[numRows] = resultrow
-		numRows++
 		row.release()
+		if !fn(resultrow) {
+			return
+		}
 	}
-	return results[:numRows]
 }
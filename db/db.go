@@ -34,6 +34,8 @@ type DB struct {
 	graphDB *leveldb.DB
 	// extended index DB
 	extendedDB *leveldb.DB
+	// store entity primary key + link key => link value
+	linkDB *leveldb.DB
 	// store relationships and their inverses
 	relationships map[turtle.URI]turtle.URI
 	relLock       sync.RWMutex
@@ -51,7 +53,16 @@ type DB struct {
 	// cache for query results
 	queryCache        *freecache.Cache
 	queryCacheEnabled bool
-	loading           bool
+	// the number of transactions committed since the database was opened
+	generation uint64
+	loading    bool
+	// the largest cross product a query may compute; 0 is unlimited
+	maxCrossProduct int
+	// whether cyclic patterns are joined with a leapfrog triejoin
+	leapfrogJoin bool
+	// statistics about the graph for the query planner
+	stats     *graphStats
+	statsLock sync.RWMutex
 
 	cache *dbcache
 
@@ -96,6 +107,11 @@ func newDB(name string, cfg *config.Config) (*DB, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "Could not open predDB file %s", predDBPath)
 	}
+	linkDBPath := path + "/db-links"
+	linkDB, err := leveldb.OpenFile(linkDBPath, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not open linkDB file %s", linkDBPath)
+	}
 
 	mapping := bleve.NewIndexMapping()
 	var index bleve.Index
@@ -115,6 +131,7 @@ func newDB(name string, cfg *config.Config) (*DB, error) {
 		pkDB:                   pkDB,
 		graphDB:                graphDB,
 		predDB:                 predDB,
+		linkDB:                 linkDB,
 		predIndex:              make(map[turtle.URI]*PredicateEntity),
 		relationships:          make(map[turtle.URI]turtle.URI),
 		transitiveEdges:        make(map[turtle.URI]struct{}),
@@ -125,6 +142,9 @@ func newDB(name string, cfg *config.Config) (*DB, error) {
 		showOperationLatencies: cfg.ShowOperationLatencies,
 		showQueryLatencies:     cfg.ShowQueryLatencies,
 		queryCacheEnabled:      !cfg.DisableQueryCache,
+		maxCrossProduct:        cfg.MaxCrossProduct,
+		leapfrogJoin:           !cfg.DisableLeapfrogJoin,
+		stats:                  newGraphStats(),
 		loading:                false,
 		textidx:                index,
 		cache:                  newCache(16),
@@ -178,6 +198,10 @@ func newDB(name string, cfg *config.Config) (*DB, error) {
 		}
 	}
 
+	if err := db.loadStats(); err != nil {
+		return nil, err
+	}
+
 	// load in Brick
 	if cfg.ReloadOntologies {
 		p := turtle.GetParser()
@@ -271,6 +295,7 @@ func (db *DB) Close() {
 	checkError(db.predDB.Close())
 	checkError(db.graphDB.Close())
 	checkError(db.extendedDB.Close())
+	checkError(db.linkDB.Close())
 	checkError(db.textidx.Close())
 }
 
@@ -291,6 +316,21 @@ func (db *DB) buildTextIndex(dataset turtle.DataSet) error {
 	return nil
 }
 
+// removes the classes that are no longer declared by the triples from the text
+// index
+func (db *DB) removeFromTextIndex(dataset turtle.DataSet) error {
+	b := db.textidx.NewBatch()
+	for _, triple := range dataset.Triples {
+		if triple.Predicate.String() == "http://www.w3.org/1999/02/22-rdf-syntax-ns#type" && triple.Object.String() == "http://www.w3.org/2002/07/owl#Class" && triple.Subject.Namespace != "" {
+			b.Delete(triple.Subject.String())
+		}
+	}
+	if err := db.textidx.Batch(b); err != nil {
+		return errors.Wrap(err, "Could not remove from text index")
+	}
+	return nil
+}
+
 func (db *DB) insertEntity(entity turtle.URI, hashdest []byte) error {
 	// check if we've inserted Subject already
 	if exists, err := db.entityDB.Has(entity.Bytes(), nil); err == nil && exists {
@@ -347,9 +387,11 @@ func (db *DB) saveIndexes() error {
 	}
 
 	ri := make(RelshipIndex)
+	db.relLock.RLock()
 	for uri, uri2 := range db.relationships {
 		ri[uri.String()] = uri2.String()
 	}
+	db.relLock.RUnlock()
 
 	if err := msgp.Encode(f, ri); err != nil {
 		return err
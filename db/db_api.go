@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -28,7 +29,7 @@ func (db *DB) runQueryString(q string) ([]*ResultRow, queryStats, error) {
 		e := errors.Wrap(err, "Could not parse hod query")
 		log.Error(e)
 		return rows, stats, e
-	} else if rows, stats, err = db.runQuery(q); err != nil {
+	} else if rows, stats, err = db.runQuery(context.Background(), q); err != nil {
 		e := errors.Wrap(err, "Could not complete hod query")
 		log.Error(e)
 		return rows, stats, e
@@ -37,71 +38,82 @@ func (db *DB) runQueryString(q string) ([]*ResultRow, queryStats, error) {
 	}
 }
 
-func (db *DB) runQuery(q *sparql.Query) ([]*ResultRow, queryStats, error) {
+func (db *DB) runQuery(goctx context.Context, q *sparql.Query) ([]*ResultRow, queryStats, error) {
 	var result []*ResultRow
+	limit := rowLimit(q)
+	stats, err := db.runQueryWith(goctx, q, func(ctx *queryContext) (int, error) {
+		results := ctx.getResults(limit)
+		result = append(result, results...)
+		return len(results), nil
+	})
+	return result, stats, err
+}
 
+// runs a query whose rows are paged by this database alone, because it has a
+// single UNION branch and no ORDER BY, and returns only the rows of its page
+func (db *DB) runQueryPage(goctx context.Context, q *sparql.Query) ([]*ResultRow, queryStats, error) {
+	var result []*ResultRow
+	stats, err := db.runQueryWith(goctx, q, func(ctx *queryContext) (int, error) {
+		result = ctx.getPage(q.Offset, q.Limit)
+		return len(result), nil
+	})
+	return result, stats, err
+}
+
+// runs an aggregate query and adds its solutions to the groups
+func (db *DB) runGroupQuery(goctx context.Context, q *sparql.Query, groups *groupSet) (queryStats, error) {
+	return db.runQueryWith(goctx, q, groups.addSolutions)
+}
+
+// evaluates the WHERE clause of the query and calls collect with the query
+// context of each UNION branch while its snapshot is still open. Calls to
+// collect are serialized; it returns the number of results it took
+func (db *DB) runQueryWith(goctx context.Context, q *sparql.Query, collect func(*queryContext) (int, error)) (queryStats, error) {
 	whereStart := time.Now()
 
 	// expand out the prefixes
-	q.IterTriples(func(triple sparql.Triple) sparql.Triple {
-		triple.Subject = db.expand(triple.Subject)
-		triple.Object = db.expand(triple.Object)
-		for idx2, pred := range triple.Predicates {
-			triple.Predicates[idx2].Predicate = db.expand(pred.Predicate)
-		}
-		return triple
+	q.MapURIs(func(uri turtle.URI) turtle.URI {
+		return db.expandPrefixed(q.Prefixes, uri)
 	})
 
-	// expand the graphgroup unions
-	var ors [][]sparql.Triple
-	if q.Where.GraphGroup != nil {
-		for _, group := range q.Where.GraphGroup.Expand() {
-			newterms := make([]sparql.Triple, len(q.Where.Terms))
-			copy(newterms, q.Where.Terms)
-			ors = append(ors, append(newterms, group...))
-		}
-	}
-
-	// if we have terms that are part of a set of OR statements, then we run
-	// parallel queries for each fully-elaborated "branch" or the OR statement,
-	// and then merge the results together at the end
+	// if we have terms that are part of a set of OR statements, or VALUES
+	// rows with UNDEF, then we run parallel queries for each fully-elaborated
+	// "branch", and then merge the results together at the end
 	var stats queryStats
-	if len(ors) > 0 {
+	branches := unionBranches(q)
+	if len(branches) > 1 {
 		var rowLock sync.Mutex
 		var wg sync.WaitGroup
 		var queryErr error
-		wg.Add(len(ors))
-		for _, group := range ors {
-			tmpQuery := q.CopyWithNewTerms(group)
-			tmpQuery.PopulateVars()
-			if tmpQuery.Select.AllVars {
-				tmpQuery.Select.Vars = tmpQuery.Variables
-			}
-
+		lockedCollect := func(ctx *queryContext) (int, error) {
+			rowLock.Lock()
+			defer rowLock.Unlock()
+			return collect(ctx)
+		}
+		wg.Add(len(branches))
+		for _, branch := range branches {
 			go func(q *sparql.Query) {
-				results, _stats, err := db.getQueryResults(&tmpQuery)
+				_stats, err := db.getQueryResults(goctx, q, lockedCollect)
 				rowLock.Lock()
 				if err != nil {
 					queryErr = err
 				} else {
 					stats.merge(_stats)
-					result = append(result, results...)
 				}
 				rowLock.Unlock()
 				wg.Done()
-			}(&tmpQuery)
+			}(branch)
 		}
 		wg.Wait()
 		if queryErr != nil {
-			return result, stats, queryErr
+			return stats, queryErr
 		}
 	} else {
-		results, _stats, err := db.getQueryResults(q)
+		_stats, err := db.getQueryResults(goctx, branches[0], collect)
 		stats = _stats
 		if err != nil {
-			return result, stats, err
+			return stats, err
 		}
-		result = append(result, results...)
 	}
 	stats.WhereTime = time.Since(whereStart)
 	//logrus.WithFields(logrus.Fields{
@@ -112,7 +124,54 @@ func (db *DB) runQuery(q *sparql.Query) ([]*ResultRow, queryStats, error) {
 	//	"Results": stats.NumResults,
 	//	"Total":   time.Since(whereStart),
 	//}).Info("Query")
-	return result, stats, nil
+	return stats, nil
+}
+
+// returns a query for each fully-elaborated branch of the UNIONs in the WHERE
+// clause of q, and for each set of variables that the rows of its VALUES
+// leave UNDEF, or just q if it has neither
+func unionBranches(q *sparql.Query) []*sparql.Query {
+	var branches []*sparql.Query
+	if q.Where.GraphGroup == nil {
+		branches = []*sparql.Query{q}
+	} else {
+		branches = expandUnions(q)
+	}
+	if len(q.Where.Values.Vars) == 0 {
+		return branches
+	}
+	partitions := q.Where.Values.Partition()
+	if len(partitions) == 1 && len(partitions[0].Vars) == len(q.Where.Values.Vars) {
+		return branches
+	}
+	var split []*sparql.Query
+	for _, branch := range branches {
+		for _, values := range partitions {
+			query := branch.Copy()
+			query.Where.Values = values
+			query.PopulateVars()
+			split = append(split, query)
+		}
+	}
+	return split
+}
+
+// returns a query for each fully-elaborated branch of the UNIONs in the WHERE
+// clause of q
+func expandUnions(q *sparql.Query) []*sparql.Query {
+	var branches []*sparql.Query
+	for _, group := range q.Where.GraphGroup.Expand() {
+		branch := q.CopyWithNewTerms(append(append([]sparql.Triple{}, q.Where.Terms...), group.Terms...))
+		branch.Where.Filters = append(append([]sparql.Filter{}, q.Where.Filters...), group.Filters...)
+		branch.Where.Optionals = append(append([]sparql.GraphGroup{}, q.Where.Optionals...), group.Optionals...)
+		branch.Where.Minus = append(append([]sparql.GraphGroup{}, q.Where.Minus...), group.Minus...)
+		branch.Where.Exists = append(append([]sparql.ExistsGroup{}, q.Where.Exists...), group.Exists...)
+		branch.Where.Binds = append(append([]sparql.Bind{}, q.Where.Binds...), group.Binds...)
+		branch.Where.Subqueries = append(append([]sparql.Query{}, q.Where.Subqueries...), group.Subqueries...)
+		branch.PopulateVars()
+		branches = append(branches, &branch)
+	}
+	return branches
 }
 
 // takes a query and returns a DOT representation to visualize
@@ -137,7 +196,7 @@ func (db *DB) queryToDOT(querystring string) (string, error) {
 	//		for _, filter := range filters {
 	//			var parts []string
 	//			for _, p := range filter.Path {
-	//				parts = append(parts, fmt.Sprintf("%s%s", p.Predicate, p.Pattern))
+	//				parts = append(parts, p.String())
 	//			}
 	//			line := fmt.Sprintf("\"%s\" -> \"%s\" [label=\"%s\"];\n", filter.Subject, filter.Object, strings.Join(parts, "/"))
 	//			if !strings.Contains(dot, line) {
@@ -150,7 +209,7 @@ func (db *DB) queryToDOT(querystring string) (string, error) {
 	for _, filter := range q.Where.Terms {
 		var parts []string
 		for _, p := range filter.Predicates {
-			parts = append(parts, fmt.Sprintf("%s%s", p.Predicate, p.Pattern))
+			parts = append(parts, p.String())
 		}
 		line := fmt.Sprintf("\"%s\" -> \"%s\" [label=\"%s\"];\n", filter.Subject, filter.Object, strings.Join(parts, "/"))
 		if !strings.Contains(dot, line) {
@@ -241,7 +300,7 @@ func (db *DB) queryToClassDOT(querystring string) (string, error) {
 		return
 	}
 
-	result, _, err := db.runQuery(q)
+	result, _, err := db.runQuery(context.Background(), q)
 	if err != nil {
 		return "", err
 	}
@@ -292,11 +351,32 @@ func (db *DB) abbreviate(uri turtle.URI) string {
 }
 
 func (db *DB) expand(uri turtle.URI) turtle.URI {
+	return db.expandPrefixed(nil, uri)
+}
+
+// expands the prefix of the URI using the prefixes declared by a query, which
+// take priority over the namespaces of the database
+func (db *DB) expandPrefixed(prefixes map[string]string, uri turtle.URI) turtle.URI {
+	lookup := func(prefix string) (string, bool) {
+		if full, found := prefixes[prefix]; found {
+			return full, true
+		}
+		full, found := db.namespaces[prefix]
+		return full, found
+	}
 	if !strings.HasPrefix(uri.Value, "?") {
-		if full, found := db.namespaces[uri.Namespace]; found {
+		if full, found := lookup(uri.Namespace); found {
 			uri.Namespace = full
 		}
 	}
+	// datatypes of literals are IRIs that may be prefixed too
+	if parts := strings.SplitN(uri.Datatype, "#", 2); len(parts) == 2 {
+		if full, found := lookup(parts[0]); found {
+			uri.Datatype = full + "#" + parts[1]
+		} else if parts[0] == "xsd" {
+			uri.Datatype = turtle.XSDNamespace + "#" + parts[1]
+		}
+	}
 	return uri
 }
 
@@ -328,7 +408,7 @@ func (db *DB) search(q string, n int) ([]string, error) {
 //
 // First we "clean" these by making sure that they have their full
 // namespaces rather than the prefix
-func (db *DB) getQueryResults(q *sparql.Query) ([]*ResultRow, queryStats, error) {
+func (db *DB) getQueryResults(goctx context.Context, q *sparql.Query, collect func(*queryContext) (int, error)) (queryStats, error) {
 	var stats queryStats
 
 	if db.showQueryPlan {
@@ -339,10 +419,11 @@ func (db *DB) getQueryResults(q *sparql.Query) ([]*ResultRow, queryStats, error)
 
 	// form dependency graph and build query plan out of it
 	//dg := db.sortQueryTerms(q)
-	dg := makeDependencyGraph(q)
+	// variables with VALUES already have values when the plan starts
+	dg := makeDependencyGraph(q, q.Where.Values.Vars)
 	qp, err := db.formQueryPlan(dg, q)
 	if err != nil {
-		return nil, stats, err
+		return stats, err
 	}
 
 	if db.showDependencyGraph {
@@ -362,37 +443,62 @@ func (db *DB) getQueryResults(q *sparql.Query) ([]*ResultRow, queryStats, error)
 	}
 
 	runStart := time.Now()
-	ctx, err := db.executeQueryPlan(qp)
+	if err := canceled(goctx); err != nil {
+		return stats, err
+	}
+	ctx, err := db.executeQueryPlan(goctx, qp)
 	defer ctx.t.under.done()
 	if err != nil {
-		return nil, stats, err
+		return stats, err
 	}
 	since := time.Since(runStart)
 
 	runStart = time.Now()
-	results := ctx.getResults()
+	stats.NumResults, err = collect(ctx)
 	stats.WhereTime = since
 	stats.ExpandTime = time.Since(runStart)
-	stats.NumResults = len(results)
 
-	return results, stats, err
+	return stats, err
 }
 
-func (db *DB) executeQueryPlan(plan *queryPlan) (*queryContext, error) {
-	ctx, err := newQueryContext(plan, db)
+func (db *DB) executeQueryPlan(goctx context.Context, plan *queryPlan) (*queryContext, error) {
+	ctx, err := newQueryContext(goctx, plan, db)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not get snapshot")
 	}
+	return ctx, ctx.execute()
+}
+
+// seeds the context with the VALUES of its query and runs the operations of
+// its plan
+func (ctx *queryContext) execute() error {
+	where := ctx.query.Where
+	if len(where.Values.Vars) > 0 {
+		if err := ctx.seedValues(where.Values); err != nil {
+			return err
+		}
+	} else if len(where.Terms) == 0 && len(where.Subqueries) == 0 && len(where.Optionals) == 0 && where.GraphGroup == nil {
+		// BINDs, FILTERs, MINUS and EXISTS on their own extend or remove the
+		// single empty solution
+		ctx.seed(nil, [][]Key{{}})
+	}
 
-	for _, op := range ctx.operations {
+	for idx, op := range ctx.operations {
+		if err := ctx.t.canceled(); err != nil {
+			return err
+		}
 		now := time.Now()
 		err := op.run(ctx)
-		if db.showOperationLatencies {
+		if ctx.db.showOperationLatencies {
 			fmt.Println(op, time.Since(now))
 		}
+		if ctx.trace != nil {
+			ctx.trace(idx, op, time.Since(now))
+		}
 		if err != nil {
-			return ctx, err
+			return err
 		}
 	}
-	return ctx, nil
+	// the last operation may have been cut short
+	return ctx.t.canceled()
 }
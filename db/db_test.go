@@ -1,13 +1,22 @@
 package db
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gtfierro/hod/config"
 	query "github.com/gtfierro/hod/lang"
+	sparql "github.com/gtfierro/hod/lang/ast"
 	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
 	logrus "github.com/sirupsen/logrus"
 )
 
@@ -60,6 +69,62 @@ func TestDBQuery(t *testing.T) {
 				{"?x": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#label"), "?y": turtle.URI{Value: "Room 1"}},
 			},
 		},
+		{
+			"SELECT ?x ?y FROM test WHERE { VALUES ?x { bldg:ahu_1 bldg:vav_1 } ?x bf:feeds ?y };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+			},
+		},
+		{
+			"SELECT ?y FROM test WHERE { VALUES ?x { bldg:vav_1 bldg:vav_404 } ?x bf:feeds ?y };",
+			[]ResultMap{{"?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { VALUES (?x ?y) { (bldg:ahu_1 bldg:vav_1) (bldg:ahu_1 bldg:hvaczone_1) } ?x bf:feeds ?y };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?t FROM test WHERE { ?x rdf:type ?t . VALUES ?x { bldg:room_1 bldg:floor_1 } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?t": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Room")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#floor_1"), "?t": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Floor")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { VALUES ?x { bldg:ahu_1 bldg:room_1 bldg:vav_1 } { ?x rdf:type brick:AHU } UNION { ?x rdf:type brick:Room } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { VALUES ?x { bldg:ahu_1 } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			// values that are not in the graph only fail to match triples
+			"SELECT ?x ?tag FROM test WHERE { ?x rdf:type brick:AHU . VALUES ?tag { \"north\" } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?tag": turtle.URI{Value: "north"}}},
+		},
+		{
+			"SELECT ?x ?l FROM test WHERE { ?x rdfs:label ?l . VALUES ?l { \"Room 1\" \"Room 2\" } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?l": turtle.URI{Value: "Room 1"}}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y . VALUES (?x ?y) { (bldg:ahu_1 UNDEF) (UNDEF bldg:hvaczone_1) } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")},
+			},
+		},
+		{
+			"SELECT ?x ?tag FROM test WHERE { ?x rdf:type brick:AHU . VALUES ?tag { \"north\" UNDEF } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?tag": turtle.URI{Value: "north"}},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+			},
+		},
 		{
 			"SELECT ?x ?y FROM test WHERE { ?r rdf:type brick:Room . ?r ?x ?y };",
 			[]ResultMap{
@@ -123,45 +188,1582 @@ func TestDBQuery(t *testing.T) {
 			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?x bf:isFedBy* ?ahu };",
 			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
 		},
+		{
+			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds{1,2} ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds{2} ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds{,1} ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds{3,} ?x };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?ahu rdf:type brick:AHU . ?x bf:isFedBy{2,5} ?ahu };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds{2} ?y };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
 		{
 			"SELECT ?vav ?room FROM test WHERE { ?vav rdf:type brick:VAV . ?room rdf:type brick:Room . ?zone rdf:type brick:HVAC_Zone . ?vav bf:feeds+ ?zone . ?room bf:isPartOf ?zone }; ",
 			[]ResultMap{{"?room": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?vav": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
 		},
 		{
-			"SELECT ?sensor FROM test WHERE { ?sensor rdf:type/rdfs:subClassOf* brick:Zone_Temperature_Sensor };",
-			[]ResultMap{{"?sensor": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")}},
+			"SELECT ?sensor FROM test WHERE { ?sensor rdf:type/rdfs:subClassOf* brick:Zone_Temperature_Sensor };",
+			[]ResultMap{{"?sensor": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")}},
+		},
+		{
+			"SELECT ?s ?p FROM test WHERE { ?s ?p brick:Zone_Temperature_Sensor . ?s rdfs:subClassOf brick:Zone_Temperature_Sensor };",
+			[]ResultMap{
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Average_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Coldest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Highest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Lowest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Warmest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#VAV_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#AHU_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#FCU_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Zone_Air_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . FILTER(regex(?x, \"vav\")) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y . FILTER(?y != bldg:vav_1) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?r FROM test WHERE { ?r rdf:type brick:Room . ?r rdfs:label ?l . FILTER(STRSTARTS(STR(?l), \"Room\") && ?l != \"Room 2\") };",
+			[]ResultMap{{"?r": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x rdf:type brick:VAV FILTER(?x = bldg:ahu_1) };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { { ?x bf:feeds ?y } UNION { ?x bf:isPointOf ?y } FILTER(STRSTARTS(STR(?x), \"http://buildsys.org/ontologies/building_example#z\")) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")}},
+		},
+		{
+			"SELECT ?x ?l FROM test WHERE { ?x rdf:type brick:Room . OPTIONAL { ?x rdfs:label ?l } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?l": turtle.URI{Value: "Room 1"}}},
+		},
+		{
+			"SELECT ?x ?u FROM test WHERE { ?x rdf:type brick:Room . OPTIONAL { ?x bf:isPartOf ?u . ?u rdf:type brick:AHU } OPTIONAL { ?x rdfs:label ?u } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?u": turtle.URI{Value: "Room 1"}}},
+		},
+		{
+			"SELECT ?x ?u FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x bf:feeds ?u . ?u rdf:type brick:VAV } OPTIONAL { ?x bf:feeds ?u } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?u": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?u": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x bf:feeds ?u . ?u rdf:type brick:VAV } MINUS { ?x bf:feeds ?u } };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x ?l FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x rdfs:label ?l } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?p FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?p bf:isPointOf ?x } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?p": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")},
+			},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . OPTIONAL { ?x bf:feeds ?y . FILTER(?y != bldg:vav_1) } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?p bf:isPointOf ?x } FILTER(!BOUND(?p)) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . FILTER NOT EXISTS { ?p bf:isPointOf ?x } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . FILTER EXISTS { ?p bf:isPointOf ?x } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . FILTER NOT EXISTS { ?y bf:feeds ?z . FILTER(?z = bldg:hvaczone_1) } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . MINUS { ?x bf:feeds bldg:vav_1 } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			// no shared variables, so nothing is removed
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . MINUS { ?p bf:isPointOf ?q } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { { ?x bf:feeds ?y } UNION { ?x bf:isPointOf ?y } MINUS { ?x rdf:type brick:AHU } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . OPTIONAL { ?x bf:feeds ?y . FILTER NOT EXISTS { ?y bf:feeds bldg:hvaczone_1 } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x ?kind FROM test WHERE { ?x rdf:type brick:AHU . OPTIONAL { BIND(\"AHU\" AS ?kind) } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?kind": turtle.URI{Value: "AHU"}}},
+		},
+		{
+			"SELECT ?x ?tag FROM test WHERE { ?x rdf:type brick:AHU . OPTIONAL { VALUES ?tag { \"north\" } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?tag": turtle.URI{Value: "north"}}},
+		},
+		{
+			"SELECT ?x ?zone FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { VALUES (?x ?zone) { (bldg:vav_1 \"zone 1\") } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?zone": turtle.URI{Value: "zone 1"}}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . MINUS { VALUES ?x { bldg:ahu_1 } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . FILTER NOT EXISTS { VALUES ?y { bldg:vav_1 } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?name FROM test WHERE { ?x rdf:type brick:AHU . BIND(STRAFTER(STR(?x), \"#\") AS ?name) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?name": turtle.URI{Value: "ahu_1"}}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y . BIND(UCASE(STRAFTER(STR(?y), \"#\")) AS ?n) FILTER(?n = \"VAV_1\") };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?z FROM test WHERE { ?x rdf:type brick:AHU . BIND(IRI(REPLACE(STR(?x), \"ahu\", \"vav\")) AS ?z) };",
+			[]ResultMap{{"?z": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?len FROM test WHERE { ?x rdf:type brick:Room . ?x rdfs:label ?l . BIND(STRLEN(CONCAT(?l, \"-\", SUBSTR(LCASE(?l), 1, 4))) AS ?len) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?len": turtle.TypedLiteral("11", turtle.XSDInteger)}},
+		},
+		{
+			// an error in the expression leaves the variable unbound
+			"SELECT ?x ?len FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x rdfs:label ?l } BIND(STRLEN(?l) AS ?len) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}, {"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?kind FROM test WHERE { { ?x rdf:type brick:AHU . BIND(\"ahu\" AS ?kind) } UNION { ?x rdf:type brick:Room . BIND(\"room\" AS ?kind) } };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?kind": turtle.URI{Value: "ahu"}},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?kind": turtle.URI{Value: "room"}},
+			},
+		},
+		{
+			"SELECT ?ahu ?n FROM test WHERE { ?ahu rdf:type brick:AHU . { SELECT ?ahu (COUNT(?vav) AS ?n) WHERE { ?ahu bf:feeds ?vav } GROUP BY ?ahu } };",
+			[]ResultMap{{"?ahu": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?n": turtle.TypedLiteral("1", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?x ?n FROM test WHERE { { SELECT ?x (COUNT(?y) AS ?n) WHERE { ?x bf:feeds+ ?y } GROUP BY ?x } FILTER(?n > 1) };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?n": turtle.TypedLiteral("2", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { { SELECT ?x WHERE { ?x bf:feeds ?z } ORDER BY ?x LIMIT 1 } ?x bf:feeds ?y };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?n FROM test WHERE { ?x rdf:type brick:AHU . { SELECT (COUNT(?r) AS ?n) WHERE { ?r rdf:type brick:Room } } };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?n": turtle.TypedLiteral("1", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x rdf:type brick:AHU . { SELECT ?x WHERE { ?x rdf:type brick:Room } } };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?p FROM test WHERE { bldg:ztemp_1 ?p/bf:feeds bldg:hvaczone_1 };",
+			[]ResultMap{{"?p": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isPointOf")}},
+		},
+		{
+			"SELECT ?p ?z FROM test WHERE { bldg:ahu_1 bf:feeds/?p ?z . ?z rdf:type brick:HVAC_Zone };",
+			[]ResultMap{{"?p": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#feeds"), "?z": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?p ?q FROM test WHERE { bldg:ahu_1 ?p/?q bldg:hvaczone_1 };",
+			[]ResultMap{{"?p": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#feeds"), "?q": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#feeds")}},
+		},
+		{
+			"SELECT ?x ?p FROM test WHERE { ?x rdf:type brick:AHU . ?x bf:feeds/^?p bldg:ztemp_1 };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?p": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isPointOf")}},
+		},
+		{
+			"SELECT ?s ?o FROM test WHERE { ?s ?p/?q ?o . ?s rdf:type brick:Zone_Temperature_Sensor . ?o rdf:type brick:HVAC_Zone };",
+			[]ResultMap{{"?s": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1"), "?o": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")}},
+		},
+		{
+			"SELECT ?r ?p FROM test WHERE { ?r rdf:type brick:Room . ?r bf:isPartOf/?p/bf:hasPoint bldg:ztemp_1 };",
+			[]ResultMap{{"?r": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1"), "?p": turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isFedBy")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x (bf:feeds|bf:isPointOf) ?y };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { bldg:vav_1 ^bf:feeds ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { bldg:hvaczone_1 ^bf:feeds+ ?x };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { brick:Floor ^rdf:type ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#floor_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { bldg:room_1 (bf:isPartOf/^bf:feeds)+ ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x ^bf:isFedBy ?y };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")},
+			},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . ?y rdf:type brick:Room };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . ?y rdf:type brick:Room . ?x bf:feeds ?z . ?y bf:isPartOf ?w };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?y": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { bldg:ahu_1 bf:feeds bldg:vav_1 . ?x rdf:type brick:Room };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x rdf:type brick:Room . bldg:ahu_1 bf:feeds+ bldg:hvaczone_1 };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { bldg:vav_1 bf:feeds bldg:ahu_1 . ?x rdf:type brick:Room };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?x };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds/bf:isFedBy ?x };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+			},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x rdf:type brick:AHU . ?x bf:feeds* ?x };",
+			[]ResultMap{{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x (bf:feeds|bf:isPointOf)+ bldg:hvaczone_1 };",
+			[]ResultMap{
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")},
+				{"?x": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")},
+			},
+		},
+	} {
+		//time.Sleep(100 * time.Millisecond)
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !compareResultMapList(test.results, result.Rows) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+			return
+		}
+	}
+}
+
+func TestDBQueryOrdered(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ahu_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")
+	vav_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")
+	hvaczone_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")
+	for _, test := range []struct {
+		query   string
+		results []ResultMap
+	}{
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y } ORDER BY ?x;",
+			[]ResultMap{{"?x": ahu_1, "?y": vav_1}, {"?x": vav_1, "?y": hvaczone_1}},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y } ORDER BY DESC(?x);",
+			[]ResultMap{{"?x": vav_1, "?y": hvaczone_1}, {"?x": ahu_1, "?y": vav_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } ORDER BY DESC(?y);",
+			[]ResultMap{{"?x": ahu_1}, {"?x": vav_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } ORDER BY ASC(?y) LIMIT 1;",
+			[]ResultMap{{"?x": vav_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } ORDER BY ?x OFFSET 1;",
+			[]ResultMap{{"?x": vav_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } ORDER BY ?x LIMIT 1 OFFSET 1;",
+			[]ResultMap{{"?x": vav_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } ORDER BY ?x OFFSET 2;",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds ?y } LIMIT 0;",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x ?l FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x rdfs:label ?l } } ORDER BY DESC(?x);",
+			[]ResultMap{{"?x": vav_1}, {"?x": ahu_1}},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result.Rows) != len(test.results) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+			continue
+		}
+		for idx, row := range result.Rows {
+			if !compareResultMap(row, test.results[idx]) {
+				t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+				break
+			}
+		}
+	}
+
+	// paging through an ordered result gives back the full result
+	full, err := db.RunQueryString("SELECT ?vav ?ahu FROM soda WHERE { ?vav rdf:type brick:VAV . ?ahu bf:feeds ?vav } ORDER BY ?vav ?ahu;")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var paged []ResultMap
+	for offset := 0; offset < full.Count+50; offset += 50 {
+		page, err := db.RunQueryString(fmt.Sprintf("SELECT ?vav ?ahu FROM soda WHERE { ?vav rdf:type brick:VAV . ?ahu bf:feeds ?vav } ORDER BY ?vav ?ahu LIMIT 50 OFFSET %d;", offset))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		paged = append(paged, page.Rows...)
+	}
+	if len(paged) != len(full.Rows) {
+		t.Errorf("Paged results had %d rows, expected %d", len(paged), len(full.Rows))
+		return
+	}
+	for idx := range paged {
+		if !compareResultMap(paged[idx], full.Rows[idx]) {
+			t.Errorf("Paged row %d was %+v, expected %+v", idx, paged[idx], full.Rows[idx])
+			return
+		}
+	}
+
+	// without ORDER BY, the pages of the rows of every database and UNION
+	// branch are disjoint and add up to the full result. A single database
+	// and branch only decodes the rows of the page
+	for _, unordered := range []string{
+		"NOCACHE SELECT ?x ?y WHERE { { ?x bf:feeds ?y } UNION { ?x bf:hasPoint ?y } }",
+		"NOCACHE SELECT ?x ?y FROM soda WHERE { ?x bf:hasPoint ?y }",
+	} {
+		full, err = db.RunQueryString(unordered + ";")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		seen := make(map[string]bool)
+		for offset := 0; offset < full.Count+10; offset += 10 {
+			page, err := db.RunQueryString(fmt.Sprintf("%s LIMIT 10 OFFSET %d;", unordered, offset))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for _, row := range page.Rows {
+				key := row["?x"].String() + " " + row["?y"].String()
+				if seen[key] {
+					t.Errorf("Row %s is on more than one page of %s", key, unordered)
+				}
+				seen[key] = true
+			}
+			if status, found := page.Databases["soda"]; len(page.Databases) == 1 && found && status.Rows != len(page.Rows) {
+				t.Errorf("Decoded %d rows for a page of %d rows of %s", status.Rows, len(page.Rows), unordered)
+			}
+		}
+		if full.Count < 20 || len(seen) != full.Count {
+			t.Errorf("Pages of %s had %d rows, expected %d", unordered, len(seen), full.Count)
+		}
+	}
+}
+
+func TestDBQueryPrologue(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	room := turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Room")
+	room_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")
+	for _, test := range []struct {
+		query   string
+		results []ResultMap
+	}{
+		{
+			"PREFIX ex: <http://buildsys.org/ontologies/building_example#> SELECT ?x FROM test WHERE { ex:room_1 rdf:type ?x };",
+			[]ResultMap{{"?x": room}},
+		},
+		{
+			// query prefixes take priority over those of the database
+			"PREFIX bldg: <http://example.com/building#> SELECT ?x FROM test WHERE { bldg:room_1 rdf:type ?x };",
+			[]ResultMap{},
+		},
+		{
+			"PREFIX b: <https://brickschema.org/schema/1.0.3/Brick#> SELECT ?x FROM test WHERE { ?x rdf:type ?t . FILTER(?t = b:Room) };",
+			[]ResultMap{{"?x": room_1}},
+		},
+		{
+			"BASE <http://buildsys.org/ontologies/building_example> SELECT ?x FROM test WHERE { <#room_1> rdf:type ?x };",
+			[]ResultMap{{"?x": room}},
+		},
+		{
+			"BASE <http://buildsys.org/ontologies/> PREFIX ex: <building_example#> SELECT ?x FROM test WHERE { ex:room_1 a ?x };",
+			[]ResultMap{{"?x": room}},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if !compareResultMapList(result.Rows, test.results) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+		}
+	}
+
+	// undefined prefixes are an error rather than a silent non-match
+	for _, querystring := range []string{
+		"SELECT ?x FROM test WHERE { ?x rdf:type nope:Room };",
+		"SELECT ?x FROM test WHERE { ?x rdf:type ?t . FILTER(?t = nope:Room) };",
+		"SELECT ?x FROM test WHERE { ?x bldg:area \"1\"^^nope:integer };",
+	} {
+		if _, err := db.RunQueryString(querystring); err == nil {
+			t.Errorf("Query %s should fail with an undefined prefix", querystring)
+		}
+	}
+}
+
+func TestDBUpdate(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// updates modify the graph, so run them against a private copy of the
+	// test building
+	cfg.DBPath = "_testhoddb_update"
+	cfg.Buildings = map[string]string{"test": "testbuildings/example.ttl"}
+	defer os.RemoveAll(cfg.DBPath)
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ahu_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")
+	vav_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")
+	hvaczone_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")
+	ztemp_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#ztemp_1")
+	for _, test := range []struct {
+		query   string
+		results []ResultMap
+	}{
+		{
+			"DELETE { ?x bf:feeds ?y } WHERE { ?x bf:feeds ?y . ?x rdf:type brick:AHU };",
+			nil,
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y };",
+			[]ResultMap{{"?x": vav_1, "?y": hvaczone_1}},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:isFedBy bldg:ahu_1 };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?y FROM test WHERE { bldg:ahu_1 bf:feeds+ ?y };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?x FROM test WHERE { ?x bf:feeds+ bldg:hvaczone_1 };",
+			[]ResultMap{{"?x": vav_1}},
+		},
+		{
+			"INSERT { bldg:ahu_1 bf:feeds bldg:vav_1 } WHERE { };",
+			nil,
+		},
+		{
+			"SELECT ?y FROM test WHERE { bldg:ahu_1 bf:feeds+ ?y };",
+			[]ResultMap{{"?y": vav_1}, {"?y": hvaczone_1}},
+		},
+		{
+			"DELETE { ?z bf:isPointOf ?v } INSERT { ?z bf:isPointOf bldg:ahu_1 } WHERE { ?z bf:isPointOf ?v };",
+			nil,
+		},
+		{
+			"SELECT ?z ?v FROM test WHERE { ?z bf:isPointOf ?v };",
+			[]ResultMap{{"?z": ztemp_1, "?v": ahu_1}},
+		},
+		{
+			"SELECT ?z FROM test WHERE { bldg:vav_1 bf:hasPoint ?z };",
+			[]ResultMap{},
+		},
+		{
+			"DELETE DATA { bldg:ztemp_1 bf:isPointOf bldg:ahu_1 };",
+			nil,
+		},
+		{
+			"SELECT ?z ?v FROM test WHERE { ?z bf:isPointOf ?v };",
+			[]ResultMap{},
+		},
+		// a WHERE clause with only VALUES has a solution for each row
+		{
+			"INSERT { ?z bf:isPointOf ?v } WHERE { VALUES (?z ?v) { (bldg:ztemp_1 bldg:vav_1) } };",
+			nil,
+		},
+		{
+			"SELECT ?z ?v FROM test WHERE { ?z bf:isPointOf ?v };",
+			[]ResultMap{{"?z": ztemp_1, "?v": vav_1}},
+		},
+		{
+			"DELETE { ?z bf:isPointOf ?v } WHERE { BIND(bldg:ztemp_1 AS ?z) BIND(bldg:vav_1 AS ?v) };",
+			nil,
+		},
+		{
+			"SELECT ?z ?v FROM test WHERE { ?z bf:isPointOf ?v };",
+			[]ResultMap{},
+		},
+		// VALUES may create entities that are not in the graph yet
+		{
+			"INSERT { ?a bf:feeds ?b } WHERE { VALUES (?a ?b) { (bldg:ahu_new bldg:vav_1) } };",
+			nil,
+		},
+		{
+			"SELECT ?a FROM test WHERE { ?a bf:feeds bldg:vav_1 };",
+			[]ResultMap{{"?a": ahu_1}, {"?a": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_new")}},
+		},
+		{
+			"DELETE { bldg:ahu_new bf:feeds bldg:vav_1 } WHERE { };",
+			nil,
+		},
+		{
+			"SELECT ?a FROM test WHERE { ?a bf:feeds bldg:vav_1 };",
+			[]ResultMap{{"?a": ahu_1}},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			return
+		}
+		if test.results == nil {
+			continue
+		}
+		if len(result.Rows) != len(test.results) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+			continue
+		}
+		for _, expected := range test.results {
+			found := false
+			for _, row := range result.Rows {
+				if compareResultMap(row, expected) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+				break
+			}
+		}
+	}
+
+	// deleting what was inserted brings the statistics back
+	_testdb, _ := db.dbs.Load("test")
+	testdb := _testdb.(*DB)
+	before := testdb.statistics()
+	if _, err := db.RunQueryString("INSERT { bldg:ahu_9 bf:feeds bldg:vav_9 . bldg:vav_9 rdf:type brick:VAV } WHERE { };"); err != nil {
+		t.Error(err)
+		return
+	}
+	inserted := testdb.statistics()
+	vav := testdb.expand(turtle.ParseURI("brick:VAV"))
+	if inserted.entities != before.entities+2 || inserted.classes[vav] != before.classes[vav]+1 {
+		t.Errorf("Expected 2 more entities and 1 more VAV, got %d and %d", inserted.entities-before.entities, inserted.classes[vav]-before.classes[vav])
+	}
+	if _, err := db.RunQueryString("DELETE DATA { bldg:ahu_9 bf:feeds bldg:vav_9 . bldg:vav_9 rdf:type brick:VAV };"); err != nil {
+		t.Error(err)
+		return
+	}
+	if after := testdb.statistics(); !reflect.DeepEqual(after, before) {
+		t.Errorf("Statistics after the delete were\n %+v\nexpected\n %+v", after, before)
+	}
+}
+
+func TestDBLiterals(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	cfg.DBPath = "_testhoddb_literals"
+	cfg.Buildings = map[string]string{"literals": "testbuildings/literals.ttl"}
+	defer os.RemoveAll(cfg.DBPath)
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	floor_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#floor_1")
+	floor_2 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#floor_2")
+	for _, test := range []struct {
+		query   string
+		results []ResultMap
+	}{
+		{
+			"SELECT ?a WHERE { bldg:floor_1 bldg:area ?a };",
+			[]ResultMap{{"?a": turtle.TypedLiteral("1200.5", turtle.XSDDouble)}},
+		},
+		{
+			"SELECT ?r WHERE { bldg:floor_2 bldg:rooms ?r };",
+			[]ResultMap{{"?r": turtle.TypedLiteral("9", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:area \"800\"^^xsd:double };",
+			[]ResultMap{{"?f": floor_2}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:area \"800\"^^<http://www.w3.org/2001/XMLSchema#double> };",
+			[]ResultMap{{"?f": floor_2}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:rooms 12 };",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:occupied true };",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f rdfs:label \"Erdgeschoss\"@de };",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f rdfs:label \"Erdgeschoss\" };",
+			[]ResultMap{},
+		},
+		{
+			"SELECT ?l WHERE { bldg:floor_1 rdfs:label ?l };",
+			[]ResultMap{{"?l": turtle.LangLiteral("First floor", "en")}, {"?l": turtle.LangLiteral("Erdgeschoss", "de")}},
+		},
+		{
+			"SELECT ?p WHERE { bldg:sp_1 ?p \"72\" };",
+			[]ResultMap{{"?p": turtle.ParseURI("http://buildsys.org/ontologies/building_example#note")}},
+		},
+		{
+			"SELECT ?p WHERE { bldg:sp_1 ?p 72 };",
+			[]ResultMap{{"?p": turtle.ParseURI("http://buildsys.org/ontologies/building_example#value")}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:area ?a . FILTER(?a > 1000) };",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:commissioned ?d . FILTER(?d < \"2018-01-01T00:00:00Z\"^^xsd:dateTime) };",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:commissioned ?d . FILTER(?d > \"2018-06-15T11:00:00+02:00\") };",
+			[]ResultMap{{"?f": floor_2}},
+		},
+		{
+			"SELECT ?l WHERE { bldg:floor_1 rdfs:label ?l . FILTER(LANGMATCHES(LANG(?l), \"EN\")) };",
+			[]ResultMap{{"?l": turtle.LangLiteral("First floor", "en")}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:rooms ?r . FILTER(DATATYPE(?r) = xsd:integer) };",
+			[]ResultMap{{"?f": floor_1}, {"?f": floor_2}},
+		},
+		{
+			"SELECT ?f WHERE { ?f bldg:occupied ?o . FILTER(!?o) };",
+			[]ResultMap{{"?f": floor_2}},
+		},
+		{
+			"SELECT (SUM(?r) AS ?rooms) (AVG(?a) AS ?area) WHERE { ?f bldg:rooms ?r . ?f bldg:area ?a };",
+			[]ResultMap{{"?rooms": turtle.TypedLiteral("21", turtle.XSDInteger), "?area": turtle.TypedLiteral("1000.25", turtle.XSDDecimal)}},
+		},
+		{
+			"SELECT (MIN(?d) AS ?first) (MAX(?d) AS ?last) WHERE { ?f bldg:commissioned ?d };",
+			[]ResultMap{{"?first": turtle.TypedLiteral("2017-03-01T09:00:00Z", turtle.XSDDateTime), "?last": turtle.TypedLiteral("2018-06-15T12:00:00Z", turtle.XSDDateTime)}},
+		},
+		{
+			"SELECT ?f (COUNT(?l) AS ?n) WHERE { ?f rdf:type brick:Floor . ?f rdfs:label ?l } GROUP BY ?f;",
+			[]ResultMap{{"?f": floor_1, "?n": turtle.TypedLiteral("2", turtle.XSDInteger)}, {"?f": floor_2, "?n": turtle.TypedLiteral("1", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?f WHERE { ?f rdf:type brick:Floor . ?f rdfs:label ?l } GROUP BY ?f HAVING (COUNT(?l) > 1);",
+			[]ResultMap{{"?f": floor_1}},
+		},
+		{
+			"SELECT ?f WHERE { ?f rdf:type brick:Floor . ?f rdfs:label ?l } GROUP BY ?f ORDER BY ASC(COUNT(?l)) LIMIT 1;",
+			[]ResultMap{{"?f": floor_2}},
+		},
+		{
+			"SELECT (COUNT(?f) AS ?n) (COUNT(DISTINCT ?f) AS ?floors) WHERE { ?f rdf:type brick:Floor . ?f rdfs:label ?l };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("3", turtle.XSDInteger), "?floors": turtle.TypedLiteral("2", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT (GROUP_CONCAT(?l; SEPARATOR=\", \") AS ?labels) (SAMPLE(?r) AS ?rooms) WHERE { ?f rdfs:label ?l . ?f bldg:rooms ?r . FILTER(?r < 10) };",
+			[]ResultMap{{"?labels": turtle.URI{Value: "Second floor"}, "?rooms": turtle.TypedLiteral("9", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT (COUNT(*) AS ?n) WHERE { ?f bldg:rooms ?r . FILTER(?r > 100) };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("0", turtle.XSDInteger)}},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if !compareResultMapList(result.Rows, test.results) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+		}
+	}
+
+	// numeric literals are ordered by value rather than lexically
+	result, err := db.RunQueryString("SELECT ?f WHERE { ?f bldg:area ?a } ORDER BY ?a;")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rows) != 2 || result.Rows[0]["?f"] != floor_2 || result.Rows[1]["?f"] != floor_1 {
+		t.Errorf("Ordered results were %+v", result.Rows)
+	}
+}
+
+func TestDBAggregates(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range []struct {
+		query   string
+		results []ResultMap
+	}{
+		{
+			"SELECT (COUNT(?x) AS ?n) FROM soda WHERE { ?x rdf:type brick:Room };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("243", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?ahu (COUNT(?vav) AS ?n) FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds ?vav } GROUP BY ?ahu;",
+			[]ResultMap{{"?ahu": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1"), "?n": turtle.TypedLiteral("1", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT ?ahu FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds+ ?x } GROUP BY ?ahu HAVING (COUNT(?x) = 2);",
+			[]ResultMap{{"?ahu": turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")}},
+		},
+		{
+			"SELECT ?ahu FROM test WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds+ ?x } GROUP BY ?ahu HAVING (COUNT(?x) > 2);",
+			[]ResultMap{},
+		},
+		{
+			"SELECT (COUNT(?x) AS ?n) FROM test WHERE { { ?x rdf:type brick:AHU } UNION { ?x rdf:type brick:VAV } };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("2", turtle.XSDInteger)}},
+		},
+		// a solution of both branches is counted once for each, unless the
+		// count is DISTINCT
+		{
+			"SELECT (COUNT(?x) AS ?n) FROM test WHERE { { ?x rdf:type brick:AHU } UNION { ?x bf:feeds bldg:vav_1 } };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("2", turtle.XSDInteger)}},
+		},
+		{
+			"SELECT (COUNT(DISTINCT ?x) AS ?n) FROM test WHERE { { ?x rdf:type brick:AHU } UNION { ?x bf:feeds bldg:vav_1 } };",
+			[]ResultMap{{"?n": turtle.TypedLiteral("1", turtle.XSDInteger)}},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if !compareResultMapList(result.Rows, test.results) {
+			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
+		}
+	}
+
+	// the classes of the ontology are in both databases, so they are
+	// counted once for each, unless the count is DISTINCT
+	for _, test := range []struct {
+		query     string
+		databases int
+	}{
+		{"SELECT (COUNT(?c) AS ?n) FROM %s WHERE { ?c rdfs:subClassOf+ brick:Sensor };", 2},
+		{"SELECT (COUNT(DISTINCT ?c) AS ?n) FROM %s WHERE { ?c rdfs:subClassOf+ brick:Sensor };", 1},
+	} {
+		one, err := db.RunQueryString(fmt.Sprintf(test.query, "test"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		both, err := db.RunQueryString(fmt.Sprintf(test.query, "soda test"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(one.Rows) != 1 || len(both.Rows) != 1 {
+			t.Errorf("Query %s had %+v on one database and %+v on both", test.query, one.Rows, both.Rows)
+			continue
+		}
+		n, _ := strconv.Atoi(one.Rows[0]["?n"].Value)
+		if expected := strconv.Itoa(n * test.databases); n == 0 || both.Rows[0]["?n"].Value != expected {
+			t.Errorf("Query %s had %+v on both databases, expected %s", test.query, both.Rows, expected)
+		}
+	}
+
+	// there is a group for each AHU, and the counts per AHU add up to the
+	// number of AHU/VAV pairs
+	ahus, err := db.RunQueryString("COUNT ?ahu FROM soda WHERE { ?ahu rdf:type brick:AHU };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pairs, err := db.RunQueryString("COUNT ?ahu ?vav FROM soda WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds ?vav };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := db.RunQueryString("SELECT ?ahu (COUNT(?vav) AS ?n) FROM soda WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds ?vav } GROUP BY ?ahu ORDER BY DESC(?n);")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rows) != ahus.Count {
+		t.Errorf("Got %d groups for %d AHUs", len(result.Rows), ahus.Count)
+	}
+	total := 0
+	last := -1
+	for _, row := range result.Rows {
+		n, err := strconv.Atoi(row["?n"].Value)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if last >= 0 && n > last {
+			t.Errorf("Groups are not ordered by count: %+v", result.Rows)
+		}
+		total, last = total+n, n
+	}
+	if total != pairs.Count {
+		t.Errorf("Counts per AHU add up to %d, expected %d", total, pairs.Count)
+	}
+
+	// the groups can be rolled up again with a subquery
+	result, err = db.RunQueryString("SELECT (SUM(?n) AS ?total) (COUNT(?ahu) AS ?groups) FROM soda WHERE { { SELECT ?ahu (COUNT(?vav) AS ?n) WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds ?vav } GROUP BY ?ahu } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rows) != 1 {
+		t.Errorf("Got %d rows for rollup, expected 1", len(result.Rows))
+		return
+	}
+	if total, err := strconv.Atoi(result.Rows[0]["?total"].Value); err != nil || total != pairs.Count {
+		t.Errorf("Rollup of counts per AHU is %+v, expected %d", result.Rows[0]["?total"], pairs.Count)
+	}
+	if groups, err := strconv.Atoi(result.Rows[0]["?groups"].Value); err != nil || groups != ahus.Count {
+		t.Errorf("Rollup has %+v groups, expected %d", result.Rows[0]["?groups"], ahus.Count)
+	}
+}
+
+func TestDBLinks(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ahu_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")
+	vav_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")
+	room_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#room_1")
+	for _, test := range []struct {
+		query string
+		links LinkResultMap
+	}{
+		{
+			"SELECT ?x[uuid] FROM test WHERE { ?x bf:feeds ?y };",
+			LinkResultMap{
+				ahu_1: {"UUID": "3a038c7c-c7e6-11e6-bfc1-1002b58053c7"},
+				vav_1: {"UUID": "427b8f7c-dc3a-11e6-8b12-1002b58053c7"},
+			},
+		},
+		{
+			"SELECT ?x[*] ?y FROM test WHERE { ?x rdf:type brick:Room . ?x bf:isPartOf ?y };",
+			LinkResultMap{room_1: {"Coords": "[1, 2]"}},
+		},
+		{
+			"SELECT ?x[UUID,Coords] FROM test WHERE { ?x rdf:type brick:Room };",
+			LinkResultMap{room_1: {"Coords": "[1, 2]"}},
+		},
+		{
+			"SELECT ?x[uuid] FROM test WHERE { ?x rdf:type brick:Room };",
+			LinkResultMap{},
+		},
+		{
+			"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y };",
+			nil,
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Links, test.links) {
+			t.Errorf("Links for %s were\n %+v\nexpected\n %+v", test.query, result.Links, test.links)
+		}
+
+		// the links and the status of each database are kept by msgp
+		var decoded QueryResult
+		if encoded, err := result.MarshalMsg(nil); err != nil {
+			t.Error(test.query, err)
+		} else if _, err := decoded.UnmarshalMsg(encoded); err != nil {
+			t.Error(test.query, err)
+		} else if !reflect.DeepEqual(decoded.Links, result.Links) || !reflect.DeepEqual(decoded.Databases, result.Databases) ||
+			decoded.Partial != result.Partial || !reflect.DeepEqual(decoded.Rows, result.Rows) {
+			t.Errorf("Decoded result for %s was\n %+v\nexpected\n %+v", test.query, decoded, result)
+		}
+		if len(result.Rows) == 0 {
+			t.Errorf("No results for %s", test.query)
+		}
+	}
+}
+
+func TestDBCrossProductLimit(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	cfg.MaxCrossProduct = 2
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range []struct {
+		query string
+		fails bool
+	}{
+		{"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . ?y rdf:type brick:Room };", false},
+		{"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?z . ?y rdf:type brick:Room };", false},
+		{"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?z . ?y bf:feeds ?w };", true},
+		{"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?z . { SELECT ?y WHERE { ?y bf:feeds ?w } } };", true},
+		{"SELECT ?x ?y FROM test WHERE { ?x rdf:type brick:AHU . { SELECT ?y WHERE { ?y rdf:type brick:Room } } };", false},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		// errors from a database are reported in the result
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+		} else if test.fails && len(result.Errors) == 0 {
+			t.Errorf("%s should be over the cross product limit", test.query)
+		} else if !test.fails && len(result.Errors) > 0 {
+			t.Error(test.query, result.Errors)
+		}
+	}
+}
+
+func TestDBStatistics(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	hod, err := NewHodDB(cfg)
+	defer hod.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_db, ok := hod.dbs.Load("test")
+	if !ok {
+		t.Error("No database test")
+		return
+	}
+	db := _db.(*DB)
+
+	stats := db.statistics()
+	if stats.entities == 0 || stats.edges == 0 {
+		t.Errorf("Expected entities and edges, got %d and %d", stats.entities, stats.edges)
+	}
+	ahu := db.expand(turtle.ParseURI("brick:AHU"))
+	if count := stats.classes[ahu]; count != 1 {
+		t.Errorf("Expected 1 instance of %s, got %d", ahu, count)
+	}
+	feeds := db.expand(turtle.ParseURI("bf:feeds"))
+	if ps := stats.predicates[feeds]; ps.edges == 0 || ps.subjects == 0 || ps.objects == 0 {
+		t.Errorf("Expected statistics for %s, got %+v", feeds, ps)
+	}
+
+	// the selective term runs first, whatever the order in the query
+	for _, test := range []struct {
+		query string
+		first int
+	}{
+		{"SELECT ?x FROM test WHERE { ?x rdf:type owl:Class . ?x rdfs:subClassOf brick:Temperature_Sensor };", 1},
+		{"SELECT ?x FROM test WHERE { ?x rdfs:subClassOf brick:Temperature_Sensor . ?x rdf:type owl:Class };", 0},
+		{"SELECT ?x FROM test WHERE { ?x rdf:type owl:Class . brick:AHU rdfs:subClassOf+ ?x };", 1},
+		{"SELECT ?x FROM test WHERE { ?x rdf:type owl:Class . ?x rdf:type brick:AHU };", 1},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		q.MapURIs(db.expand)
+		dg := makeDependencyGraph(q, nil)
+		if _, err := db.formQueryPlan(dg, q); err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if first := q.Where.Terms[test.first]; dg.plan[0].Triple.String() != first.String() {
+			t.Errorf("Query %s should start with %s, plan was %v", test.query, first, dg.plan)
+		}
+	}
+}
+
+func TestDBExplain(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range []struct {
+		query      string
+		analyzed   bool
+		plans      int
+		operations int
+		rows       int
+	}{
+		{"EXPLAIN SELECT ?x FROM test WHERE { ?x rdf:type brick:Room . bldg:hvaczone_1 bf:hasPart ?x };", false, 1, 2, 0},
+		{"EXPLAIN ANALYZE SELECT ?x FROM test WHERE { ?x rdf:type brick:Room . bldg:hvaczone_1 bf:hasPart ?x };", true, 1, 2, 1},
+		{"EXPLAIN ANALYZE SELECT ?x FROM test WHERE { ?x rdf:type brick:Room . FILTER(?x != bldg:room_1) };", true, 1, 2, 0},
+		{"EXPLAIN ANALYZE SELECT ?x FROM test WHERE { { ?x rdf:type brick:Room } UNION { ?x rdf:type brick:AHU } };", true, 2, 1, 1},
+		{"EXPLAIN ASK FROM test WHERE { ?x rdf:type brick:Room };", false, 1, 1, 0},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.Explain(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if len(result.Errors) > 0 {
+			t.Error(test.query, result.Errors)
+			continue
+		}
+		if result.Analyzed != test.analyzed || len(result.Plans) != test.plans {
+			t.Errorf("Query %s should have %d plans (analyzed: %v), got %+v", test.query, test.plans, test.analyzed, result)
+			continue
+		}
+		for _, plan := range result.Plans {
+			if plan.Database != "test" || len(plan.Operations) != test.operations || plan.Rows != test.rows {
+				t.Errorf("Query %s should have %d operations and %d rows, got %+v", test.query, test.operations, test.rows, plan)
+				continue
+			}
+			last := plan.Operations[len(plan.Operations)-1]
+			if test.analyzed && (last.Rows != test.rows || last.Elapsed == 0) {
+				t.Errorf("Query %s should have analyzed its operations, got %+v", test.query, plan.Operations)
+			}
+			if last.EstimatedRows == 0 {
+				t.Errorf("Query %s should have estimated its rows, got %+v", test.query, plan.Operations)
+			}
+		}
+	}
+
+	// the selective term is planned first
+	q, _ := query.Parse("EXPLAIN SELECT ?x FROM test WHERE { ?x rdf:type owl:Class . brick:AHU rdfs:subClassOf ?x };")
+	if result, err := db.Explain(q); err != nil {
+		t.Error(err)
+	} else if order := result.Plans[0].Order; len(order) != 2 || !strings.Contains(order[0], "subClassOf") {
+		t.Errorf("Wrong plan order %v", order)
+	}
+
+	// explained queries are not run
+	q, _ = query.Parse("EXPLAIN SELECT ?x FROM test WHERE { ?x rdf:type brick:Room };")
+	if _, err := db.RunQuery(q); err == nil {
+		t.Error("EXPLAIN query should not return rows")
+	}
+	q = &sparql.Query{Type: sparql.INSERT_QUERY, Explain: sparql.EXPLAIN_ANALYZE}
+	if _, err := db.Explain(q); err == nil {
+		t.Error("EXPLAIN ANALYZE should not run updates")
+	}
+}
+
+func TestDBQueryCancel(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	q, err := query.Parse("SELECT ?x ?y FROM test WHERE { ?x bf:feeds+ ?y };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expiredCtx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	for _, test := range []struct {
+		ctx     context.Context
+		timeout bool
+	}{
+		{canceledCtx, false},
+		{expiredCtx, true},
+	} {
+		_, err := db.RunQueryContext(test.ctx, q.Copy())
+		if canceled, ok := errors.Cause(err).(*QueryCanceledError); !ok {
+			t.Errorf("Expected a QueryCanceledError, got %v", err)
+		} else if canceled.Timeout() != test.timeout {
+			t.Errorf("Expected a timeout of %v, got %v", test.timeout, canceled)
+		}
+		if _, err := db.AskContext(test.ctx, &sparql.Query{Type: sparql.ASK_QUERY, Where: q.Where, From: q.From}); err == nil {
+			t.Error("ASK should have been canceled")
+		}
+	}
+
+	// the query runs with a context that is not done
+	if result, err := db.RunQueryContext(context.Background(), q.Copy()); err != nil || result.Count == 0 {
+		t.Errorf("Expected results, got %v (%v)", result, err)
+	}
+
+	// searches stop once the query is canceled
+	_db, _ := db.dbs.Load("test")
+	snap, err := _db.(*DB).snapshot()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer snap.done()
+	tr := &traversal{under: snap, goctx: canceledCtx}
+	from := newKeymap()
+	ahu, _ := tr.getHash(_db.(*DB).expand(turtle.ParseURI("bldg:ahu_1")))
+	from.Add(ahu)
+	pattern := sparql.PathPattern{Predicate: _db.(*DB).expand(turtle.ParseURI("bf:feeds")), Pattern: sparql.PATTERN_ONE_PLUS}
+	if _, err := tr.reachableByPattern(from, pattern, true); err == nil {
+		t.Error("Search should have been canceled")
+	}
+
+	// the timeout of the config applies to every query
+	cfg.QueryTimeout = time.Nanosecond
+	db.cfg = cfg
+	if _, err := db.RunQuery(q.Copy()); err == nil {
+		t.Error("Query should have timed out")
+	}
+}
+
+// databases that were updated before a query was canceled are reported
+func TestDBUpdateCancel(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	cfg.DBPath = "_testhoddb_updatecancel"
+	// a file is only loaded into the first database that names it
+	cfg.Buildings = map[string]string{"a": "testbuildings/example.ttl", "b": "testbuildings/berkeley.ttl"}
+	cfg.QueryConcurrency = 1
+	defer os.RemoveAll(cfg.DBPath)
+	hod, err := NewHodDB(cfg)
+	defer hod.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_a, _ := hod.dbs.Load("a")
+	_b, _ := hod.dbs.Load("b")
+	a, b := _a.(*DB), _b.(*DB)
+	generationA, generationB := a.currentGeneration(), b.currentGeneration()
+
+	// the databases are updated in order; b plans its query once a is
+	// updated, and is blocked on its statistics until the query is canceled
+	q, err := query.Parse("INSERT { ?ahu bf:feeds bldg:hvaczone_1 } WHERE { ?ahu rdf:type brick:AHU };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	goctx, cancel := context.WithCancel(context.Background())
+	b.statsLock.Lock()
+	go func() {
+		for a.currentGeneration() == generationA {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		b.statsLock.Unlock()
+	}()
+	result, err := hod.RunQueryContext(goctx, q)
+	if _, ok := errors.Cause(err).(*QueryCanceledError); !ok {
+		t.Errorf("Expected a QueryCanceledError, got %v", err)
+	}
+	if status := result.Databases["a"]; !status.Updated || status.Error != "" {
+		t.Errorf("a should have been updated, got %+v", status)
+	}
+	if status := result.Databases["b"]; status.Updated || status.Error == "" {
+		t.Errorf("b should have been canceled, got %+v", status)
+	}
+	if b.currentGeneration() != generationB {
+		t.Error("b should not have been updated")
+	}
+}
+
+func TestDBRoutes(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ahu_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#ahu_1")
+	vav_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#vav_1")
+	hvaczone_1 := turtle.ParseURI("http://buildsys.org/ontologies/building_example#hvaczone_1")
+	feeds := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#feeds")
+	isFedBy := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isFedBy")
+	for _, test := range []struct {
+		query string
+		// the expected route for each value of ?x
+		routes map[turtle.URI][]turtle.URI
+	}{
+		{
+			"SELECT ?x ?r FROM test WHERE { bldg:ahu_1 bf:feeds+ ?x ROUTE ?r };",
+			map[turtle.URI][]turtle.URI{
+				vav_1:      {ahu_1, feeds, vav_1},
+				hvaczone_1: {ahu_1, feeds, vav_1, feeds, hvaczone_1},
+			},
+		},
+		{
+			"SELECT ?x ?r FROM test WHERE { ?x rdf:type brick:AHU . ?x bf:feeds/bf:feeds bldg:hvaczone_1 ROUTE ?r };",
+			map[turtle.URI][]turtle.URI{
+				ahu_1: {ahu_1, feeds, vav_1, feeds, hvaczone_1},
+			},
+		},
+		{
+			"SELECT ?x ?r FROM test WHERE { ?x ^bf:feeds{,2} bldg:ahu_1 ROUTE ?r . FILTER(?x != bldg:ahu_1) };",
+			map[turtle.URI][]turtle.URI{
+				vav_1:      {vav_1, isFedBy, ahu_1},
+				hvaczone_1: {hvaczone_1, isFedBy, vav_1, isFedBy, ahu_1},
+			},
+		},
+		{
+			"SELECT ?x ?r FROM test WHERE { ?x rdf:type brick:AHU . ?x bf:feeds* ?x ROUTE ?r };",
+			map[turtle.URI][]turtle.URI{
+				ahu_1: {ahu_1},
+			},
+		},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.RunQuery(q)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if len(result.Rows) != len(test.routes) {
+			t.Errorf("Results for %s had\n %+v\nexpected routes\n %+v", test.query, result.Rows, test.routes)
+			continue
+		}
+		for _, row := range result.Rows {
+			route, ok := ParseRoute(row["?r"])
+			if !ok || !reflect.DeepEqual(route, test.routes[row["?x"]]) {
+				t.Errorf("Route to %s for %s was\n %+v\nexpected\n %+v", row["?x"], test.query, route, test.routes[row["?x"]])
+			}
+		}
+	}
+
+	// routes are serialized as lists
+	route, err := routeLiteral([]turtle.URI{ahu_1, feeds, vav_1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(ResultMap{"?r": route})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"?r":[{"Namespace":"http://buildsys.org/ontologies/building_example","Value":"ahu_1"},{"Namespace":"https://brickschema.org/schema/1.0.3/BrickFrame","Value":"feeds"},{"Namespace":"http://buildsys.org/ontologies/building_example","Value":"vav_1"}]}`
+	if string(b) != expected {
+		t.Errorf("Route was serialized as\n %s\nexpected\n %s", b, expected)
+	}
+}
+
+func TestDBQueryForms(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	bldg := func(value string) turtle.URI {
+		return turtle.ParseURI("http://buildsys.org/ontologies/building_example#" + value)
+	}
+	feeds := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#feeds")
+	isFedBy := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isFedBy")
+	isPointOf := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#isPointOf")
+	hasPoint := turtle.ParseURI("https://brickschema.org/schema/1.0.3/BrickFrame#hasPoint")
+
+	for _, test := range []struct {
+		query  string
+		result bool
+	}{
+		{"ASK FROM test WHERE { ?x bf:feeds bldg:vav_1 };", true},
+		{"ASK { ?x bf:feeds bldg:ahu_1 };", false},
+		{"ASK FROM test { ?x rdf:type brick:AHU . FILTER NOT EXISTS { ?x bf:feeds ?y } };", false},
+		{"ASK FROM test WHERE { bldg:ahu_1 bf:feeds bldg:vav_1 };", true},
+		{"ASK FROM test WHERE { bldg:ahu_1 bf:feeds+ bldg:hvaczone_1 . bldg:vav_1 bf:feeds bldg:ahu_1 };", false},
+	} {
+		q, e := query.Parse(test.query)
+		if e != nil {
+			t.Error(test.query, e)
+			continue
+		}
+		result, err := db.Ask(q)
+		if err != nil {
+			t.Error(test.query, err)
+		} else if result.Result != test.result {
+			t.Errorf("Result for %s was %v, expected %v", test.query, result.Result, test.result)
+		}
+	}
+
+	for _, test := range []struct {
+		query   string
+		triples []turtle.Triple
+	}{
+		{
+			"CONSTRUCT { ?y bldg:fedBy ?x } FROM test WHERE { ?x bf:feeds ?y };",
+			[]turtle.Triple{
+				{Subject: bldg("hvaczone_1"), Predicate: bldg("fedBy"), Object: bldg("vav_1")},
+				{Subject: bldg("vav_1"), Predicate: bldg("fedBy"), Object: bldg("ahu_1")},
+			},
+		},
+		{
+			// triples with unbound variables are left out
+			"CONSTRUCT { ?x rdfs:label ?l . ?x bf:feeds ?y . } FROM test WHERE { ?x bf:feeds ?y . OPTIONAL { ?x rdfs:label ?l } } ORDER BY ?x LIMIT 1;",
+			[]turtle.Triple{{Subject: bldg("ahu_1"), Predicate: feeds, Object: bldg("vav_1")}},
 		},
 		{
-			"SELECT ?s ?p FROM test WHERE { ?s ?p brick:Zone_Temperature_Sensor . ?s rdfs:subClassOf brick:Zone_Temperature_Sensor };",
-			[]ResultMap{
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Average_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Coldest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Highest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Lowest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Warmest_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#VAV_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#AHU_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#FCU_Zone_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-				{"?s": turtle.ParseURI("https://brickschema.org/schema/1.0.3/Brick#Zone_Air_Temperature_Sensor"), "?p": turtle.ParseURI("http://www.w3.org/2000/01/rdf-schema#subClassOf")},
-			},
+			"CONSTRUCT { ?x bf:feeds ?y } FROM test WHERE { ?x bf:feeds ?y . ?y bf:feeds ?x };",
+			nil,
 		},
 	} {
-		//time.Sleep(100 * time.Millisecond)
 		q, e := query.Parse(test.query)
 		if e != nil {
 			t.Error(test.query, e)
 			continue
 		}
-		result, err := db.RunQuery(q)
+		result, err := db.Construct(q)
 		if err != nil {
-			t.Error(err)
-			return
+			t.Error(test.query, err)
+		} else if len(result.Triples) != len(test.triples) || (len(test.triples) > 0 && !reflect.DeepEqual(result.Triples, test.triples)) {
+			t.Errorf("Triples for %s were\n %+v\nexpected\n %+v", test.query, result.Triples, test.triples)
 		}
-		if !compareResultMapList(test.results, result.Rows) {
-			t.Errorf("Results for %s had\n %+v\nexpected\n %+v", test.query, result.Rows, test.results)
-			return
+	}
+
+	q, err := query.Parse("DESCRIBE ?x FROM test WHERE { ?x bf:isPointOf bldg:vav_1 };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := db.Describe(q)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Entities) != 1 || result.Entities[0] != bldg("ztemp_1") {
+		t.Errorf("Wrong described entities %v", result.Entities)
+	}
+	for _, triple := range []turtle.Triple{
+		{Subject: bldg("ztemp_1"), Predicate: isPointOf, Object: bldg("vav_1")},
+		{Subject: bldg("vav_1"), Predicate: hasPoint, Object: bldg("ztemp_1")},
+	} {
+		found := false
+		for _, t := range result.Triples {
+			found = found || t == triple
+		}
+		if !found {
+			t.Errorf("DESCRIBE result %v is missing %v", result.Triples, triple)
+		}
+	}
+	for _, triple := range result.Triples {
+		if triple.Subject != bldg("ztemp_1") && triple.Object != bldg("ztemp_1") {
+			t.Errorf("DESCRIBE result has unrelated triple %v", triple)
 		}
 	}
+
+	q, err = query.Parse("DESCRIBE bldg:ahu_1 bldg:nothing FROM test;")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err = db.Describe(q)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Entities) != 1 || result.Entities[0] != bldg("ahu_1") {
+		t.Errorf("Wrong described entities %v", result.Entities)
+	}
+	hasFeeds, hasIsFedBy := false, false
+	for _, triple := range result.Triples {
+		hasFeeds = hasFeeds || triple == turtle.Triple{Subject: bldg("ahu_1"), Predicate: feeds, Object: bldg("vav_1")}
+		hasIsFedBy = hasIsFedBy || triple == turtle.Triple{Subject: bldg("vav_1"), Predicate: isFedBy, Object: bldg("ahu_1")}
+	}
+	if !hasFeeds || !hasIsFedBy {
+		t.Errorf("DESCRIBE result %v is missing the edges of ahu_1", result.Triples)
+	}
+
+	if _, err := db.RunQuery(q); err == nil {
+		t.Error("RunQuery should not run DESCRIBE queries")
+	}
 }
 
 func TestDBQueryBerkeley(t *testing.T) {
@@ -256,6 +1858,34 @@ func TestDBQueryBerkeley(t *testing.T) {
 			"COUNT ?vav ?x ?y ?z FROM soda WHERE { ?vav rdf:type brick:VAV . ?vav bf:feeds+ ?x . ?vav bf:isFedBy+ ?y . ?vav bf:hasPoint+ ?z };",
 			823,
 		},
+		{
+			"COUNT ?vav FROM soda WHERE { ?vav rdf:type brick:VAV . FILTER(!regex(?vav, \"^.*vav_C7\")) };",
+			237,
+		},
+		{
+			"COUNT ?vav ?ahu FROM soda WHERE { ?vav rdf:type brick:VAV . OPTIONAL { ?ahu bf:feeds ?vav . ?ahu rdf:type brick:AHU } };",
+			244,
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?ahu rdf:type brick:AHU . ?x ^bf:feeds+ ?ahu };",
+			480,
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?ahu rdf:type brick:AHU . ?ahu (bf:feeds|bf:feeds/bf:feeds) ?x };",
+			480,
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?x rdf:type brick:Room } LIMIT 10;",
+			10,
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?x rdf:type brick:Room } OFFSET 240;",
+			3,
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?x rdf:type brick:Room } ORDER BY DESC(?x) LIMIT 10 OFFSET 238;",
+			5,
+		},
 	} {
 		time.Sleep(100 * time.Millisecond)
 		q, e := query.Parse(test.query)
@@ -356,3 +1986,440 @@ func BenchmarkINSERTPerformance1(b *testing.B) {
 		})
 	}
 }
+
+func TestDBQueryCache(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	cfg.DBPath = "_testhoddb_cache"
+	cfg.Buildings = map[string]string{"test": "testbuildings/example.ttl"}
+	cfg.DisableQueryCache = false
+	defer os.RemoveAll(cfg.DBPath)
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	run := func(querystring string) QueryResult {
+		result, err := db.RunQueryString(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+		}
+		return result
+	}
+	lookups := func(stats QueryCacheStats) int64 {
+		return stats.Hits + stats.Misses
+	}
+
+	first := run("SELECT ?y FROM test WHERE { bldg:ahu_1 bf:feeds ?y . ?y rdf:type brick:VAV };")
+	before := db.QueryCacheStats()["test"]
+	if before.Misses != 1 || before.Hits != 0 || before.Entries != 1 {
+		t.Errorf("Expected 1 miss and 1 entry, got %+v", before)
+	}
+
+	// the same query with its triples in another order is a hit
+	second := run("SELECT ?y FROM test WHERE { ?y rdf:type brick:VAV . bldg:ahu_1 bf:feeds ?y };")
+	after := db.QueryCacheStats()["test"]
+	if after.Hits != before.Hits+1 || after.Misses != before.Misses {
+		t.Errorf("Expected a hit, got %+v", after)
+	}
+	if !compareResultMapList(first.Rows, second.Rows) || len(first.Rows) != 1 {
+		t.Errorf("Cached results %+v differ from %+v", second.Rows, first.Rows)
+	}
+
+	// other queries and NOCACHE queries miss
+	run("SELECT ?y ?x FROM test WHERE { bldg:ahu_1 bf:feeds ?y . ?y rdf:type brick:VAV };")
+	if stats := db.QueryCacheStats()["test"]; stats.Misses != after.Misses+1 {
+		t.Errorf("Query selecting other variables should miss, got %+v", stats)
+	}
+	before = db.QueryCacheStats()["test"]
+	run("NOCACHE SELECT ?y FROM test WHERE { bldg:ahu_1 bf:feeds ?y . ?y rdf:type brick:VAV };")
+	if after := db.QueryCacheStats()["test"]; lookups(after) != lookups(before) {
+		t.Errorf("NOCACHE query should not use the cache, got %+v", after)
+	}
+
+	// an update starts a new generation, so the results are found again
+	run("INSERT { bldg:ahu_1 bf:feeds bldg:vav_2 . bldg:vav_2 rdf:type brick:VAV } WHERE { };")
+	after = db.QueryCacheStats()["test"]
+	if after.Generation <= before.Generation {
+		t.Errorf("Update should start a new generation, got %+v", after)
+	}
+	third := run("SELECT ?y FROM test WHERE { bldg:ahu_1 bf:feeds ?y . ?y rdf:type brick:VAV };")
+	if stats := db.QueryCacheStats()["test"]; stats.Misses != after.Misses+1 {
+		t.Errorf("Query after an update should miss, got %+v", stats)
+	}
+	if len(third.Rows) != 2 {
+		t.Errorf("Expected 2 results after the update, got %+v", third.Rows)
+	}
+}
+
+func TestDBQueryIter(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// a path of 18 variables alternating between ahu_1 and vav_1
+	var chain = []string{"bldg:ahu_1 bf:feeds ?x1"}
+	var chainVars = []string{"?x1"}
+	for i := 2; i <= 18; i++ {
+		pred := "bf:isFedBy"
+		if i%2 == 1 {
+			pred = "bf:feeds"
+		}
+		chain = append(chain, fmt.Sprintf("?x%d %s ?x%d", i-1, pred, i))
+		chainVars = append(chainVars, fmt.Sprintf("?x%d", i))
+	}
+	chainQuery := fmt.Sprintf("SELECT %s FROM test WHERE { %s };", strings.Join(chainVars, " "), strings.Join(chain, " . "))
+
+	for _, querystring := range []string{
+		"SELECT ?x ?y FROM test WHERE { ?x bf:feeds ?y };",
+		"SELECT ?x FROM * WHERE { ?x rdf:type brick:Room };",
+		"SELECT ?x FROM test soda WHERE { { ?x rdf:type brick:AHU } UNION { ?x rdf:type brick:VAV } };",
+		"SELECT ?x FROM test WHERE { ?x rdf:type brick:Zone_Temperature_Sensor . OPTIONAL { ?x bf:isPointOf ?y } };",
+		"SELECT ?x ?y FROM test WHERE { ?x bf:feeds+ ?y } ORDER BY ?y ?x LIMIT 2;",
+		"SELECT ?x (COUNT(?y) AS ?n) FROM test WHERE { ?x bf:feeds+ ?y } GROUP BY ?x;",
+		chainQuery,
+	} {
+		q, err := query.Parse(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+			continue
+		}
+		expected, err := db.RunQuery(q.Copy())
+		if err != nil {
+			t.Error(querystring, err)
+			continue
+		}
+		// each database runs its own copy of the query, so the prefixes of
+		// one are not expanded in the query of another, or in the caller's
+		streamed, _ := query.Parse(querystring)
+		iter, err := db.QueryIter(context.Background(), streamed)
+		if err != nil {
+			t.Error(querystring, err)
+			continue
+		}
+		var rows []ResultMap
+		for iter.Next() {
+			rows = append(rows, iter.Row())
+		}
+		if err := iter.Err(); err != nil {
+			t.Error(querystring, err)
+		}
+		iter.Close()
+		if len(expected.Rows) == 0 || !compareResultMapList(rows, expected.Rows) {
+			t.Errorf("Streamed rows for %s were\n %+v\nexpected\n %+v", querystring, rows, expected.Rows)
+		}
+		if unchanged, _ := query.Parse(querystring); !reflect.DeepEqual(streamed, unchanged) {
+			t.Errorf("Streaming %s changed the query to\n %+v", querystring, streamed)
+		}
+	}
+
+	// LIMIT and OFFSET select the same page of the distinct rows as RunQuery,
+	// on every call
+	q, _ := query.Parse("SELECT ?x FROM test soda WHERE { ?x rdf:type ?t } LIMIT 3 OFFSET 4;")
+	expected, err := db.RunQuery(q.Copy())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		iter, err := db.QueryIter(context.Background(), q.Copy())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var page []ResultMap
+		for iter.Next() {
+			page = append(page, iter.Row())
+		}
+		iter.Close()
+		if len(page) != 3 || !reflect.DeepEqual(page, expected.Rows) {
+			t.Errorf("Expected the page %v, got %v", expected.Rows, page)
+		}
+	}
+
+	// closing the iterator early stops the query
+	q, _ = query.Parse("SELECT ?x ?y FROM * WHERE { ?x ?p ?y };")
+	iter, err := db.QueryIter(context.Background(), q)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !iter.Next() {
+		t.Error("Expected a row")
+	}
+	iter.Close()
+	if iter.Next() {
+		t.Error("Expected no rows once the iterator is closed")
+	}
+
+	// canceled queries stop with a QueryCanceledError
+	goctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q, _ = query.Parse("SELECT ?x ?y FROM test WHERE { ?x bf:feeds+ ?y };")
+	iter, err = db.QueryIter(goctx, q)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for iter.Next() {
+	}
+	if _, ok := errors.Cause(iter.Err()).(*QueryCanceledError); !ok {
+		t.Errorf("Expected a QueryCanceledError, got %v", iter.Err())
+	}
+	iter.Close()
+
+	for _, querystring := range []string{
+		"COUNT ?x FROM test WHERE { ?x rdf:type brick:Room };",
+		"ASK { ?x rdf:type brick:Room };",
+		"INSERT { bldg:ahu_1 bf:feeds bldg:vav_1 } WHERE { };",
+	} {
+		q, err := query.Parse(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+			continue
+		}
+		if _, err := db.QueryIter(context.Background(), q); err == nil {
+			t.Errorf("%s should not be streamed", querystring)
+		}
+	}
+}
+
+// the cyclic queries of TestDBLeapfrogJoin and BenchmarkCyclicJoin
+var cyclicQueries = []struct {
+	name  string
+	query string
+}{
+	{"SensorRoomZoneVAV", "SELECT ?sensor ?room ?zone ?vav WHERE { ?sensor bf:isPointOf ?vav . ?vav bf:feeds ?zone . ?room bf:isPartOf ?zone . ?sensor bf:isLocatedIn ?room };"},
+	{"VAVZoneRoomZone", "SELECT ?vav ?zone ?room ?zone2 WHERE { ?vav bf:feeds ?zone . ?room bf:isPartOf ?zone . ?zone2 bf:hasPart ?room . ?vav bf:feeds ?zone2 };"},
+	{"AHUVAVZoneVAV", "SELECT ?ahu ?vav ?zone ?vav2 WHERE { ?ahu bf:feeds ?vav . ?vav bf:feeds ?zone . ?zone bf:isFedBy ?vav2 . ?ahu bf:feeds ?vav2 };"},
+	{"TypedInverse", "SELECT ?vav ?zone ?room ?zone2 WHERE { ?vav rdf:type brick:VAV . ?vav bf:feeds ?zone . ?zone ^bf:isPartOf ?room . ?room ^bf:hasPart ?zone2 . ?zone2 ^bf:feeds ?vav };"},
+}
+
+func setLeapfrogJoin(hod *HodDB, enabled bool) {
+	hod.dbs.Range(func(_, _db interface{}) bool {
+		_db.(*DB).leapfrogJoin = enabled
+		return true
+	})
+}
+
+func TestDBLeapfrogJoin(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	run := func(querystring string, leapfrog bool) QueryResult {
+		setLeapfrogJoin(db, leapfrog)
+		result, err := db.RunQueryString(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+		} else if len(result.Errors) > 0 {
+			t.Error(querystring, result.Errors)
+		}
+		return result
+	}
+	explainsLeapfrog := func(querystring string, leapfrog bool) bool {
+		setLeapfrogJoin(db, leapfrog)
+		q, err := query.Parse("EXPLAIN " + querystring)
+		if err != nil {
+			t.Error(querystring, err)
+			return false
+		}
+		result, err := db.Explain(q)
+		if err != nil {
+			t.Error(querystring, err)
+			return false
+		}
+		for _, plan := range result.Plans {
+			for _, op := range plan.Operations {
+				if strings.Contains(op.Operation, "leapfrogJoin") {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, test := range []struct {
+		query    string
+		cyclic   bool
+		nonempty bool
+	}{
+		{cyclicQueries[1].query, true, true},
+		{cyclicQueries[2].query, true, true},
+		{cyclicQueries[3].query, true, true},
+		{"SELECT ?ahu ?vav ?zone FROM soda WHERE { ?ahu bf:feeds ?vav . ?vav bf:feeds ?zone . ?zone bf:isFedBy ?ahu };", true, false},
+		// the bound subject leaves ?ahu out of the cycle
+		{"SELECT ?vav ?zone ?vav2 FROM soda WHERE { soda_hall:ahu_A1 bf:feeds ?vav . ?vav bf:feeds ?zone . ?zone bf:isFedBy ?vav2 . soda_hall:ahu_A1 bf:feeds ?vav2 };", false, false},
+		// two terms between the same variables are not a cycle
+		{"SELECT ?vav ?zone FROM soda WHERE { ?vav bf:feeds ?zone . ?zone bf:isFedBy ?vav };", false, true},
+		// nor are paths
+		{"SELECT ?ahu ?vav ?zone FROM soda WHERE { ?ahu bf:feeds ?vav . ?vav bf:feeds ?zone . ?ahu bf:feeds+ ?zone };", false, true},
+	} {
+		if got := explainsLeapfrog(test.query, true); got != test.cyclic {
+			t.Errorf("Query %s should use a leapfrog join: %v, got %v", test.query, test.cyclic, got)
+		}
+		if explainsLeapfrog(test.query, false) {
+			t.Errorf("Query %s should not use a leapfrog join when it is disabled", test.query)
+		}
+		leapfrog, pairwise := run(test.query, true), run(test.query, false)
+		if leapfrog.Count != pairwise.Count || !compareResultMapList(leapfrog.Rows, pairwise.Rows) {
+			t.Errorf("Query %s has %d rows with a leapfrog join, expected %d", test.query, leapfrog.Count, pairwise.Count)
+		}
+		if test.nonempty && pairwise.Count == 0 {
+			t.Errorf("Query %s should have results", test.query)
+		}
+	}
+}
+
+func TestDBQueryParallel(t *testing.T) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	run := func(querystring string, concurrency int) QueryResult {
+		db.cfg.QueryConcurrency = concurrency
+		result, err := db.RunQueryString(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+		} else if len(result.Errors) > 0 || result.Partial {
+			t.Error(querystring, result.Errors)
+		}
+		return result
+	}
+
+	// the result does not depend on how many databases are queried at once
+	for _, querystring := range []string{
+		"SELECT ?x WHERE { ?x rdf:type brick:Room };",
+		"SELECT ?x ?y WHERE { ?x bf:feeds ?y };",
+		"SELECT ?x WHERE { ?x rdf:type brick:VAV } ORDER BY DESC(?x) LIMIT 3 OFFSET 1;",
+		"COUNT ?x WHERE { ?x rdf:type brick:Room };",
+		"SELECT ?x WHERE { { ?x rdf:type brick:AHU } UNION { ?x rdf:type brick:VAV } };",
+		"SELECT ?ahu (COUNT(?vav) AS ?n) WHERE { ?ahu bf:feeds ?vav . ?vav rdf:type brick:VAV } GROUP BY ?ahu;",
+		"SELECT ?x FROM soda test WHERE { ?x rdf:type brick:AHU };",
+	} {
+		sequential := run(querystring, 1)
+		if sequential.Count == 0 {
+			t.Errorf("Query %s should have results", querystring)
+		}
+		for _, concurrency := range []int{0, 2, 8} {
+			parallel := run(querystring, concurrency)
+			if parallel.Count != sequential.Count || !reflect.DeepEqual(parallel.Rows, sequential.Rows) {
+				t.Errorf("Query %s has %d rows on %d databases at once, expected %d", querystring, parallel.Count, concurrency, sequential.Count)
+			}
+		}
+		if len(sequential.Databases) != 2 {
+			t.Errorf("Query %s should report the status of 2 databases, got %v", querystring, sequential.Databases)
+		}
+		for dbname, status := range sequential.Databases {
+			if status.Error != "" || status.Elapsed == 0 {
+				t.Errorf("Query %s has status %+v on %s", querystring, status, dbname)
+			}
+		}
+	}
+
+	// the rows of each database are counted before duplicates are removed
+	result := run("SELECT ?class WHERE { ?x rdf:type ?class };", 8)
+	soda, test := result.Databases["soda"], result.Databases["test"]
+	if soda.Rows == 0 || test.Rows == 0 || soda.Rows+test.Rows <= result.Count {
+		t.Errorf("Classes should be found in both databases: %+v, %d rows", result.Databases, result.Count)
+	}
+
+	// a database that fails does not fail the query
+	sodadb, _ := db.dbs.Load("soda")
+	sodadb.(*DB).maxCrossProduct = 2
+	defer func() {
+		sodadb.(*DB).maxCrossProduct = cfg.MaxCrossProduct
+	}()
+	db.cfg.QueryConcurrency = 8
+	querystring := "SELECT ?x ?y WHERE { ?x rdf:type brick:AHU . ?y rdf:type brick:Floor };"
+	result, err = db.RunQueryString(querystring)
+	if err != nil {
+		t.Error(querystring, err)
+		return
+	}
+	soda, test = result.Databases["soda"], result.Databases["test"]
+	if !result.Partial || len(result.Errors) != 1 || soda.Error == "" || test.Error != "" {
+		t.Errorf("Query %s should only fail on soda: %+v", querystring, result.Databases)
+	}
+	if test.Rows == 0 || result.Count != test.Rows {
+		t.Errorf("Query %s should have the %d rows of test, got %d", querystring, test.Rows, result.Count)
+	}
+}
+
+func BenchmarkCyclicJoin(b *testing.B) {
+	cfg, err := config.ReadConfig("testhodconfig.yaml")
+	if err != nil {
+		b.Error(err)
+		return
+	}
+	files, err := filepath.Glob("../buildings/*.ttl")
+	if err != nil {
+		b.Error(err)
+		return
+	}
+	cfg.DBPath = "_testhoddb_buildings"
+	cfg.Buildings = make(map[string]string)
+	cfg.Links = nil
+	for _, file := range files {
+		cfg.Buildings[strings.TrimSuffix(filepath.Base(file), ".ttl")] = file
+	}
+	defer os.RemoveAll(cfg.DBPath)
+	db, err := NewHodDB(cfg)
+	defer db.Close()
+	if err != nil {
+		b.Error(err)
+		return
+	}
+
+	for _, bm := range cyclicQueries {
+		q, err := query.Parse(bm.query)
+		if err != nil {
+			b.Error(bm.query, err)
+			continue
+		}
+		for _, mode := range []struct {
+			name     string
+			leapfrog bool
+		}{
+			{"Leapfrog", true},
+			{"Pairwise", false},
+		} {
+			b.Run(bm.name+"/"+mode.name, func(b *testing.B) {
+				setLeapfrogJoin(db, mode.leapfrog)
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := db.RunQuery(q); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
 	"reflect"
 	"strings"
 )
@@ -11,48 +12,110 @@ import (
 type dependencyGraph struct {
 	selectVars []string
 	variables  map[string]bool
-	terms      []*queryTerm
-	plan       []queryTerm
+	// variables that already have values before the plan is executed,
+	// e.g. when planning a nested group
+	bound []string
+	// variables selected by subqueries, which are evaluated before the terms
+	subqueryVars []string
+	// variables introduced to join the steps of paths with variable
+	// predicates, or to stand in for a variable that is repeated in a term;
+	// they are not part of the query
+	pathVars []string
+	// the variables that stand in for repeated variables, and the variables
+	// they must be equal to
+	sameVars map[string]string
+	terms    []*queryTerm
+	plan     []queryTerm
+	// the estimated number of rows after each term of the plan is resolved
+	estimates []float64
 }
 
-func makeDependencyGraph(q *sparql.Query) *dependencyGraph {
+func makeDependencyGraph(q *sparql.Query, bound []string) *dependencyGraph {
 	dg := &dependencyGraph{
 		selectVars: []string{},
 		variables:  make(map[string]bool),
-		terms:      make([]*queryTerm, len(q.Where.Terms)),
+		bound:      bound,
+		sameVars:   make(map[string]string),
+	}
+	for _, sub := range q.Where.Subqueries {
+		dg.subqueryVars = append(dg.subqueryVars, sub.Select.Vars...)
 	}
 	for _, v := range q.Select.Vars {
 		dg.selectVars = append(dg.selectVars, v)
 	}
-	for i, term := range q.Where.Terms {
-		dg.terms[i] = dg.makeQueryTerm(term)
+	for _, triple := range q.Where.Terms {
+		for _, term := range dg.splitPath(triple) {
+			dg.terms = append(dg.terms, dg.makeQueryTerm(dg.renameRepeatedVars(term)))
+		}
 	}
+	return dg
+}
 
-	// find term with fewest variables
-	var next *queryTerm
-rootLoop:
-	for numvars := 1; numvars <= 3; numvars++ {
-		for idx, term := range dg.terms {
-			if len(term.variables) == numvars {
-				next = term
-				dg.terms = append(dg.terms[:idx], dg.terms[idx+1:]...)
-				break rootLoop
-			}
+// orders the terms into the plan. Terms without variables only check that
+// their triple is in the graph, so they go first. After them, the term that
+// shares a variable with the plan and is estimated to produce the fewest rows
+// is added, until there are none left. If no term shares a variable with the
+// plan, the cheapest term starts a new group, which is joined with the rest
+// by a cross product
+func (dg *dependencyGraph) order(cm *costModel) {
+	dg.plan = dg.plan[:0]
+	dg.estimates = dg.estimates[:0]
+	var terms []*queryTerm
+	for _, term := range dg.terms {
+		if len(term.variables) == 0 {
+			dg.plan = append(dg.plan, *term)
+			dg.estimates = append(dg.estimates, 1)
+		} else {
+			terms = append(terms, term)
 		}
 	}
-	dg.plan = append(dg.plan, *next)
 
-	for len(dg.terms) > 0 {
-		for idx, term := range dg.terms {
-			if term.overlap(next) > 0 {
-				next = term
-				dg.plan = append(dg.plan, *next)
-				dg.terms = append(dg.terms[:idx], dg.terms[idx+1:]...)
-				break
+	bound := make(map[string]bool)
+	for _, varname := range dg.bound {
+		bound[varname] = true
+	}
+	for _, varname := range dg.subqueryVars {
+		bound[varname] = true
+	}
+	isBound := func(varname string) bool { return bound[varname] }
+
+	// the cost of a term is the number of rows it produces for each row it is
+	// joined with, or for a single empty row if it starts a group
+	rows := 1.0
+	for len(terms) > 0 {
+		best, bestCost, bestJoined := -1, 0.0, false
+		for idx, term := range terms {
+			joined := false
+			for _, varname := range term.variables {
+				joined = joined || bound[varname]
+			}
+			if bestJoined && !joined {
+				continue
+			}
+			cost := cm.termCost(term, isBound)
+			if best < 0 || (joined && !bestJoined) || cost < bestCost ||
+				(cost == bestCost && dg.numUnbound(term, bound) < dg.numUnbound(terms[best], bound)) {
+				best, bestCost, bestJoined = idx, cost, joined
 			}
 		}
+		for _, varname := range terms[best].variables {
+			bound[varname] = true
+		}
+		rows *= bestCost
+		dg.plan = append(dg.plan, *terms[best])
+		dg.estimates = append(dg.estimates, rows)
+		terms = append(terms[:best], terms[best+1:]...)
 	}
-	return dg
+}
+
+func (dg *dependencyGraph) numUnbound(term *queryTerm, bound map[string]bool) int {
+	count := 0
+	for _, varname := range term.variables {
+		if !bound[varname] {
+			count++
+		}
+	}
+	return count
 }
 
 func (dg *dependencyGraph) dump() {
@@ -81,9 +144,11 @@ func (dg *dependencyGraph) makeQueryTerm(t sparql.Triple) *queryTerm {
 		dg.variables[qt.Subject.String()] = false
 		qt.variables = append(qt.variables, qt.Subject.String())
 	}
-	if qt.Predicates[0].Predicate.IsVariable() {
-		dg.variables[qt.Predicates[0].Predicate.String()] = false
-		qt.variables = append(qt.variables, qt.Predicates[0].Predicate.String())
+	for _, path := range qt.Predicates {
+		if path.Predicate.IsVariable() && !containsString(qt.variables, path.Predicate.String()) {
+			dg.variables[path.Predicate.String()] = false
+			qt.variables = append(qt.variables, path.Predicate.String())
+		}
 	}
 	if qt.Object.IsVariable() {
 		dg.variables[qt.Object.String()] = false
@@ -92,6 +157,84 @@ func (dg *dependencyGraph) makeQueryTerm(t sparql.Triple) *queryTerm {
 	return qt
 }
 
+// splits the path of the triple at its variable predicates, so that each of
+// them is resolved by a term of its own, one hop at a time. The steps of the
+// path are joined through new variables; runs of IRIs between the variables
+// stay together as a path
+func (dg *dependencyGraph) splitPath(triple sparql.Triple) []sparql.Triple {
+	split := false
+	for _, path := range triple.Predicates {
+		split = split || path.Predicate.IsVariable()
+	}
+	if len(triple.Predicates) == 1 || !split {
+		return []sparql.Triple{triple}
+	}
+	var (
+		terms   []sparql.Triple
+		subject = triple.Subject
+		run     []sparql.PathPattern
+	)
+	// the variable at the end of the next step of the path
+	next := func(last bool) turtle.URI {
+		if last {
+			return triple.Object
+		}
+		varname := fmt.Sprintf("?path.%d", len(dg.pathVars)+1)
+		dg.pathVars = append(dg.pathVars, varname)
+		return turtle.URI{Value: varname}
+	}
+	for idx, path := range triple.Predicates {
+		if !path.Predicate.IsVariable() {
+			run = append(run, path)
+			continue
+		}
+		if len(run) > 0 {
+			object := next(false)
+			terms = append(terms, sparql.Triple{Subject: subject, Predicates: run, Object: object})
+			subject, run = object, nil
+		}
+		object := next(idx == len(triple.Predicates)-1)
+		step := sparql.Triple{Subject: subject, Predicates: []sparql.PathPattern{path}, Object: object}
+		if path.Inverse {
+			step.Predicates[0].Inverse = false
+			step.Subject, step.Object = step.Object, step.Subject
+		}
+		terms = append(terms, step)
+		subject = object
+	}
+	if len(run) > 0 {
+		terms = append(terms, sparql.Triple{Subject: subject, Predicates: run, Object: triple.Object})
+	}
+	return terms
+}
+
+// replaces the second occurrence of a variable in the term, e.g. the object
+// of ?x bf:feeds ?x, with a new variable, so that the term is resolved like
+// any other. The plan then keeps the rows in which both are equal
+func (dg *dependencyGraph) renameRepeatedVars(triple sparql.Triple) sparql.Triple {
+	var seen []string
+	rename := func(uri turtle.URI) turtle.URI {
+		if !uri.IsVariable() {
+			return uri
+		}
+		if !containsString(seen, uri.String()) {
+			seen = append(seen, uri.String())
+			return uri
+		}
+		varname := fmt.Sprintf("%s.%d", uri, len(dg.pathVars)+1)
+		dg.pathVars = append(dg.pathVars, varname)
+		dg.sameVars[varname] = uri.String()
+		return turtle.URI{Value: varname}
+	}
+	triple.Subject = rename(triple.Subject)
+	if len(triple.Predicates) == 1 {
+		triple.Predicates = []sparql.PathPattern{triple.Predicates[0]}
+		triple.Predicates[0].Predicate = rename(triple.Predicates[0].Predicate)
+	}
+	triple.Object = rename(triple.Object)
+	return triple
+}
+
 // returns true if two query terms are equal
 func (qt *queryTerm) equals(qt2 *queryTerm) bool {
 	return qt.Subject == qt2.Subject &&
@@ -82,6 +82,42 @@ func (e *Entity) AddOutEdge(predicate, endpoint Key) bool {
 	return true
 }
 
+// returns true if we removed an endpoint; false if it was not there
+func (e *Entity) RemoveInEdge(predicate, endpoint Key) bool {
+	return removeEdge(e.InEdges, predicate, endpoint)
+}
+
+// true if the entity is the subject or object of any triple
+func (e *Entity) hasEdges() bool {
+	return len(e.InEdges) > 0 || len(e.OutEdges) > 0
+}
+
+// returns true if we removed an endpoint; false if it was not there
+func (e *Entity) RemoveOutEdge(predicate, endpoint Key) bool {
+	return removeEdge(e.OutEdges, predicate, endpoint)
+}
+
+// removes the endpoint from the edge list of the predicate. Empty edge lists
+// are removed from the map
+func removeEdge(edges map[string][]Key, predicate, endpoint Key) bool {
+	edgeList, found := edges[string(predicate[:])]
+	if !found {
+		return false
+	}
+	for idx, edge := range edgeList {
+		if edge == endpoint {
+			edgeList = append(edgeList[:idx], edgeList[idx+1:]...)
+			if len(edgeList) == 0 {
+				delete(edges, string(predicate[:]))
+			} else {
+				edges[string(predicate[:])] = edgeList
+			}
+			return true
+		}
+	}
+	return false
+}
+
 type PredicateEntity struct {
 	PK Key `msg:"p"`
 	// note: we have to use string keys to get msgp to work
@@ -124,6 +160,28 @@ func (e *PredicateEntity) AddSubjectObject(subject, object Key) bool {
 	return changed
 }
 
+// returns true if the subject is related to the object by this predicate
+func (e *PredicateEntity) HasSubjectObject(subject, object Key) bool {
+	_, found := e.Subjects[string(subject[:])][string(object[:])]
+	return found
+}
+
+// removes subject/object from Predicate index entry. Returns true if this changed the entity
+func (e *PredicateEntity) RemoveSubjectObject(subject, object Key) bool {
+	if !e.HasSubjectObject(subject, object) {
+		return false
+	}
+	delete(e.Subjects[string(subject[:])], string(object[:]))
+	if len(e.Subjects[string(subject[:])]) == 0 {
+		delete(e.Subjects, string(subject[:]))
+	}
+	delete(e.Objects[string(object[:])], string(subject[:]))
+	if len(e.Objects[string(object[:])]) == 0 {
+		delete(e.Objects, string(object[:]))
+	}
+	return true
+}
+
 //func (e *PredicateEntity) Dump(db *DB) {
 //	fmt.Printf("dump predicate> %s %p\n", db.MustGetURI(e.PK), e)
 //	for sub, objmap := range e.Subjects {
@@ -201,3 +259,12 @@ func (e *EntityExtendedIndex) AddInPlusEdge(predicate, endpoint Key) bool {
 	e.InPlusEdges[string(predicate[:])] = edgeList
 	return true
 }
+
+// removes all closure edges of the predicate. Returns true if this changed the index
+func (e *EntityExtendedIndex) RemovePlusEdges(predicate Key) bool {
+	_, foundIn := e.InPlusEdges[string(predicate[:])]
+	_, foundOut := e.OutPlusEdges[string(predicate[:])]
+	delete(e.InPlusEdges, string(predicate[:]))
+	delete(e.OutPlusEdges, string(predicate[:]))
+	return foundIn || foundOut
+}
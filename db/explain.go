@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+
+	"github.com/pkg/errors"
+)
+
+// ExplainResult is the answer to an EXPLAIN query: the plan that each
+// database would run for its WHERE clause. For EXPLAIN ANALYZE, the plans
+// have been run and also hold what each operation did
+type ExplainResult struct {
+	Analyzed bool
+	Plans    []PlanExplanation
+	Elapsed  time.Duration
+	Errors   []string
+}
+
+// PlanExplanation is the plan of a query on a database. A query with UNIONs
+// has a plan for each of its branches
+type PlanExplanation struct {
+	Database string
+	// the triples of the WHERE clause in the order they are resolved
+	Order      []string
+	Operations []OperationExplanation
+	// EXPLAIN ANALYZE: the rows after the plan was run, and how long it took
+	Rows    int
+	Elapsed time.Duration
+}
+
+// OperationExplanation is an operation of a plan
+type OperationExplanation struct {
+	Operation string
+	Variables []string
+	// the estimated number of rows after the operation
+	EstimatedRows float64
+	// EXPLAIN ANALYZE: the rows after the operation, the number of distinct
+	// values of each of its variables, and how long it took
+	Rows    int
+	Values  map[string]int
+	Elapsed time.Duration
+}
+
+// Explains a parsed EXPLAIN or EXPLAIN ANALYZE query. The WHERE clause is
+// planned, and for EXPLAIN ANALYZE run, on each of the queried databases
+func (hod *HodDB) Explain(q *sparql.Query) (ExplainResult, error) {
+	return hod.ExplainContext(context.Background(), q)
+}
+
+// Explains a query. EXPLAIN ANALYZE is canceled when the context is done
+func (hod *HodDB) ExplainContext(goctx context.Context, q *sparql.Query) (ExplainResult, error) {
+	start := time.Now()
+	if q.IsUpdate() {
+		return ExplainResult{}, errors.New("EXPLAIN is not supported for INSERT and DELETE")
+	}
+	databases := hod.queryDatabases(q)
+	if err := checkPrefixes(q, databases); err != nil {
+		return ExplainResult{}, err
+	}
+	runq, _, _ := prepareQuery(q)
+	goctx, cancel := hod.withQueryTimeout(goctx)
+	defer cancel()
+
+	var dbnames []string
+	for dbname := range databases {
+		dbnames = append(dbnames, dbname)
+	}
+	sort.Strings(dbnames)
+
+	result := ExplainResult{Analyzed: q.Explain == sparql.EXPLAIN_ANALYZE}
+	for _, dbname := range dbnames {
+		plans, err := databases[dbname].explain(goctx, runq, result.Analyzed)
+		if cancelErr := canceled(goctx); cancelErr != nil {
+			return result, cancelErr
+		} else if err != nil {
+			err := errors.Wrapf(err, "Error explaining query on %s", dbname)
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.Plans = append(result.Plans, plans...)
+	}
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// plans the WHERE clause of the query, and runs the plans if analyze is true
+func (db *DB) explain(goctx context.Context, q *sparql.Query, analyze bool) ([]PlanExplanation, error) {
+	q.MapURIs(func(uri turtle.URI) turtle.URI {
+		return db.expandPrefixed(q.Prefixes, uri)
+	})
+	branches := unionBranches(q)
+
+	var plans []PlanExplanation
+	for _, branch := range branches {
+		dg := makeDependencyGraph(branch, branch.Where.Values.Vars)
+		qp, err := db.formQueryPlan(dg, branch)
+		if err != nil {
+			return nil, err
+		}
+		plan := PlanExplanation{Database: db.name}
+		for _, term := range dg.plan {
+			plan.Order = append(plan.Order, term.Triple.String())
+		}
+		// operations that do not resolve a term keep the estimate of the
+		// operation before them
+		var estimate float64
+		for _, op := range qp.operations {
+			if est, found := qp.estimates[op]; found {
+				estimate = est
+			}
+			plan.Operations = append(plan.Operations, OperationExplanation{
+				Operation:     op.String(),
+				Variables:     op.GetTerm().variables,
+				EstimatedRows: estimate,
+			})
+		}
+		if analyze {
+			if err := db.analyze(goctx, qp, &plan); err != nil {
+				return nil, err
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// runs the query plan and records what each of its operations did
+func (db *DB) analyze(goctx context.Context, qp *queryPlan, plan *PlanExplanation) error {
+	ctx, err := newQueryContext(goctx, qp, db)
+	if err != nil {
+		return errors.Wrap(err, "Could not get snapshot")
+	}
+	defer ctx.t.under.done()
+	ctx.trace = func(idx int, op operation, elapsed time.Duration) {
+		opexp := &plan.Operations[idx]
+		opexp.Rows = len(ctx.rel.rows)
+		opexp.Values = make(map[string]int)
+		for _, varname := range op.GetTerm().variables {
+			opexp.Values[varname] = ctx.cardinalityUnique(varname)
+		}
+		opexp.Elapsed = elapsed
+	}
+	start := time.Now()
+	if err := ctx.execute(); err != nil {
+		return err
+	}
+	plan.Rows = len(ctx.rel.rows)
+	plan.Elapsed = time.Since(start)
+	return nil
+}
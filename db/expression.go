@@ -0,0 +1,642 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
+)
+
+type valueKind uint
+
+const (
+	valueUnbound valueKind = iota
+	valueURI
+	valueString
+	valueNumber
+	valueBoolean
+	valueDateTime
+)
+
+// the result of evaluating an expression. For values that come from a term,
+// uri holds the term and str its lexical form
+type exprValue struct {
+	kind valueKind
+	uri  turtle.URI
+	str  string
+	num  float64
+	b    bool
+	t    time.Time
+}
+
+var errUnbound = errors.New("Variable is unbound")
+
+// the numeric XML Schema datatypes
+var numericDatatypes = map[string]bool{
+	turtle.XSDInteger:                           true,
+	turtle.XSDDecimal:                           true,
+	turtle.XSDDouble:                            true,
+	turtle.XSDNamespace + "#float":              true,
+	turtle.XSDNamespace + "#int":                true,
+	turtle.XSDNamespace + "#long":               true,
+	turtle.XSDNamespace + "#short":              true,
+	turtle.XSDNamespace + "#nonNegativeInteger": true,
+	turtle.XSDNamespace + "#positiveInteger":    true,
+	turtle.XSDNamespace + "#unsignedInt":        true,
+	turtle.XSDNamespace + "#unsignedLong":       true,
+}
+
+// converts a value stored in the graph into an expression value. Entities
+// without a namespace are literals; literals with a numeric, boolean or
+// dateTime datatype are converted to that type if their lexical form is valid
+func valueFromURI(uri turtle.URI) exprValue {
+	if uri.Namespace != "" {
+		return exprValue{kind: valueURI, uri: uri}
+	}
+	val := exprValue{kind: valueString, uri: uri, str: uri.Value}
+	switch {
+	case numericDatatypes[uri.Datatype]:
+		if num, err := strconv.ParseFloat(strings.TrimSpace(uri.Value), 64); err == nil {
+			val.kind, val.num = valueNumber, num
+		}
+	case uri.Datatype == turtle.XSDBoolean:
+		if b, err := strconv.ParseBool(strings.TrimSpace(uri.Value)); err == nil {
+			val.kind, val.b = valueBoolean, b
+		}
+	case uri.Datatype == turtle.XSDDateTime:
+		if t, err := parseDateTime(uri.Value); err == nil {
+			val.kind, val.t = valueDateTime, t
+		}
+	}
+	return val
+}
+
+// parses an xsd:dateTime. Times without a timezone are taken to be UTC
+func parseDateTime(str string) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	if t, err := time.Parse(time.RFC3339Nano, str); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02T15:04:05.999999999", str)
+}
+
+// the lexical form of the value
+func (v exprValue) String() string {
+	switch v.kind {
+	case valueURI:
+		return v.uri.IRI()
+	case valueString, valueDateTime:
+		return v.str
+	case valueNumber:
+		if v.str != "" {
+			return v.str
+		}
+		return strconv.FormatFloat(v.num, 'f', -1, 64)
+	case valueBoolean:
+		if v.str != "" {
+			return v.str
+		}
+		return strconv.FormatBool(v.b)
+	}
+	return ""
+}
+
+// returns the value as a time. Strings are parsed so that dateTime literals
+// can be compared with plain strings
+func (v exprValue) dateTime() (time.Time, error) {
+	switch v.kind {
+	case valueDateTime:
+		return v.t, nil
+	case valueString:
+		return parseDateTime(v.str)
+	}
+	return time.Time{}, fmt.Errorf("%s is not a dateTime", v)
+}
+
+// the datatype IRI of a literal value
+func (v exprValue) datatype() (string, error) {
+	switch {
+	case v.kind == valueURI || v.kind == valueUnbound:
+		return "", fmt.Errorf("%s is not a literal", v)
+	case v.uri.Datatype != "":
+		return v.uri.Datatype, nil
+	case v.uri.Lang != "":
+		return "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString", nil
+	}
+	switch v.kind {
+	case valueNumber:
+		return turtle.XSDDecimal, nil
+	case valueBoolean:
+		return turtle.XSDBoolean, nil
+	case valueDateTime:
+		return turtle.XSDDateTime, nil
+	}
+	return turtle.XSDString, nil
+}
+
+// returns the value as a number. Strings are parsed so that numeric
+// literals stored in the graph can be compared with numbers
+func (v exprValue) number() (float64, error) {
+	switch v.kind {
+	case valueNumber:
+		return v.num, nil
+	case valueString:
+		return strconv.ParseFloat(strings.TrimSpace(v.str), 64)
+	}
+	return 0, fmt.Errorf("%s is not a number", v)
+}
+
+// the SPARQL "effective boolean value" of the value
+func (v exprValue) effectiveBool() (bool, error) {
+	switch v.kind {
+	case valueBoolean:
+		return v.b, nil
+	case valueString:
+		return v.str != "", nil
+	case valueNumber:
+		return v.num != 0 && !math.IsNaN(v.num), nil
+	case valueUnbound:
+		return false, errUnbound
+	}
+	return false, fmt.Errorf("%s has no boolean value", v)
+}
+
+func boolValue(b bool) exprValue {
+	return exprValue{kind: valueBoolean, b: b}
+}
+
+func stringValue(str string) exprValue {
+	return exprValue{kind: valueString, str: str}
+}
+
+func integerValue(i int) exprValue {
+	return valueFromURI(turtle.TypedLiteral(strconv.Itoa(i), turtle.XSDInteger))
+}
+
+// resolves variables to their values while evaluating an expression.
+// Unbound variables return errUnbound
+type bindings interface {
+	lookup(varname string) (turtle.URI, error)
+}
+
+// the bindings of a row in the relation of a query context
+type relationRow struct {
+	ctx *queryContext
+	row *Row
+}
+
+func (r relationRow) lookup(varname string) (turtle.URI, error) {
+	pos, found := r.ctx.variablePosition[varname]
+	if !found {
+		return turtle.URI{}, errUnbound
+	}
+	key := r.row.valueAt(pos)
+	if key == emptyKey {
+		return turtle.URI{}, errUnbound
+	}
+	return r.ctx.t.getURI(key)
+}
+
+// evaluates expressions against rows of a relation; regexes are compiled once
+// and reused
+type evaluator struct {
+	ctx     *queryContext
+	regexes map[string]*regexp.Regexp
+}
+
+func newEvaluator(ctx *queryContext) *evaluator {
+	return &evaluator{
+		ctx:     ctx,
+		regexes: make(map[string]*regexp.Regexp),
+	}
+}
+
+// evaluates the filter against the row. Any error (e.g. an unbound variable or
+// a type error) means the row does not pass the filter
+func (ev *evaluator) filter(filter sparql.Filter, row *Row) bool {
+	val, err := ev.eval(filter.Expression, relationRow{ev.ctx, row})
+	if err != nil {
+		return false
+	}
+	b, err := val.effectiveBool()
+	return err == nil && b
+}
+
+func (ev *evaluator) eval(expr sparql.Expression, row bindings) (exprValue, error) {
+	switch e := expr.(type) {
+	case sparql.VarExpression:
+		uri, err := row.lookup(e.Name)
+		if err != nil {
+			return exprValue{}, err
+		}
+		return valueFromURI(uri), nil
+	case sparql.TermExpression:
+		return valueFromURI(e.URI), nil
+	case sparql.LiteralExpression:
+		switch e.Type {
+		case sparql.LITERAL_INTEGER:
+			return valueFromURI(turtle.TypedLiteral(e.Value, turtle.XSDInteger)), nil
+		case sparql.LITERAL_DECIMAL:
+			return valueFromURI(turtle.TypedLiteral(e.Value, turtle.XSDDecimal)), nil
+		case sparql.LITERAL_BOOLEAN:
+			return boolValue(e.Value == "true"), nil
+		}
+		return exprValue{kind: valueString, str: e.Value}, nil
+	case sparql.UnaryExpression:
+		return ev.evalUnary(e, row)
+	case sparql.BinaryExpression:
+		return ev.evalBinary(e, row)
+	case sparql.FunctionCall:
+		return ev.evalFunction(e, row)
+	case sparql.AggregateExpression:
+		if group, ok := row.(groupBindings); ok {
+			return group.aggregate(e)
+		}
+		return exprValue{}, fmt.Errorf("Aggregate %s used outside of a group", e)
+	}
+	return exprValue{}, fmt.Errorf("Unknown expression %s", expr)
+}
+
+func (ev *evaluator) evalUnary(e sparql.UnaryExpression, row bindings) (exprValue, error) {
+	val, err := ev.eval(e.Operand, row)
+	if err != nil {
+		return val, err
+	}
+	switch e.Op {
+	case "!":
+		b, err := val.effectiveBool()
+		return boolValue(!b), err
+	case "-":
+		num, err := val.number()
+		return exprValue{kind: valueNumber, num: -num}, err
+	case "+":
+		num, err := val.number()
+		return exprValue{kind: valueNumber, num: num}, err
+	}
+	return exprValue{}, fmt.Errorf("Unknown operator %s", e.Op)
+}
+
+func (ev *evaluator) evalBinary(e sparql.BinaryExpression, row bindings) (exprValue, error) {
+	// logical operators follow the SPARQL error semantics: an error on one
+	// side can be masked by the other side
+	switch e.Op {
+	case "||", "&&":
+		var lb, rb bool
+		left, lerr := ev.eval(e.Left, row)
+		if lerr == nil {
+			lb, lerr = left.effectiveBool()
+		}
+		right, rerr := ev.eval(e.Right, row)
+		if rerr == nil {
+			rb, rerr = right.effectiveBool()
+		}
+		if e.Op == "||" {
+			switch {
+			case (lerr == nil && lb) || (rerr == nil && rb):
+				return boolValue(true), nil
+			case lerr != nil:
+				return exprValue{}, lerr
+			case rerr != nil:
+				return exprValue{}, rerr
+			}
+			return boolValue(false), nil
+		}
+		switch {
+		case (lerr == nil && !lb) || (rerr == nil && !rb):
+			return boolValue(false), nil
+		case lerr != nil:
+			return exprValue{}, lerr
+		case rerr != nil:
+			return exprValue{}, rerr
+		}
+		return boolValue(true), nil
+	}
+
+	left, err := ev.eval(e.Left, row)
+	if err != nil {
+		return left, err
+	}
+	right, err := ev.eval(e.Right, row)
+	if err != nil {
+		return right, err
+	}
+
+	switch e.Op {
+	case "=", "!=":
+		eq, err := valuesEqual(left, right)
+		if e.Op == "!=" {
+			eq = !eq
+		}
+		return boolValue(eq), err
+	case "<", ">", "<=", ">=":
+		cmp, err := compareValues(left, right)
+		if err != nil {
+			return exprValue{}, err
+		}
+		switch e.Op {
+		case "<":
+			return boolValue(cmp < 0), nil
+		case ">":
+			return boolValue(cmp > 0), nil
+		case "<=":
+			return boolValue(cmp <= 0), nil
+		}
+		return boolValue(cmp >= 0), nil
+	}
+
+	// arithmetic
+	l, err := left.number()
+	if err != nil {
+		return exprValue{}, err
+	}
+	r, err := right.number()
+	if err != nil {
+		return exprValue{}, err
+	}
+	switch e.Op {
+	case "+":
+		return exprValue{kind: valueNumber, num: l + r}, nil
+	case "-":
+		return exprValue{kind: valueNumber, num: l - r}, nil
+	case "*":
+		return exprValue{kind: valueNumber, num: l * r}, nil
+	case "/":
+		if r == 0 {
+			return exprValue{}, errors.New("Division by zero")
+		}
+		return exprValue{kind: valueNumber, num: l / r}, nil
+	}
+	return exprValue{}, fmt.Errorf("Unknown operator %s", e.Op)
+}
+
+func valuesEqual(left, right exprValue) (bool, error) {
+	switch {
+	case left.kind == valueURI || right.kind == valueURI:
+		return left.kind == right.kind && left.uri == right.uri, nil
+	case left.kind == valueNumber || right.kind == valueNumber,
+		left.kind == valueDateTime || right.kind == valueDateTime:
+		cmp, err := compareValues(left, right)
+		return cmp == 0, err
+	case left.kind == valueBoolean && right.kind == valueBoolean:
+		return left.b == right.b, nil
+	}
+	return left.String() == right.String(), nil
+}
+
+// returns -1, 0 or 1. Numbers compare numerically, dateTimes compare
+// chronologically, and everything else compares by lexical form
+func compareValues(left, right exprValue) (int, error) {
+	if left.kind == valueDateTime || right.kind == valueDateTime {
+		l, err := left.dateTime()
+		if err != nil {
+			return 0, err
+		}
+		r, err := right.dateTime()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case l.Before(r):
+			return -1, nil
+		case l.After(r):
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if left.kind == valueNumber || right.kind == valueNumber {
+		l, err := left.number()
+		if err != nil {
+			return 0, err
+		}
+		r, err := right.number()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case l < r:
+			return -1, nil
+		case l > r:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if left.kind == valueBoolean && right.kind == valueBoolean {
+		switch {
+		case left.b == right.b:
+			return 0, nil
+		case !left.b:
+			return -1, nil
+		}
+		return 1, nil
+	}
+	return strings.Compare(left.String(), right.String()), nil
+}
+
+func (ev *evaluator) evalFunction(e sparql.FunctionCall, row bindings) (exprValue, error) {
+	// BOUND is the only function that accepts unbound arguments
+	if e.Function == "BOUND" {
+		if len(e.Args) != 1 {
+			return exprValue{}, errors.New("BOUND takes 1 argument")
+		}
+		if _, ok := e.Args[0].(sparql.VarExpression); !ok {
+			return exprValue{}, errors.New("BOUND takes a variable")
+		}
+		_, err := ev.eval(e.Args[0], row)
+		if err == errUnbound {
+			return boolValue(false), nil
+		}
+		return boolValue(err == nil), err
+	}
+
+	args := make([]exprValue, len(e.Args))
+	for idx, arg := range e.Args {
+		val, err := ev.eval(arg, row)
+		if err != nil {
+			return val, err
+		}
+		args[idx] = val
+	}
+	nargs := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("Wrong number of arguments to %s", e.Function)
+		}
+		return nil
+	}
+
+	switch e.Function {
+	case "STR":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		return stringValue(args[0].String()), nil
+	case "IRI", "URI":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		if args[0].kind == valueURI {
+			return args[0], nil
+		}
+		if args[0].kind != valueString || args[0].str == "" {
+			return exprValue{}, fmt.Errorf("%s is not an IRI", args[0])
+		}
+		return valueFromURI(turtle.ParseURI(args[0].str)), nil
+	case "STRLEN":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		return integerValue(utf8.RuneCountInString(args[0].String())), nil
+	case "SUBSTR":
+		if err := nargs(2, 3); err != nil {
+			return exprValue{}, err
+		}
+		// positions are 1-based and count characters
+		runes := []rune(args[0].String())
+		start, err := args[1].number()
+		if err != nil {
+			return exprValue{}, err
+		}
+		end := float64(len(runes) + 1)
+		if len(args) == 3 {
+			length, err := args[2].number()
+			if err != nil {
+				return exprValue{}, err
+			}
+			end = math.Min(end, start+length)
+		}
+		from, to := int(math.Max(start, 1))-1, int(end)-1
+		if from >= to {
+			return stringValue(""), nil
+		}
+		return stringValue(string(runes[from:to])), nil
+	case "UCASE", "LCASE":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		if e.Function == "UCASE" {
+			return stringValue(strings.ToUpper(args[0].String())), nil
+		}
+		return stringValue(strings.ToLower(args[0].String())), nil
+	case "STRBEFORE", "STRAFTER":
+		if err := nargs(2, 2); err != nil {
+			return exprValue{}, err
+		}
+		str, sep := args[0].String(), args[1].String()
+		idx := strings.Index(str, sep)
+		switch {
+		case idx < 0:
+			return stringValue(""), nil
+		case e.Function == "STRBEFORE":
+			return stringValue(str[:idx]), nil
+		}
+		return stringValue(str[idx+len(sep):]), nil
+	case "CONCAT":
+		var parts []string
+		for _, arg := range args {
+			parts = append(parts, arg.String())
+		}
+		return stringValue(strings.Join(parts, "")), nil
+	case "REPLACE":
+		if err := nargs(3, 4); err != nil {
+			return exprValue{}, err
+		}
+		pattern := args[1].String()
+		if len(args) == 4 && args[3].String() != "" {
+			pattern = "(?" + args[3].String() + ")" + pattern
+		}
+		re, err := ev.regex(pattern)
+		if err != nil {
+			return exprValue{}, err
+		}
+		return stringValue(re.ReplaceAllString(args[0].String(), args[2].String())), nil
+	case "ENCODE_FOR_URI":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		return stringValue(url.PathEscape(args[0].String())), nil
+	case "REGEX":
+		if err := nargs(2, 3); err != nil {
+			return exprValue{}, err
+		}
+		pattern := args[1].String()
+		if len(args) == 3 && args[2].String() != "" {
+			pattern = "(?" + args[2].String() + ")" + pattern
+		}
+		re, err := ev.regex(pattern)
+		if err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(re.MatchString(args[0].String())), nil
+	case "STRSTARTS":
+		if err := nargs(2, 2); err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(strings.HasPrefix(args[0].String(), args[1].String())), nil
+	case "STRENDS":
+		if err := nargs(2, 2); err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(strings.HasSuffix(args[0].String(), args[1].String())), nil
+	case "CONTAINS":
+		if err := nargs(2, 2); err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(strings.Contains(args[0].String(), args[1].String())), nil
+	case "ISIRI", "ISURI":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(args[0].kind == valueURI), nil
+	case "ISLITERAL":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		return boolValue(args[0].kind != valueURI), nil
+	case "LANG":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		if args[0].kind == valueURI {
+			return exprValue{}, fmt.Errorf("%s is not a literal", args[0])
+		}
+		return stringValue(args[0].uri.Lang), nil
+	case "LANGMATCHES":
+		if err := nargs(2, 2); err != nil {
+			return exprValue{}, err
+		}
+		tag, lrange := strings.ToLower(args[0].String()), strings.ToLower(args[1].String())
+		if lrange == "*" {
+			return boolValue(tag != ""), nil
+		}
+		return boolValue(tag == lrange || strings.HasPrefix(tag, lrange+"-")), nil
+	case "DATATYPE":
+		if err := nargs(1, 1); err != nil {
+			return exprValue{}, err
+		}
+		datatype, err := args[0].datatype()
+		if err != nil {
+			return exprValue{}, err
+		}
+		return exprValue{kind: valueURI, uri: turtle.ParseURI(datatype)}, nil
+	}
+	return exprValue{}, fmt.Errorf("Unknown function %s", e.Function)
+}
+
+func (ev *evaluator) regex(pattern string) (*regexp.Regexp, error) {
+	if re, found := ev.regexes[pattern]; found {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid regex %s", pattern)
+	}
+	ev.regexes[pattern] = re
+	return re, nil
+}
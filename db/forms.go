@@ -0,0 +1,257 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Runs an ASK query: the WHERE clause is evaluated until the first solution
+// is found
+func (hod *HodDB) Ask(q *sparql.Query) (AskResult, error) {
+	return hod.AskContext(context.Background(), q)
+}
+
+// Runs an ASK query, which is canceled when the context is done
+func (hod *HodDB) AskContext(goctx context.Context, q *sparql.Query) (AskResult, error) {
+	start := time.Now()
+	if !q.IsAsk() {
+		return AskResult{}, errors.New("Not an ASK query")
+	}
+	selq := q.Copy()
+	selq.Type = sparql.SELECT_QUERY
+	selq.Count = true
+	selq.HasLimit, selq.Limit, selq.Offset = true, 1, 0
+	res, err := hod.RunQueryContext(goctx, selq)
+	if err != nil {
+		return AskResult{}, err
+	}
+	return AskResult{
+		Result:  res.Count > 0,
+		Elapsed: time.Since(start),
+		Errors:  res.Errors,
+	}, nil
+}
+
+// Runs a CONSTRUCT query. As with updates, the template is instantiated with
+// the solutions of each database separately, so LIMIT and OFFSET apply to
+// the solutions of each database
+func (hod *HodDB) Construct(q *sparql.Query) (ConstructResult, error) {
+	return hod.ConstructContext(context.Background(), q)
+}
+
+// Runs a CONSTRUCT query, which is canceled when the context is done
+func (hod *HodDB) ConstructContext(goctx context.Context, q *sparql.Query) (ConstructResult, error) {
+	start := time.Now()
+	var result = ConstructResult{Namespaces: make(map[string]string)}
+	if !q.IsConstruct() {
+		return result, errors.New("Not a CONSTRUCT query")
+	}
+	var graph = newTripleSet()
+	err := hod.forEachDatabase(goctx, q, func(db *DB, rows []ResultMap) error {
+		for _, triple := range db.instantiateTemplate(q.Construct.Terms, q.Prefixes, rows).Triples {
+			graph.add(triple)
+		}
+		return nil
+	}, &result.Errors)
+	if err != nil {
+		return result, err
+	}
+	for _, db := range hod.queryDatabases(q) {
+		db.addNamespaces(result.Namespaces)
+	}
+	result.Triples = graph.sorted()
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// Runs a DESCRIBE query, returning all edges into and out of each described
+// entity
+func (hod *HodDB) Describe(q *sparql.Query) (DescribeResult, error) {
+	return hod.DescribeContext(context.Background(), q)
+}
+
+// Runs a DESCRIBE query, which is canceled when the context is done
+func (hod *HodDB) DescribeContext(goctx context.Context, q *sparql.Query) (DescribeResult, error) {
+	start := time.Now()
+	var result = DescribeResult{Namespaces: make(map[string]string)}
+	if !q.IsDescribe() {
+		return result, errors.New("Not a DESCRIBE query")
+	}
+	var (
+		graph    = newTripleSet()
+		entities = make(map[turtle.URI]struct{})
+	)
+	err := hod.forEachDatabase(goctx, q, func(db *DB, rows []ResultMap) error {
+		var describe []turtle.URI
+		for _, term := range q.Describe.Terms {
+			if !term.IsVariable() {
+				describe = append(describe, db.expandPrefixed(q.Prefixes, term))
+			}
+		}
+		for _, row := range rows {
+			for _, varname := range q.Select.Vars {
+				if value, found := row[varname]; found && !value.IsLiteral() {
+					describe = append(describe, value)
+				}
+			}
+		}
+		found, triples, err := db.describe(describe)
+		if err != nil {
+			return err
+		}
+		for _, uri := range found {
+			entities[uri] = struct{}{}
+		}
+		for _, triple := range triples {
+			graph.add(triple)
+		}
+		return nil
+	}, &result.Errors)
+	if err != nil {
+		return result, err
+	}
+	for _, db := range hod.queryDatabases(q) {
+		db.addNamespaces(result.Namespaces)
+	}
+	for uri := range entities {
+		result.Entities = append(result.Entities, uri)
+	}
+	sort.Slice(result.Entities, func(i, j int) bool {
+		return result.Entities[i].String() < result.Entities[j].String()
+	})
+	result.Triples = graph.sorted()
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// evaluates the WHERE clause of the query on each of its databases in turn,
+// and calls f with the database and its solutions. Queries without a WHERE
+// clause have no solutions. Errors from evaluating the WHERE clause are
+// added to errs
+func (hod *HodDB) forEachDatabase(goctx context.Context, q *sparql.Query, f func(db *DB, rows []ResultMap) error, errs *[]string) error {
+	databases := hod.queryDatabases(q)
+	if err := checkPrefixes(q, databases); err != nil {
+		return err
+	}
+	var dbnames []string
+	for dbname := range databases {
+		dbnames = append(dbnames, dbname)
+	}
+	sort.Strings(dbnames)
+	for _, dbname := range dbnames {
+		var rows []ResultMap
+		if !whereIsEmpty(q) {
+			selq := q.Copy()
+			selq.Type = sparql.SELECT_QUERY
+			selq.From = sparql.FromClause{Databases: []string{dbname}}
+			res, err := hod.RunQueryContext(goctx, selq)
+			if err != nil {
+				return err
+			}
+			*errs = append(*errs, res.Errors...)
+			rows = res.Rows
+		}
+		if err := f(databases[dbname], rows); err != nil {
+			return errors.Wrapf(err, "Error running query on %s", dbname)
+		}
+	}
+	return nil
+}
+
+// returns the entities in the database and the edges into and out of them
+func (db *DB) describe(entities []turtle.URI) ([]turtle.URI, []turtle.Triple, error) {
+	snap, err := db.snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer snap.Close()
+
+	var (
+		found   []turtle.URI
+		triples []turtle.Triple
+	)
+	edges := func(edgemap map[string][]Key, add func(predicate, endpoint turtle.URI)) error {
+		for pred, endpoints := range edgemap {
+			var predicateHash Key
+			predicateHash.FromSlice([]byte(pred))
+			predicate, err := snap.getURI(predicateHash)
+			if err != nil {
+				return err
+			}
+			for _, endpointHash := range endpoints {
+				endpoint, err := snap.getURI(endpointHash)
+				if err != nil {
+					return err
+				}
+				add(predicate, endpoint)
+			}
+		}
+		return nil
+	}
+	for _, uri := range entities {
+		entity, err := snap.getEntityByURI(uri)
+		if errors.Cause(err) == leveldb.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		found = append(found, uri)
+		if err := edges(entity.OutEdges, func(predicate, object turtle.URI) {
+			triples = append(triples, turtle.Triple{Subject: uri, Predicate: predicate, Object: object})
+		}); err != nil {
+			return nil, nil, err
+		}
+		if err := edges(entity.InEdges, func(predicate, subject turtle.URI) {
+			triples = append(triples, turtle.Triple{Subject: subject, Predicate: predicate, Object: uri})
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return found, triples, nil
+}
+
+// adds the namespace prefixes of the database that are not in namespaces yet
+func (db *DB) addNamespaces(namespaces map[string]string) {
+	for abbr, full := range db.namespaces {
+		if _, found := namespaces[abbr]; !found && abbr != "" {
+			namespaces[abbr] = full
+		}
+	}
+}
+
+// a set of triples
+type tripleSet map[turtle.Triple]struct{}
+
+func newTripleSet() tripleSet {
+	return make(tripleSet)
+}
+
+func (set tripleSet) add(triple turtle.Triple) {
+	set[triple] = struct{}{}
+}
+
+// returns the triples ordered by subject, predicate and object
+func (set tripleSet) sorted() []turtle.Triple {
+	var triples = make([]turtle.Triple, 0, len(set))
+	for triple := range set {
+		triples = append(triples, triple)
+	}
+	sort.Slice(triples, func(i, j int) bool {
+		ti, tj := triples[i], triples[j]
+		if ti.Subject != tj.Subject {
+			return ti.Subject.String() < tj.Subject.String()
+		}
+		if ti.Predicate != tj.Predicate {
+			return ti.Predicate.String() < tj.Predicate.String()
+		}
+		return ti.Object.String() < tj.Object.String()
+	})
+	return triples
+}
@@ -2,16 +2,20 @@ package db
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode"
 
 	"github.com/gtfierro/btree"
 	"github.com/gtfierro/hod/config"
@@ -135,6 +139,9 @@ func NewHodDB(cfg *config.Config) (*HodDB, error) {
 					}
 					hod.dbs.Store(buildingname, db)
 					hod.buildings = append(hod.buildings, buildingname)
+					if err := hod.loadLinks(buildingname, false); err != nil {
+						errchan <- err
+					}
 					loadwg.Done()
 					continue
 				}
@@ -148,6 +155,9 @@ func NewHodDB(cfg *config.Config) (*HodDB, error) {
 					loadwg.Done()
 					continue
 				}
+				if err := hod.loadLinks(buildingname, true); err != nil {
+					errchan <- err
+				}
 				loadwg.Done()
 			}
 		}()
@@ -228,11 +238,9 @@ func (hod *HodDB) Databases() []string {
 	return hod.buildings
 }
 
-// Execute a parsed query against HodDB
-func (hod *HodDB) RunQuery(q *sparql.Query) (QueryResult, error) {
+// returns the databases named in the FROM clause of the query
+func (hod *HodDB) queryDatabases(q *sparql.Query) map[string]*DB {
 	var databases = make(map[string]*DB)
-	fullQueryStart := time.Now()
-
 	if q.From.AllDBs {
 		hod.dbs.Range(func(_dbname, _db interface{}) bool {
 			dbname := _dbname.(string)
@@ -248,63 +256,187 @@ func (hod *HodDB) RunQuery(q *sparql.Query) (QueryResult, error) {
 			}
 		}
 	}
+	return databases
+}
 
-	var wg sync.WaitGroup
-	wg.Add(len(databases))
-	//var rowlock sync.Mutex
-	unionedRows := btree.New(4, "")
-	var result QueryResult
+// Execute a parsed query against HodDB. ASK, CONSTRUCT and DESCRIBE queries
+// are run with Ask, Construct and Describe, and EXPLAIN queries with Explain
+func (hod *HodDB) RunQuery(q *sparql.Query) (QueryResult, error) {
+	return hod.RunQueryContext(context.Background(), q)
+}
+
+// Execute a parsed query against HodDB. The query is canceled when the
+// context is done or the QueryTimeout of the config has passed, and returns
+// a QueryCanceledError
+func (hod *HodDB) RunQueryContext(goctx context.Context, q *sparql.Query) (QueryResult, error) {
+	fullQueryStart := time.Now()
+	if q.IsAsk() || q.IsConstruct() || q.IsDescribe() {
+		return QueryResult{}, errors.New("ASK, CONSTRUCT and DESCRIBE queries do not return rows")
+	}
+	if q.IsExplain() {
+		return QueryResult{}, errors.New("EXPLAIN queries do not return rows")
+	}
+
+	databases := hod.queryDatabases(q)
+	if err := checkPrefixes(q, databases); err != nil {
+		return QueryResult{}, err
+	}
+
+	runq, orderBy, groups := prepareQuery(q)
+	goctx, cancel := hod.withQueryTimeout(goctx)
+	defer cancel()
+
+	// the rows found in each database are cached, unless the query asks not
+	// to be. Hashed before the query runs, which expands its prefixes
+	var queryHash []byte
+	if !q.NoCache && !q.IsUpdate() && groups == nil {
+		var err error
+		if queryHash, err = hashQuery(runq); err != nil {
+			log.Warning(err)
+		}
+	}
+
+	var (
+		result   QueryResult
+		stats    = new(queryStats)
+		names    = sortedDatabaseNames(databases)
+		outcomes = make(map[string]*queryOutcome, len(names))
+		// the rows of a single database and UNION branch need not be paged
+		// together with others, so only the rows of the page are decoded
+		paged = len(names) == 1 && !q.IsUpdate() && rowLimit(runq) >= 0 && len(unionBranches(runq)) == 1
+	)
 	result.selectVars = q.Select.Vars
-	var stats = new(queryStats)
+	result.Databases = make(map[string]DatabaseStatus, len(names))
+	for _, dbname := range names {
+		outcomes[dbname] = new(queryOutcome)
+	}
 
-	for dbname, db := range databases {
-		//go func() {
+	hod.eachDatabase(names, func(dbname string) {
+		var (
+			db    = databases[dbname]
+			out   = outcomes[dbname]
+			start = time.Now()
+			// running the query expands its prefixes with those of the
+			// database, so each database gets its own copy
+			dbq = runq.DeepCopy()
+		)
+		defer func() {
+			out.elapsed = time.Since(start)
+		}()
+		if out.err = canceled(goctx); out.err != nil {
+			return
+		}
+		// solutions of aggregate queries are added to their groups, which
+		// are turned into rows once all databases have been queried
+		if groups != nil {
+			out.stats, out.err = db.runGroupQuery(goctx, dbq, groups)
+			out.solutions = out.stats.NumResults
+			return
+		}
+		if !q.IsUpdate() || !whereIsEmpty(q) {
+			out.rows, out.stats, out.err = db.runCachedQuery(goctx, dbq, queryHash, paged)
+			out.solutions = len(out.rows)
+			log.Debugf("%+v", out.stats)
+		}
+		// handle DELETE/INSERT query: each database is updated with the
+		// rows from its own WHERE clause
+		if q.IsUpdate() {
+			if err := canceled(goctx); err != nil {
+				out.err = err
+				return
+			}
+			updatestats, err := db.handleUpdate(dbq, updateRows(q, out.rows))
+			out.updateErr = err
+			out.updated = err == nil && updatestats.NumInserted+updatestats.NumDeleted > 0
+			out.stats.merge(updatestats)
+		}
+	})
 
-		// handle SELECT query
-		//if q.IsSelect() {
-		singleresult, _stats, err := db.runQuery(q)
-		log.Debugf("%+v", _stats)
-		stats.merge(_stats)
-		if err != nil {
-			err := errors.Wrapf(err, "Error running query on %s", dbname)
+	// the status of every database is reported, even if the query was
+	// canceled, since updates may have been committed to some of them
+	var updateErr error
+	for _, dbname := range names {
+		out := outcomes[dbname]
+		stats.merge(out.stats)
+		status := DatabaseStatus{Rows: out.solutions, Elapsed: out.elapsed, Updated: out.updated}
+		if out.err != nil {
+			err := errors.Wrapf(out.err, "Error running query on %s", dbname)
+			status.Error = err.Error()
 			result.Errors = append(result.Errors, err.Error())
+			result.Partial = true
+		} else if out.updateErr != nil {
+			err := errors.Wrapf(out.updateErr, "Could not update %s", dbname)
+			status.Error = err.Error()
+			if updateErr == nil {
+				updateErr = err
+			}
 		}
-		//rowlock.Lock()
-
-		for _, row := range singleresult {
-			unionedRows.ReplaceOrInsert(row)
+		result.Databases[dbname] = status
+		if q.IsUpdate() {
+			result.Count += len(out.rows)
 		}
-		if !q.Count {
-			i := unionedRows.DeleteMax()
-			for i != nil {
-				row := i.(*ResultRow)
-				if !q.IsInsert() {
-					m := make(ResultMap)
-					for idx, vname := range q.Select.Vars {
-						m[vname] = row.row[idx]
-					}
-					result.Rows = append(result.Rows, m)
-				}
-				result.Count += 1
+	}
+	err := canceled(goctx)
+	if err != nil || q.IsUpdate() {
+		for _, out := range outcomes {
+			for _, row := range out.rows {
 				finishResultRow(row)
-				i = unionedRows.DeleteMax()
 			}
-		} else {
-			result.Count = unionedRows.Len()
 		}
-		//}
+	}
+	if err != nil {
+		return result, err
+	} else if updateErr != nil {
+		return result, updateErr
+	}
+
+	// the rows are merged in the order of the database names, so the result
+	// does not depend on which database finished first
+	unionedRows := btree.New(4, "")
+	if !q.IsUpdate() {
+		for _, dbname := range names {
+			for _, row := range outcomes[dbname].rows {
+				if old := unionedRows.ReplaceOrInsert(row); old != nil {
+					finishResultRow(old.(*ResultRow))
+				}
+			}
+		}
+	}
+
+	if groups != nil {
+		for _, row := range groups.rows(runq, runq.Select.Vars) {
+			if old := unionedRows.ReplaceOrInsert(row); old != nil {
+				finishResultRow(old.(*ResultRow))
+			}
+		}
+	}
 
-		// handle INSERT query
-		if q.IsInsert() {
-			insertstats, err := db.handleInsert(q.Insert, result)
+	if !q.IsUpdate() {
+		rows := make([]*ResultRow, 0, unionedRows.Len())
+		unionedRows.Ascend(func(i btree.Item) bool {
+			rows = append(rows, i.(*ResultRow))
+			return true
+		})
+		page := rows
+		if !paged {
+			rows, page = pageRows(q, runq, orderBy, rows)
+		}
+		result.Count = len(page)
+		if !q.Count {
+			for _, row := range page {
+				result.Rows = append(result.Rows, row.toResultMap(q.Select.Vars))
+			}
+		}
+		if !q.Count && len(q.Select.Links) > 0 {
+			links, err := getLinkResults(databases, q.Select.Links, result.Rows)
 			if err != nil {
-				return result, err
+				return result, errors.Wrap(err, "Could not get links")
 			}
-			stats.merge(insertstats)
+			result.Links = links
+		}
+		for _, row := range rows {
+			finishResultRow(row)
 		}
-		//rowlock.Unlock()
-		wg.Done()
-		//}()
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -318,9 +450,178 @@ func (hod *HodDB) RunQuery(q *sparql.Query) (QueryResult, error) {
 		"Total":      time.Since(fullQueryStart),
 	}).Info("Query")
 
+	result.Elapsed = time.Since(fullQueryStart)
+	return result, nil
+}
+
+// what a query produced on one database
+type queryOutcome struct {
+	rows []*ResultRow
+	// the number of rows, or of solutions added to the groups of an
+	// aggregate query
+	solutions int
+	stats     queryStats
+	elapsed   time.Duration
+	// the error of the WHERE clause, and of the update that followed it
+	err, updateErr error
+	// true if the update was committed
+	updated bool
+}
+
+// calls fn with the name of each database, on at most QueryConcurrency of
+// them at a time, and returns once all of the calls have
+func (hod *HodDB) eachDatabase(names []string, fn func(dbname string)) {
+	workers := hod.cfg.QueryConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+	var queue = make(chan string)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for dbname := range queue {
+				fn(dbname)
+			}
+		}()
+	}
+	for _, dbname := range names {
+		queue <- dbname
+	}
+	close(queue)
 	wg.Wait()
+}
 
-	return result, nil
+// the names of the databases, sorted
+func sortedDatabaseNames(databases map[string]*DB) []string {
+	names := make([]string, 0, len(databases))
+	for dbname := range databases {
+		names = append(names, dbname)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// true if the query has no WHERE pattern, e.g. DELETE DATA
+func whereIsEmpty(q *sparql.Query) bool {
+	where := q.Where
+	return len(where.Terms) == 0 && len(where.Filters) == 0 && len(where.Optionals) == 0 &&
+		len(where.Minus) == 0 && len(where.Exists) == 0 && len(where.Binds) == 0 &&
+		len(where.Subqueries) == 0 && where.GraphGroup == nil && len(where.Values.Vars) == 0
+}
+
+// returns the query to run for q and the ORDER BY conditions to sort its rows
+// by. Variables that are only needed by ORDER BY are selected internally and
+// projected out before the rows are returned. Aggregate queries also get the
+// set of groups that their solutions are added to
+func prepareQuery(q *sparql.Query) (*sparql.Query, []sparql.OrderCondition, *groupSet) {
+	runq := q
+	orderBy := q.OrderBy
+	var groups *groupSet
+	if q.IsAggregate() {
+		runq, orderBy = aggregateOrderBy(q)
+		groups = newGroupSet(runq)
+	}
+	for _, varname := range (sparql.SolutionModifier{OrderBy: orderBy}).OrderVars() {
+		if !containsString(runq.Select.Vars, varname) && containsString(q.Variables, varname) {
+			if runq == q {
+				runq = q.Copy()
+				runq.Select.Vars = append([]string{}, q.Select.Vars...)
+			}
+			runq.Select.Vars = append(runq.Select.Vars, varname)
+		}
+	}
+	return runq, orderBy, groups
+}
+
+// sorts the distinct rows of runq, removes the rows that are duplicates once
+// the variables only selected for sorting are projected out, and returns the
+// remaining rows along with the page of them selected by OFFSET and LIMIT
+func pageRows(q, runq *sparql.Query, orderBy []sparql.OrderCondition, rows []*ResultRow) ([]*ResultRow, []*ResultRow) {
+	if len(orderBy) > 0 {
+		sortResultRows(rows, runq.Select.Vars, orderBy)
+	}
+	if len(runq.Select.Vars) > len(q.Select.Vars) {
+		rows = distinctResultRows(rows, len(q.Select.Vars))
+	}
+	page := rows
+	if q.Offset < len(page) {
+		page = page[q.Offset:]
+	} else {
+		page = nil
+	}
+	if q.HasLimit && q.Limit < len(page) {
+		page = page[:q.Limit]
+	}
+	return rows, page
+}
+
+// returns the solutions that the templates of an update are instantiated
+// with. An update without a WHERE pattern has a single, empty solution
+func updateRows(q *sparql.Query, rows []*ResultRow) []ResultMap {
+	if whereIsEmpty(q) {
+		return []ResultMap{{}}
+	}
+	maps := make([]ResultMap, len(rows))
+	for idx, row := range rows {
+		maps[idx] = row.toResultMap(q.Select.Vars)
+	}
+	return maps
+}
+
+// returns an error if the query uses a prefix that is neither declared by the
+// query nor defined by any of the databases it runs against. A prefix that is
+// only defined by some of the databases simply does not match in the others
+func checkPrefixes(q *sparql.Query, databases map[string]*DB) error {
+	if len(databases) == 0 {
+		return nil
+	}
+	var undefined []string
+	check := func(prefix string) {
+		if _, found := q.Prefixes[prefix]; found || prefix == "xsd" {
+			return
+		}
+		for _, db := range databases {
+			if _, found := db.namespaces[prefix]; found {
+				return
+			}
+		}
+		if !containsString(undefined, prefix) {
+			undefined = append(undefined, prefix)
+		}
+	}
+	q.MapURIs(func(uri turtle.URI) turtle.URI {
+		// full IRIs like <http://example.com/a> are split at the ':' when
+		// they have no '#', which must not be taken for a prefix
+		if !uri.IsVariable() && isPrefixName(uri.Namespace) && !strings.HasPrefix(uri.Value, "/") {
+			check(uri.Namespace)
+		}
+		if parts := strings.SplitN(uri.Datatype, "#", 2); len(parts) == 2 && isPrefixName(parts[0]) {
+			check(parts[0])
+		}
+		return uri
+	})
+	if len(undefined) > 0 {
+		return errors.Errorf("Undefined prefix %s", strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// true if the namespace of a URI is an unexpanded prefix rather than an IRI
+func isPrefixName(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+	for _, c := range namespace {
+		if !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
 }
 
 func (hod *HodDB) loadDataset(name, ttlfile string) error {
@@ -368,6 +669,39 @@ func (hod *HodDB) loadDataset(name, ttlfile string) error {
 	return nil
 }
 
+// loads the links file configured for the building, if there is one. Unless
+// force is true (the building was just loaded), the file is only loaded if
+// it has changed since it was last loaded
+func (hod *HodDB) loadLinks(name string, force bool) error {
+	linkfile, found := hod.cfg.Links[name]
+	if !found {
+		return nil
+	}
+	contents, err := ioutil.ReadFile(linkfile)
+	if err != nil {
+		return errors.Wrapf(err, "Could not read links file %s", linkfile)
+	}
+	filehash := sha256.Sum256(contents)
+	hod.Lock()
+	existinghash, found := hod.loadedfilehashes[linkfile]
+	hod.Unlock()
+	if !force && found && bytes.Equal(filehash[:], existinghash) {
+		log.Infof("Links file %s has not changed since we last loaded it! Skipping...", linkfile)
+		return nil
+	}
+	_db, ok := hod.dbs.Load(name)
+	if !ok {
+		return errors.Errorf("No database %s for links file %s", name, linkfile)
+	}
+	if err := _db.(*DB).loadLinks(linkfile); err != nil {
+		return errors.Wrapf(err, "Could not load links file %s", linkfile)
+	}
+	hod.Lock()
+	hod.loadedfilehashes[linkfile] = filehash[:]
+	hod.Unlock()
+	return nil
+}
+
 // Close HodDB
 func (hod *HodDB) Close() {
 	hod.dbs.Range(func(_dbname, _db interface{}) bool {
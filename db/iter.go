@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+
+	"github.com/pkg/errors"
+)
+
+// the number of rows a ResultIter decodes before they are read
+const resultIterBuffer = 64
+
+// ResultIter yields the rows of a SELECT query one at a time, as they are
+// decoded from each database, instead of holding all of them in memory:
+//
+//	iter, err := hod.QueryIter(ctx, q)
+//	if err != nil { ... }
+//	defer iter.Close()
+//	for iter.Next() {
+//		row := iter.Row()
+//	}
+//	if err := iter.Err(); err != nil { ... }
+//
+// Rows are distinct, and yielded database by database in the order of their
+// names. Queries with ORDER BY, aggregates, LIMIT or OFFSET are answered by
+// RunQueryContext before their first row is yielded, so that their rows and
+// pages are the same as those of RunQuery
+type ResultIter struct {
+	vars   []string
+	rows   chan ResultMap
+	row    ResultMap
+	cancel context.CancelFunc
+	// closed once the query has stopped
+	done chan struct{}
+	// set before rows is closed, and read once Next has seen it closed
+	err      error
+	errors   []string
+	finished bool
+	// digests of the rows that have been yielded, to remove the rows that
+	// more than one database or branch of a UNION finds; nil if there is
+	// only one of each, whose rows are already distinct
+	seen map[rowDigest]struct{}
+	key  []byte
+}
+
+// the first 128 bits of the SHA-256 hash of the values of a row, so that
+// memory grows by a fixed amount for each row, however long its values are
+type rowDigest [16]byte
+
+// Runs the query against HodDB and returns an iterator over its rows. The
+// iterator must be closed, which cancels the query if it is still running.
+// The query is canceled when the context is done or the QueryTimeout of the
+// config has passed; Err then returns a QueryCanceledError
+func (hod *HodDB) QueryIter(goctx context.Context, q *sparql.Query) (*ResultIter, error) {
+	switch {
+	case q.IsAsk() || q.IsConstruct() || q.IsDescribe():
+		return nil, errors.New("ASK, CONSTRUCT and DESCRIBE queries do not return rows")
+	case q.IsExplain():
+		return nil, errors.New("EXPLAIN queries do not return rows")
+	case q.IsUpdate():
+		return nil, errors.New("INSERT and DELETE queries do not return rows")
+	case q.Count:
+		return nil, errors.New("COUNT queries do not return rows")
+	case len(q.Select.Links) > 0:
+		return nil, errors.New("Links cannot be selected by a streamed query")
+	}
+
+	databases := hod.queryDatabases(q)
+	if err := checkPrefixes(q, databases); err != nil {
+		return nil, err
+	}
+
+	goctx, timeoutCancel := hod.withQueryTimeout(goctx)
+	goctx, cancel := context.WithCancel(goctx)
+	iter := &ResultIter{
+		vars: q.Select.Vars,
+		rows: make(chan ResultMap, resultIterBuffer),
+		cancel: func() {
+			cancel()
+			timeoutCancel()
+		},
+		done: make(chan struct{}),
+	}
+	if len(databases) > 1 || len(unionBranches(q)) > 1 {
+		iter.seen = make(map[rowDigest]struct{})
+	}
+
+	go func() {
+		defer close(iter.done)
+		// closed before done, so that Close can drain the rows
+		defer close(iter.rows)
+		// pages are taken in the order of ResultRow.Less over every database,
+		// which RunQueryContext does while keeping only the rows of the page
+		if len(q.OrderBy) > 0 || q.IsAggregate() || q.HasLimit || q.Offset > 0 {
+			iter.sendResult(hod.RunQueryContext(goctx, q))
+			return
+		}
+		for _, dbname := range sortedDatabaseNames(databases) {
+			db := databases[dbname]
+			// running the query expands its prefixes with those of the
+			// database, so each database gets its own copy
+			_, err := db.runQueryWith(goctx, q.DeepCopy(), func(ctx *queryContext) (int, error) {
+				return iter.sendRows(goctx, ctx), nil
+			})
+			if err := canceled(goctx); err != nil {
+				iter.err = err
+				return
+			}
+			if err != nil {
+				iter.errors = append(iter.errors, errors.Wrapf(err, "Error running query on %s", dbname).Error())
+			}
+		}
+	}()
+	return iter, nil
+}
+
+// sends the rows of a query that was answered in full
+func (iter *ResultIter) sendResult(result QueryResult, err error) {
+	if err != nil {
+		iter.err = err
+		return
+	}
+	iter.errors = result.Errors
+	for _, row := range result.Rows {
+		iter.rows <- row
+	}
+}
+
+// sends the rows of the selected variables that were not sent before, until
+// the query is canceled. Returns the number of rows that were sent
+func (iter *ResultIter) sendRows(goctx context.Context, ctx *queryContext) int {
+	var sent int
+	ctx.eachResult(0, func(row *ResultRow) bool {
+		defer finishResultRow(row)
+		if iter.seen != nil {
+			iter.key = appendResultRowKey(iter.key[:0], row)
+			var digest rowDigest
+			hash := sha256.Sum256(iter.key)
+			copy(digest[:], hash[:])
+			if _, found := iter.seen[digest]; found {
+				return true
+			}
+			iter.seen[digest] = struct{}{}
+		}
+		select {
+		case iter.rows <- row.toResultMap(iter.vars):
+			sent++
+			return true
+		case <-goctx.Done():
+			return false
+		}
+	})
+	return sent
+}
+
+// advances to the next row; false once there are no more rows, or the query
+// failed
+func (iter *ResultIter) Next() bool {
+	row, ok := <-iter.rows
+	if !ok {
+		iter.row = nil
+		iter.finished = true
+		return false
+	}
+	iter.row = row
+	return true
+}
+
+// the current row
+func (iter *ResultIter) Row() ResultMap {
+	return iter.row
+}
+
+// the selected variables, in the order they are selected
+func (iter *ResultIter) Variables() []string {
+	return iter.vars
+}
+
+// the error that stopped the query, if any; set once Next returns false
+func (iter *ResultIter) Err() error {
+	if !iter.finished {
+		return nil
+	}
+	return iter.err
+}
+
+// the errors of databases that could not be queried; the rows of the other
+// databases are still yielded. Set once Next returns false
+func (iter *ResultIter) Errors() []string {
+	if !iter.finished {
+		return nil
+	}
+	return iter.errors
+}
+
+// stops the query, if it is still running, and releases its resources
+func (iter *ResultIter) Close() error {
+	iter.cancel()
+	// the rows that were already decoded are dropped so the query can finish
+	for range iter.rows {
+	}
+	<-iter.done
+	return nil
+}
+
+// appends the values of the row to dest, so that they can be hashed
+func appendResultRowKey(dest []byte, row *ResultRow) []byte {
+	for _, uri := range row.row {
+		dest = append(dest, uri.Bytes()...)
+		dest = append(dest, 0)
+	}
+	return dest
+}
@@ -0,0 +1,382 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+
+	"github.com/pkg/errors"
+)
+
+// Terms whose variables form a cycle, such as
+//
+//	?sensor bf:isLocatedIn ?room . ?room bf:isPartOf ?zone .
+//	?vav bf:feeds ?zone . ?sensor bf:isPointOf ?vav
+//
+// are expensive to join one at a time: each term is joined with every row of
+// the terms before it, and the rows that the last term of the cycle removes
+// are built first. A leapfrog triejoin instead binds one variable at a time
+// to the values that every term on the variable allows, by intersecting the
+// sorted edges of the terms' predicates, so it only builds rows that satisfy
+// all of the terms.
+
+// true if the term can be resolved by a leapfrog triejoin: its subject and
+// object are different variables, joined by a single predicate
+func leapfrogEligible(term queryTerm, sameVars map[string]string) bool {
+	if !term.Subject.IsVariable() || !term.Object.IsVariable() || term.Route != "" || len(term.Predicates) != 1 {
+		return false
+	}
+	pattern := term.Predicates[0]
+	if pattern.IsGroup() || pattern.Pattern != sparql.PATTERN_SINGLE || pattern.Predicate.IsVariable() {
+		return false
+	}
+	// the copies of repeated variables are compared with restrictSameValue
+	_, renamedSubject := sameVars[term.Subject.String()]
+	_, renamedObject := sameVars[term.Object.String()]
+	return term.Subject != term.Object && !renamedSubject && !renamedObject
+}
+
+// returns the groups of terms of the plan, by their index, that are joined
+// with a leapfrog triejoin: the connected groups of eligible terms whose
+// variables form a cycle of at least three variables. Two terms between the
+// same pair of variables are not a cycle; they are joined on both variables
+func (dg *dependencyGraph) cyclicGroups() [][]int {
+	var (
+		parent   = make(map[string]string)
+		pairs    = make(map[[2]string]bool)
+		eligible []int
+		cycles   []string
+	)
+	var find func(varname string) string
+	find = func(varname string) string {
+		if p, found := parent[varname]; found && p != varname {
+			root := find(p)
+			parent[varname] = root
+			return root
+		}
+		return varname
+	}
+	for idx, term := range dg.plan {
+		if !leapfrogEligible(term, dg.sameVars) {
+			continue
+		}
+		eligible = append(eligible, idx)
+		pair := [2]string{term.Subject.String(), term.Object.String()}
+		if pair[1] < pair[0] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if pairs[pair] {
+			continue
+		}
+		pairs[pair] = true
+		if subjectRoot, objectRoot := find(pair[0]), find(pair[1]); subjectRoot == objectRoot {
+			cycles = append(cycles, pair[0])
+		} else {
+			parent[subjectRoot] = objectRoot
+		}
+	}
+
+	var (
+		cyclic = make(map[string]bool)
+		groups [][]int
+		group  = make(map[string]int)
+	)
+	for _, varname := range cycles {
+		cyclic[find(varname)] = true
+	}
+	for _, idx := range eligible {
+		root := find(dg.plan[idx].Subject.String())
+		if !cyclic[root] {
+			continue
+		}
+		if pos, found := group[root]; found {
+			groups[pos] = append(groups[pos], idx)
+		} else {
+			group[root] = len(groups)
+			groups = append(groups, []int{idx})
+		}
+	}
+	return groups
+}
+
+// resolves a group of terms whose variables form a cycle with a leapfrog
+// triejoin
+type leapfrogJoin struct {
+	// the variables of the terms
+	term  queryTerm
+	terms []queryTerm
+}
+
+func newLeapfrogJoin(terms []queryTerm) *leapfrogJoin {
+	op := &leapfrogJoin{terms: terms}
+	for _, term := range terms {
+		for _, varname := range term.variables {
+			if !containsString(op.term.variables, varname) {
+				op.term.variables = append(op.term.variables, varname)
+			}
+		}
+	}
+	return op
+}
+
+func (op *leapfrogJoin) String() string {
+	var terms []string
+	for _, term := range op.terms {
+		terms = append(terms, term.String())
+	}
+	return fmt.Sprintf("[leapfrogJoin %s]", terms)
+}
+
+func (op *leapfrogJoin) SortKey() string {
+	return op.term.variables[0]
+}
+
+func (op *leapfrogJoin) GetTerm() queryTerm {
+	return op.term
+}
+
+func (op *leapfrogJoin) run(ctx *queryContext) error {
+	var (
+		atoms    = make([]*trieAtom, len(op.terms))
+		restrict = make(map[string][]Key)
+	)
+	for idx, term := range op.terms {
+		pattern := term.Predicates[0]
+		edges, err := ctx.t.getPredicateEdges(pattern.Predicate)
+		if err != nil {
+			return errors.Wrapf(err, "Can't find predicate %v", pattern.Predicate)
+		}
+		atoms[idx] = &trieAtom{subjectVar: term.Subject.String(), objectVar: term.Object.String(), edges: edges, inverse: pattern.Inverse}
+	}
+	// variables that already have values only take those values
+	for _, varname := range op.term.variables {
+		if ctx.defined(varname) {
+			restrict[varname] = sortedKeymap(ctx.getValuesForVariable(varname))
+		}
+	}
+
+	var (
+		vars  = op.term.variables
+		bound = make(map[string]Key, len(vars))
+		rows  [][]Key
+		err   error
+	)
+	// the sorted lists that the values of the variable must be in, given the
+	// variables that are bound
+	candidates := func(varname string) [][]Key {
+		var lists [][]Key
+		if allowed, found := restrict[varname]; found {
+			lists = append(lists, allowed)
+		}
+		for _, atom := range atoms {
+			if other, found := atom.other(varname); !found {
+				continue
+			} else if value, isBound := bound[other]; isBound {
+				lists = append(lists, atom.neighbors(varname, value))
+			} else {
+				lists = append(lists, atom.values(varname))
+			}
+		}
+		return lists
+	}
+	var search func() bool
+	search = func() bool {
+		if len(bound) == len(vars) {
+			row := make([]Key, len(vars))
+			for idx, varname := range vars {
+				row[idx] = bound[varname]
+			}
+			rows = append(rows, row)
+			return true
+		}
+		// the variable with the fewest values to try is bound next, so that
+		// variables with many values are bound once the others restrict them
+		var (
+			next      string
+			nextLists [][]Key
+			fewest    = -1
+		)
+		for _, varname := range vars {
+			if _, isBound := bound[varname]; isBound {
+				continue
+			}
+			lists := candidates(varname)
+			for _, list := range lists {
+				if fewest < 0 || len(list) < fewest {
+					next, nextLists, fewest = varname, lists, len(list)
+				}
+			}
+		}
+		more := true
+		leapfrogIntersect(nextLists, func(value Key) bool {
+			if err = ctx.t.canceled(); err != nil {
+				more = false
+				return false
+			}
+			bound[next] = value
+			more = search()
+			return more
+		})
+		delete(bound, next)
+		return more
+	}
+	search()
+	if err != nil {
+		return err
+	}
+
+	rel := NewRelation(vars)
+	rel.addRows(vars, rows)
+	var joinOn []string
+	for _, varname := range vars {
+		if ctx.bound(varname) {
+			joinOn = append(joinOn, varname)
+		}
+	}
+	if len(joinOn) == 0 {
+		if err := ctx.extend(rel); err != nil {
+			return err
+		}
+	} else {
+		ctx.rel.join(rel, joinOn, ctx)
+	}
+	ctx.defineFromRelation(vars...)
+	return nil
+}
+
+// the edges of a predicate as sorted adjacency lists
+type predicateEdges struct {
+	// the distinct subjects and objects of the predicate
+	subjects, objects []Key
+	// the objects of each subject, and the subjects of each object
+	objectsOf, subjectsOf map[Key][]Key
+}
+
+func newPredicateEdges(pred *PredicateEntity) *predicateEdges {
+	pe := &predicateEdges{
+		objectsOf:  sortedAdjacency(pred.Subjects),
+		subjectsOf: sortedAdjacency(pred.Objects),
+	}
+	pe.subjects = sortedKeys(pe.objectsOf)
+	pe.objects = sortedKeys(pe.subjectsOf)
+	return pe
+}
+
+// a term of the triejoin, ?subject predicate ?object, or ?subject ^predicate
+// ?object if inverse is true
+type trieAtom struct {
+	subjectVar, objectVar string
+	edges                 *predicateEdges
+	inverse               bool
+}
+
+// returns the other variable of the term, if the variable is one of its own
+func (atom *trieAtom) other(varname string) (string, bool) {
+	switch varname {
+	case atom.subjectVar:
+		return atom.objectVar, true
+	case atom.objectVar:
+		return atom.subjectVar, true
+	}
+	return "", false
+}
+
+// the sorted values the variable can have in the term
+func (atom *trieAtom) values(varname string) []Key {
+	if (varname == atom.subjectVar) != atom.inverse {
+		return atom.edges.subjects
+	}
+	return atom.edges.objects
+}
+
+// the sorted values the variable can have in the term when its other
+// variable has the given value
+func (atom *trieAtom) neighbors(varname string, value Key) []Key {
+	if (varname == atom.subjectVar) != atom.inverse {
+		return atom.edges.subjectsOf[value]
+	}
+	return atom.edges.objectsOf[value]
+}
+
+// calls fn with each value that is in all of the sorted lists, in order,
+// until it returns false. Each list seeks forward to the largest value seen
+// so far, so lists skip the values that the others do not have
+func leapfrogIntersect(lists [][]Key, fn func(Key) bool) {
+	if len(lists) == 0 {
+		return
+	}
+	for _, list := range lists {
+		if len(list) == 0 {
+			return
+		}
+	}
+	// the lists are visited in the order of their first values
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i][0].LessThan(lists[j][0])
+	})
+	var (
+		positions = make([]int, len(lists))
+		max       = lists[len(lists)-1][0]
+	)
+	for current := 0; ; current = (current + 1) % len(lists) {
+		list, pos := lists[current], positions[current]
+		if list[pos] == max {
+			// every list is at the same value
+			if !fn(max) {
+				return
+			}
+			pos++
+		} else {
+			pos += sort.Search(len(list)-pos, func(i int) bool {
+				return !list[pos+i].LessThan(max)
+			})
+		}
+		if pos == len(list) {
+			return
+		}
+		positions[current] = pos
+		max = list[pos]
+	}
+}
+
+func sortedAdjacency(index map[string]map[string]uint32) map[Key][]Key {
+	adjacency := make(map[Key][]Key, len(index))
+	for from, tos := range index {
+		var fromKey Key
+		fromKey.FromSlice([]byte(from))
+		list := make([]Key, 0, len(tos))
+		for to := range tos {
+			var toKey Key
+			toKey.FromSlice([]byte(to))
+			list = append(list, toKey)
+		}
+		sortKeys(list)
+		adjacency[fromKey] = list
+	}
+	return adjacency
+}
+
+func sortedKeys(adjacency map[Key][]Key) []Key {
+	keys := make([]Key, 0, len(adjacency))
+	for key := range adjacency {
+		keys = append(keys, key)
+	}
+	sortKeys(keys)
+	return keys
+}
+
+func sortedKeymap(values *keymap) []Key {
+	keys := make([]Key, 0, values.Len())
+	values.Iter(func(key Key) {
+		keys = append(keys, key)
+	})
+	sortKeys(keys)
+	return keys
+}
+
+func sortKeys(keys []Key) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].LessThan(keys[j])
+	})
+}
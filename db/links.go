@@ -0,0 +1,152 @@
+package db
+
+import (
+	"encoding/json"
+	"os"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Links are key/value pairs attached to entities, e.g. the UUID of the
+// timeseries for a sensor. They are stored in the link database under the
+// primary key of the entity followed by the name of the key, so all links of
+// an entity can be found with a prefix scan. Links are not part of the graph:
+// they are retrieved for the results of a query with SELECT ?var[key]
+
+// reads a links file, which maps the full URI of each entity to its links:
+//
+//	{ "http://example.com/building#sensor_1": { "UUID": "..." } }
+func readLinksFile(filename string) (map[string]map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not open links file %s", filename)
+	}
+	defer f.Close()
+	var links map[string]map[string]string
+	if err := json.NewDecoder(f).Decode(&links); err != nil {
+		return nil, errors.Wrapf(err, "Could not decode links file %s", filename)
+	}
+	return links, nil
+}
+
+// loads the links file into the database
+func (db *DB) loadLinks(filename string) error {
+	links, err := readLinksFile(filename)
+	if err != nil {
+		return err
+	}
+	tx, err := db.openTransaction()
+	if err != nil {
+		return err
+	}
+	if err := tx.addLinks(links); err != nil {
+		tx.discard()
+		return err
+	}
+	if err := tx.done(); err != nil {
+		tx.discard()
+		return err
+	}
+	return nil
+}
+
+// replaces the links of each of the entities with the given ones. Links for
+// entities that are not in the database are skipped
+func (tx *transaction) addLinks(links map[string]map[string]string) error {
+	b := new(leveldb.Batch)
+	for entity, keyvalues := range links {
+		uri := turtle.ParseURI(entity)
+		hash, found, err := tx.existingHash(uri)
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Warningf("Skipping links for unknown entity %s", uri)
+			continue
+		}
+		iter := tx.link.NewIterator(util.BytesPrefix(hash[:]), nil)
+		for iter.Next() {
+			b.Delete(append([]byte{}, iter.Key()...))
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return errors.Wrapf(err, "Could not read links for %s", uri)
+		}
+		for key, value := range keyvalues {
+			b.Put(linkKey(hash, key), []byte(value))
+		}
+	}
+	if err := tx.link.Write(b, nil); err != nil {
+		return errors.Wrap(err, "Could not save links")
+	}
+	return nil
+}
+
+func linkKey(hash Key, key string) []byte {
+	return append(append([]byte{}, hash[:]...), key...)
+}
+
+// returns the links of the entity that are selected by sel
+func (snap *snapshot) getLinks(hash Key, sel sparql.LinkSelection) (map[string]string, error) {
+	links := make(map[string]string)
+	iter := snap.linkSnapshot.NewIterator(util.BytesPrefix(hash[:]), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := string(iter.Key()[len(hash):])
+		if sel.Matches(key) {
+			links[key] = string(iter.Value())
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrapf(err, "Could not read links for %v", hash)
+	}
+	return links, nil
+}
+
+// looks up the selected links of the entities in the result rows. Each entity
+// is looked up in all of the databases, since rows are merged across them
+func getLinkResults(databases map[string]*DB, selections []sparql.LinkSelection, rows []ResultMap) (LinkResultMap, error) {
+	result := make(LinkResultMap)
+	for _, db := range databases {
+		snap, err := db.snapshot()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not get snapshot of %s", db.name)
+		}
+		for _, sel := range selections {
+			for _, row := range rows {
+				uri, found := row[sel.Var]
+				if !found || uri.IsLiteral() {
+					continue
+				}
+				hash, err := snap.getHash(uri)
+				if errors.Cause(err) == leveldb.ErrNotFound {
+					continue
+				} else if err != nil {
+					snap.Close()
+					return nil, err
+				}
+				links, err := snap.getLinks(hash, sel)
+				if err != nil {
+					snap.Close()
+					return nil, err
+				}
+				if len(links) == 0 {
+					continue
+				}
+				if result[uri] == nil {
+					result[uri] = make(map[string]string)
+				}
+				for key, value := range links {
+					result[uri][key] = value
+				}
+			}
+		}
+		snap.Close()
+	}
+	return result, nil
+}
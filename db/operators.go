@@ -4,7 +4,9 @@ package db
 import (
 	"fmt"
 
+	"github.com/gtfierro/btree"
 	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 )
@@ -52,7 +54,11 @@ func (rs *resolveSubject) run(ctx *queryContext) error {
 	if !ctx.defined(subjectVar) {
 		// if not defined, then we put this into the relation
 		ctx.defineVariable(subjectVar, subjects)
-		ctx.rel.add1Value(subjectVar, subjects)
+		newrel := NewRelation([]string{subjectVar})
+		newrel.add1Value(subjectVar, subjects)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	} else {
 		// if it *is* already defined, then we intersect the values by joining
 		ctx.unionDefinitions(subjectVar, subjects)
@@ -100,7 +106,11 @@ func (ro *resolveObject) run(ctx *queryContext) error {
 
 	if !ctx.defined(objectVar) {
 		ctx.defineVariable(objectVar, objects)
-		ctx.rel.add1Value(objectVar, objects)
+		newrel := NewRelation([]string{objectVar})
+		newrel.add1Value(objectVar, objects)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	} else {
 		ctx.unionDefinitions(objectVar, objects)
 
@@ -155,7 +165,11 @@ func (op *resolvePredicate) run(ctx *queryContext) error {
 	// new stuff
 	if !ctx.defined(predicateVar) {
 		ctx.defineVariable(predicateVar, predicates)
-		ctx.rel.add1Value(predicateVar, predicates)
+		newrel := NewRelation([]string{predicateVar})
+		newrel.add1Value(predicateVar, predicates)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	} else {
 		ctx.unionDefinitions(predicateVar, predicates)
 
@@ -196,72 +210,47 @@ func (rso *restrictSubjectObjectByPredicate) run(ctx *queryContext) error {
 	// this operator takes existing values for subjects and objects and finds the pairs of them that
 	// are connected by the path defined by rso.term.Predicates.
 
-	var rsop_relation *Relation
+	var rsop_relation = NewRelation([]string{subjectVar, objectVar})
 	var relation_contents [][]Key
 	var joinOn []string
 	var itererr error
 
-	// use whichever variable has already been joined on, which means
-	// that there are values in the relation that we can join with
-	if ctx.hasJoined(subjectVar) {
+	// join on the variables that have already been joined, which means that
+	// there are values in the relation that we can join with. The path is
+	// followed from the end that has been joined; if both or neither have,
+	// it is followed from the end that is estimated to reach fewer entities
+	var fromSubject bool
+	switch subjectJoined, objectJoined := ctx.hasJoined(subjectVar), ctx.hasJoined(objectVar); {
+	case subjectJoined && objectJoined:
+		joinOn = []string{subjectVar, objectVar}
+		fromSubject = rso.subjectFirst(ctx)
+	case subjectJoined:
 		joinOn = []string{subjectVar}
-		subjects := ctx.getValuesForVariable(subjectVar)
-
-		rsop_relation = NewRelation([]string{subjectVar, objectVar})
-
-		subjects.Iter(func(subject Key) {
-			reachableObjects := ctx.t.getObjectFromSubjectPred(subject, rso.term.Predicates)
-			// we restrict the values in reachableObjects to those that we already have inside 'objectVar'
-			ctx.restrictToResolved(objectVar, reachableObjects)
-			reachableObjects.Iter(func(objectKey Key) {
-				relation_contents = append(relation_contents, []Key{subject, objectKey})
-			})
-		})
-		rsop_relation.add2Values(subjectVar, objectVar, relation_contents)
-
-	} else if ctx.hasJoined(objectVar) {
+		fromSubject = true
+	case objectJoined:
 		joinOn = []string{objectVar}
-		objects := ctx.getValuesForVariable(objectVar)
-
-		rsop_relation = NewRelation([]string{objectVar, subjectVar})
-
-		objects.Iter(func(object Key) {
-			reachableSubjects, err := ctx.t.getSubjectFromPredObject(object, rso.term.Predicates)
-			if err != nil {
-				itererr = err
-				return
-			}
-			ctx.restrictToResolved(subjectVar, reachableSubjects)
+		fromSubject = false
+	default:
+		fromSubject = rso.subjectFirst(ctx)
+		if fromSubject {
+			joinOn = []string{subjectVar}
+		} else {
+			joinOn = []string{objectVar}
+		}
+	}
 
-			reachableSubjects.Iter(func(subjectKey Key) {
-				relation_contents = append(relation_contents, []Key{object, subjectKey})
-			})
-		})
-		rsop_relation.add2Values(objectVar, subjectVar, relation_contents)
-	} else if ctx.cardinalityUnique(subjectVar) < ctx.cardinalityUnique(objectVar) {
-		// we start with whichever has fewer values (subject or object). For each of them, we search
-		// the graph for reachable endpoints (object or subject) on the provided path (rso.term.Predicates)
-		// neither is joined
-		joinOn = []string{subjectVar}
+	if fromSubject {
 		subjects := ctx.getValuesForVariable(subjectVar)
-
-		rsop_relation = NewRelation([]string{subjectVar, objectVar})
-
 		subjects.Iter(func(subject Key) {
 			reachableObjects := ctx.t.getObjectFromSubjectPred(subject, rso.term.Predicates)
+			// we restrict the values in reachableObjects to those that we already have inside 'objectVar'
 			ctx.restrictToResolved(objectVar, reachableObjects)
-
 			reachableObjects.Iter(func(objectKey Key) {
 				relation_contents = append(relation_contents, []Key{subject, objectKey})
 			})
 		})
-		rsop_relation.add2Values(subjectVar, objectVar, relation_contents)
 	} else {
-		joinOn = []string{objectVar}
 		objects := ctx.getValuesForVariable(objectVar)
-
-		rsop_relation = NewRelation([]string{objectVar, subjectVar})
-
 		objects.Iter(func(object Key) {
 			reachableSubjects, err := ctx.t.getSubjectFromPredObject(object, rso.term.Predicates)
 			if err != nil {
@@ -269,13 +258,12 @@ func (rso *restrictSubjectObjectByPredicate) run(ctx *queryContext) error {
 				return
 			}
 			ctx.restrictToResolved(subjectVar, reachableSubjects)
-
 			reachableSubjects.Iter(func(subjectKey Key) {
-				relation_contents = append(relation_contents, []Key{object, subjectKey})
+				relation_contents = append(relation_contents, []Key{subjectKey, object})
 			})
 		})
-		rsop_relation.add2Values(objectVar, subjectVar, relation_contents)
 	}
+	rsop_relation.add2Values(subjectVar, objectVar, relation_contents)
 
 	if itererr != nil {
 		return itererr
@@ -288,6 +276,23 @@ func (rso *restrictSubjectObjectByPredicate) run(ctx *queryContext) error {
 	return nil
 }
 
+// returns true if following the path from the values of the subject is
+// estimated to reach fewer entities than following it back from the values of
+// the object
+func (rso *restrictSubjectObjectByPredicate) subjectFirst(ctx *queryContext) bool {
+	var (
+		est          = ctx.db.costModel().estimatePath(rso.term.Predicates)
+		subjects     = ctx.cardinalityUnique(rso.term.Subject.String())
+		objects      = ctx.cardinalityUnique(rso.term.Object.String())
+		fromSubjects = float64(subjects) * est.fanOut
+		fromObjects  = float64(objects) * est.fanIn
+	)
+	if fromSubjects == fromObjects {
+		return subjects < objects
+	}
+	return fromSubjects < fromObjects
+}
+
 // ?sub pred ?obj, but we have already resolved the object
 // For each of the current
 type resolveSubjectFromVarObject struct {
@@ -447,10 +452,14 @@ func (rso *resolveSubjectObjectFromPred) run(ctx *queryContext) error {
 		rsop_relation.add2Values(subjectVar, objectVar, subsobjs)
 		ctx.rel.join(rsop_relation, []string{objectVar}, ctx)
 	} else {
-		ctx.rel.add2Values(subjectVar, objectVar, subsobjs)
+		newrel := NewRelation([]string{subjectVar, objectVar})
+		newrel.add2Values(subjectVar, objectVar, subsobjs)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	}
-	ctx.markJoined(subjectVar)
-	ctx.markJoined(objectVar)
+	// later terms on the variables start from the pairs that were found
+	ctx.defineFromRelation(subjectVar, objectVar)
 
 	return nil
 }
@@ -530,11 +539,13 @@ func (op *resolveSubjectPredFromObject) run(ctx *queryContext) error {
 		rsop_relation.add2Values(subjectVar, predicateVar, sub_pred_pairs)
 		ctx.rel.join(rsop_relation, []string{predicateVar}, ctx)
 	} else {
-		ctx.rel.add2Values(subjectVar, predicateVar, sub_pred_pairs)
+		newrel := NewRelation([]string{subjectVar, predicateVar})
+		newrel.add2Values(subjectVar, predicateVar, sub_pred_pairs)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	}
-
-	ctx.defineVariable(predicateVar, newKeymap())
-	ctx.defineVariable(subjectVar, newKeymap())
+	ctx.defineFromRelation(subjectVar, predicateVar)
 
 	return nil
 }
@@ -610,14 +621,14 @@ func (op *resolvePredObjectFromSubject) run(ctx *queryContext) error {
 		rsop_relation.add2Values(predicateVar, objectVar, pred_obj_pairs)
 		ctx.rel.join(rsop_relation, joinOn, ctx)
 	} else {
-		// if nothing has been joined yet, then we are populating this relation for the first time.
-		// from that predicate
-		ctx.rel.add2Values(predicateVar, objectVar, pred_obj_pairs)
-		ctx.defineVariable(predicateVar, newKeymap())
-		ctx.defineVariable(objectVar, newKeymap())
+		// neither variable has been joined yet
+		newrel := NewRelation([]string{predicateVar, objectVar})
+		newrel.add2Values(predicateVar, objectVar, pred_obj_pairs)
+		if err := ctx.extend(newrel); err != nil {
+			return err
+		}
 	}
-	ctx.markJoined(predicateVar)
-	ctx.markJoined(objectVar)
+	ctx.defineFromRelation(predicateVar, objectVar)
 
 	return nil
 }
@@ -669,9 +680,7 @@ func (op *resolveVarTripleFromSubject) run(ctx *queryContext) error {
 
 	rsop_relation.add3Values(subjectVar, predicateVar, objectVar, relation_contents)
 	ctx.rel.join(rsop_relation, []string{subjectVar}, ctx)
-	ctx.markJoined(subjectVar)
-	ctx.markJoined(predicateVar)
-	ctx.markJoined(objectVar)
+	ctx.defineFromRelation(subjectVar, predicateVar, objectVar)
 	return nil
 }
 
@@ -720,9 +729,7 @@ func (op *resolveVarTripleFromObject) run(ctx *queryContext) error {
 
 	rsop_relation.add3Values(objectVar, predicateVar, subjectVar, relation_contents)
 	ctx.rel.join(rsop_relation, []string{objectVar}, ctx)
-	ctx.markJoined(subjectVar)
-	ctx.markJoined(predicateVar)
-	ctx.markJoined(objectVar)
+	ctx.defineFromRelation(subjectVar, predicateVar, objectVar)
 	return nil
 }
 
@@ -777,12 +784,9 @@ func (op *resolveVarTripleFromPredicate) run(ctx *queryContext) error {
 		return itererr
 	}
 
-	ctx.markJoined(predicateVar)
 	rsop_relation.add3Values(predicateVar, subjectVar, objectVar, relation_contents)
 	ctx.rel.join(rsop_relation, []string{predicateVar}, ctx)
-	ctx.markJoined(subjectVar)
-	ctx.markJoined(predicateVar)
-	ctx.markJoined(objectVar)
+	ctx.defineFromRelation(subjectVar, predicateVar, objectVar)
 	return nil
 
 }
@@ -809,7 +813,6 @@ func (op *resolveVarTripleAll) run(ctx *queryContext) error {
 		objectVar    = op.term.Object.String()
 		predicateVar = op.term.Predicates[0].Predicate.String()
 	)
-	var relation = NewRelation([]string{subjectVar, predicateVar, objectVar})
 	var content [][]Key
 
 	iter := func(subjectHash Key, entity *Entity) bool {
@@ -826,14 +829,667 @@ func (op *resolveVarTripleAll) run(ctx *queryContext) error {
 		return err
 	}
 
-	relation.add3Values(subjectVar, predicateVar, objectVar, content)
-	if len(ctx.rel.rows) > 0 {
-		panic("This should not happen! Tell Gabe")
+	newrel := NewRelation([]string{subjectVar, predicateVar, objectVar})
+	newrel.add3Values(subjectVar, predicateVar, objectVar, content)
+	if err := ctx.extend(newrel); err != nil {
+		return err
 	}
-	// in this case, we just replace the relation
-	ctx.rel = relation
-	ctx.markJoined(subjectVar)
-	ctx.markJoined(predicateVar)
-	ctx.markJoined(objectVar)
+	ctx.defineFromRelation(subjectVar, predicateVar, objectVar)
+	return nil
+}
+
+// subject predicate object
+// Keeps the rows of the relation only if the triple is in the graph
+type checkTriple struct {
+	term queryTerm
+}
+
+func (op *checkTriple) String() string {
+	return fmt.Sprintf("[checkTriple %s]", op.term)
+}
+
+func (op *checkTriple) SortKey() string {
+	return op.term.Subject.String()
+}
+
+func (op *checkTriple) GetTerm() queryTerm {
+	return op.term
+}
+
+func (op *checkTriple) run(ctx *queryContext) error {
+	var holds bool
+	subject, err := ctx.t.getHash(op.term.Subject)
+	if err != nil && errors.Cause(err) != leveldb.ErrNotFound {
+		return errors.Wrap(err, fmt.Sprintf("%+v", op.term))
+	}
+	object, err2 := ctx.t.getHash(op.term.Object)
+	if err2 != nil && errors.Cause(err2) != leveldb.ErrNotFound {
+		return errors.Wrap(err2, fmt.Sprintf("%+v", op.term))
+	}
+	if err == nil && err2 == nil {
+		holds = ctx.t.getObjectFromSubjectPred(subject, op.term.Predicates).Has(object)
+	}
+
+	if !holds {
+		// no solutions, whatever the other terms match
+		ctx.rel.filter(func(row *Row) bool { return false })
+		ctx.rel.populated = true
+	} else if !ctx.rel.populated {
+		// a single solution that binds no variables
+		ctx.rel.addRows(nil, [][]Key{{}})
+	}
+	return nil
+}
+
+// ?x predicate ?x
+// Keeps the rows of the relation in which the variable that stands in for a
+// repeated variable has the same value as it
+type restrictSameValue struct {
+	varname string
+	alias   string
+}
+
+func (op *restrictSameValue) String() string {
+	return fmt.Sprintf("[restrictSameValue %s %s]", op.varname, op.alias)
+}
+
+func (op *restrictSameValue) SortKey() string {
+	return op.varname
+}
+
+func (op *restrictSameValue) GetTerm() queryTerm {
+	return queryTerm{variables: []string{op.varname, op.alias}}
+}
+
+func (op *restrictSameValue) run(ctx *queryContext) error {
+	pos := ctx.variablePosition[op.varname]
+	aliasPos := ctx.variablePosition[op.alias]
+	ctx.rel.filter(func(row *Row) bool {
+		return row.valueAt(pos) == row.valueAt(aliasPos)
+	})
+	ctx.restrictToRelation()
+	return nil
+}
+
+// FILTER(expr)
+// Removes the rows of the relation for which the expression does not evaluate to true
+type filterRows struct {
+	filter sparql.Filter
+}
+
+func (op *filterRows) String() string {
+	return fmt.Sprintf("[filterRows %s]", op.filter)
+}
+
+func (op *filterRows) SortKey() string {
+	return op.filter.String()
+}
+
+func (op *filterRows) GetTerm() queryTerm {
+	return queryTerm{}
+}
+
+func (op *filterRows) run(ctx *queryContext) error {
+	ev := newEvaluator(ctx)
+	ctx.rel.filter(func(row *Row) bool {
+		return ev.filter(op.filter, row)
+	})
+	ctx.restrictToRelation()
+	return nil
+}
+
+// OPTIONAL { ... }
+// Evaluates the optional group using the current bindings of its variables,
+// and left-outer-joins the results into the relation
+type leftJoinOptional struct {
+	group sparql.GraphGroup
+	// holds the variables of the group
+	term queryTerm
+}
+
+func (op *leftJoinOptional) String() string {
+	return fmt.Sprintf("[leftJoinOptional %v]", op.group.Terms)
+}
+
+func (op *leftJoinOptional) SortKey() string {
+	return fmt.Sprintf("%v", op.term.variables)
+}
+
+func (op *leftJoinOptional) GetTerm() queryTerm {
+	return op.term
+}
+
+func (op *leftJoinOptional) run(ctx *queryContext) error {
+	// the group is evaluated for the values of its variables that are
+	// already bound, and joined back into the relation on them
+	bound := ctx.boundGroupVars(op.group, true)
+	result, err := ctx.evaluateGroup(op.group, bound)
+	if err != nil {
+		return err
+	}
+	ctx.rel.leftJoin(result, bound, nil)
+
+	// the variables of the group are now defined by whatever values were
+	// found for them, including the values that filled variables an earlier
+	// OPTIONAL left unbound
+	for _, varname := range op.term.variables {
+		if _, found := ctx.rel.vars[varname]; !found {
+			continue
+		}
+		values := newKeymap()
+		for value := range ctx.rel.multiindex[varname] {
+			values.Add(value)
+		}
+		ctx.definitions[varname] = values
+		ctx.markJoined(varname)
+	}
+	return nil
+}
+
+// MINUS { ... }
+// Evaluates the group for the current values of the variables it shares with
+// the relation, and removes the rows that have a compatible solution
+type antiJoinMinus struct {
+	group sparql.GraphGroup
+}
+
+func (op *antiJoinMinus) String() string {
+	return fmt.Sprintf("[antiJoinMinus %v]", op.group.Terms)
+}
+
+func (op *antiJoinMinus) SortKey() string {
+	return fmt.Sprintf("%v", op.group.Vars())
+}
+
+// the variables of the group are not bound by this operation
+func (op *antiJoinMinus) GetTerm() queryTerm {
+	return queryTerm{}
+}
+
+func (op *antiJoinMinus) run(ctx *queryContext) error {
+	// filters in a MINUS group cannot see the variables of the relation
+	bound := ctx.boundGroupVars(op.group, false)
+	result, err := ctx.evaluateGroup(op.group, bound)
+	if err != nil {
+		return err
+	}
+	ctx.rel.minus(result, bound)
+	ctx.restrictToRelation()
+	return nil
+}
+
+// FILTER EXISTS { ... } / FILTER NOT EXISTS { ... }
+// Evaluates the group for the current values of its bound variables, and
+// keeps the rows that have (or do not have) a solution
+type semiJoinExists struct {
+	group sparql.ExistsGroup
+}
+
+func (op *semiJoinExists) String() string {
+	return fmt.Sprintf("[semiJoinExists %v]", op.group)
+}
+
+func (op *semiJoinExists) SortKey() string {
+	return fmt.Sprintf("%v", op.group.Vars())
+}
+
+// the variables of the group are not bound by this operation
+func (op *semiJoinExists) GetTerm() queryTerm {
+	return queryTerm{}
+}
+
+func (op *semiJoinExists) run(ctx *queryContext) error {
+	bound := ctx.boundGroupVars(op.group.GraphGroup, true)
+	result, err := ctx.evaluateGroup(op.group.GraphGroup, bound)
+	if err != nil {
+		return err
+	}
+	ctx.rel.semiJoin(result, bound, op.group.Negated)
+	ctx.restrictToRelation()
+	return nil
+}
+
+// BIND(expr AS ?var)
+// Sets the variable in each row of the relation to the value of the
+// expression. Values that are not in the graph get keys that are only valid
+// for the query
+type bindExpression struct {
+	bind sparql.Bind
+}
+
+func (op *bindExpression) String() string {
+	return fmt.Sprintf("[bindExpression %s]", op.bind)
+}
+
+func (op *bindExpression) SortKey() string {
+	return op.bind.Var
+}
+
+func (op *bindExpression) GetTerm() queryTerm {
+	return queryTerm{variables: []string{op.bind.Var}}
+}
+
+func (op *bindExpression) run(ctx *queryContext) error {
+	pos, found := ctx.variablePosition[op.bind.Var]
+	if !found {
+		return errors.Errorf("Unknown variable %s in %s", op.bind.Var, op.bind)
+	}
+	ev := newEvaluator(ctx)
+	for _, row := range ctx.rel.rows {
+		// an error leaves the variable unbound in the row
+		val, err := ev.eval(op.bind.Expression, relationRow{ctx, row})
+		if err != nil {
+			continue
+		}
+		key, err := ctx.t.getValueKey(termFromValue(val))
+		if err != nil {
+			return err
+		}
+		row.addValue(pos, key)
+	}
+	ctx.rel.reindex()
 	return nil
 }
+
+// s path o ROUTE ?route
+// Binds the route of each solution of the term: the entities and predicates
+// of the shortest path from its subject to its object
+type bindRoute struct {
+	term queryTerm
+}
+
+func (op *bindRoute) String() string {
+	return fmt.Sprintf("[bindRoute %s]", op.term)
+}
+
+func (op *bindRoute) SortKey() string {
+	return op.term.Route
+}
+
+func (op *bindRoute) GetTerm() queryTerm {
+	return queryTerm{variables: []string{op.term.Route}}
+}
+
+func (op *bindRoute) run(ctx *queryContext) error {
+	pos, found := ctx.variablePosition[op.term.Route]
+	if !found {
+		return errors.Errorf("Unknown variable %s in %s", op.term.Route, op.term)
+	}
+	// returns the key of the subject or object of the term in the row
+	endpoint := func(uri turtle.URI, row *Row) (Key, error) {
+		if uri.IsVariable() {
+			return row.valueAt(ctx.variablePosition[uri.String()]), nil
+		}
+		return ctx.t.getHash(uri)
+	}
+	var (
+		path = ctx.t.normalizePath(op.term.Predicates)
+		// the routes from each subject, by the entity they end at
+		routes = make(map[Key]map[Key]*routeStep)
+	)
+	for _, row := range ctx.rel.rows {
+		subject, err := endpoint(op.term.Subject, row)
+		if err != nil {
+			return err
+		}
+		object, err := endpoint(op.term.Object, row)
+		if err != nil {
+			return err
+		}
+		if _, searched := routes[subject]; !searched {
+			reached, err := ctx.t.routesBySequence([]*routeStep{{entity: subject}}, path, true)
+			if err != nil {
+				return err
+			}
+			routes[subject] = make(map[Key]*routeStep, len(reached))
+			for _, step := range reached {
+				routes[subject][step.entity] = step
+			}
+		}
+		step, found := routes[subject][object]
+		if !found {
+			continue
+		}
+		route, err := ctx.t.routeURIs(step)
+		if err != nil {
+			return err
+		}
+		value, err := routeLiteral(route)
+		if err != nil {
+			return err
+		}
+		key, err := ctx.t.getValueKey(value)
+		if err != nil {
+			return err
+		}
+		row.addValue(pos, key)
+	}
+	ctx.rel.reindex()
+	return nil
+}
+
+// { SELECT ... }
+// Evaluates the subquery on its own and joins its solutions with the relation
+// on the variables they share
+type joinSubquery struct {
+	query sparql.Query
+	// holds the selected variables of the subquery
+	term queryTerm
+}
+
+func (op *joinSubquery) String() string {
+	return fmt.Sprintf("[joinSubquery %v %v]", op.query.Select.Vars, op.query.Where.Terms)
+}
+
+func (op *joinSubquery) SortKey() string {
+	return fmt.Sprintf("%v", op.term.variables)
+}
+
+func (op *joinSubquery) GetTerm() queryTerm {
+	return op.term
+}
+
+func (op *joinSubquery) run(ctx *queryContext) error {
+	result, err := ctx.evaluateSubquery(&op.query)
+	if err != nil {
+		return err
+	}
+	// values computed by the subquery are not in the graph, so they cannot
+	// match the triples of the query
+	result.filter(func(row *Row) bool {
+		for _, varname := range op.term.variables {
+			if _, used := ctx.dg.variables[varname]; used && isComputedKey(row.valueAt(result.vars[varname])) {
+				return false
+			}
+		}
+		return true
+	})
+
+	var shared []string
+	for _, varname := range op.term.variables {
+		if ctx.bound(varname) {
+			shared = append(shared, varname)
+		}
+	}
+	switch {
+	case len(shared) > 0:
+		ctx.rel.join(result, shared, ctx)
+		ctx.rel.reindex()
+	case len(ctx.joined) == 0 && len(ctx.definitions) == 0:
+		// nothing has been evaluated yet
+		ctx.rel.union(result)
+	case len(result.rows) == 0:
+		ctx.rel.filter(func(row *Row) bool { return false })
+	default:
+		// every row is paired with every solution, up to MaxCrossProduct
+		if err := ctx.extend(result); err != nil {
+			return err
+		}
+	}
+	ctx.restrictToRelation()
+
+	// the other selected variables are defined by the values the subquery
+	// found for them
+	for _, varname := range op.term.variables {
+		if ctx.bound(varname) {
+			continue
+		}
+		values := newKeymap()
+		for value := range ctx.rel.multiindex[varname] {
+			values.Add(value)
+		}
+		ctx.definitions[varname] = values
+		ctx.markJoined(varname)
+	}
+	return nil
+}
+
+// returns the variables of the group that already have values, optionally
+// including those only used in the group's filters
+func (ctx *queryContext) boundGroupVars(group sparql.GraphGroup, withFilters bool) []string {
+	var bound []string
+	vars := group.Vars()
+	if withFilters {
+		for _, filter := range group.Filters {
+			vars = append(vars, filter.Vars()...)
+		}
+	}
+	for _, varname := range vars {
+		if ctx.bound(varname) && !containsString(bound, varname) {
+			bound = append(bound, varname)
+		}
+	}
+	return bound
+}
+
+// evaluates each branch of the group in a sub-context seeded with the
+// current values of the [bound] variables, and returns the union of the
+// resulting relations. Rows that leave some of the variables unbound (from an
+// earlier OPTIONAL) are evaluated separately, seeded only with the variables
+// they bind
+func (ctx *queryContext) evaluateGroup(group sparql.GraphGroup, bound []string) (*Relation, error) {
+	projections := []projection{{}}
+	if len(bound) > 0 {
+		projections = ctx.rel.projectByBound(bound)
+	}
+
+	var results []*Relation
+	for _, seeded := range projections {
+		for _, branch := range group.Expand() {
+			result, err := ctx.evaluateBranch(branch, bound, seeded)
+			if err != nil {
+				return nil, err
+			}
+			if result != nil {
+				results = append(results, result)
+			}
+		}
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	vars := append([]string{}, bound...)
+	for _, varname := range group.Vars() {
+		if !containsString(vars, varname) {
+			vars = append(vars, varname)
+		}
+	}
+	result := NewRelation(vars)
+	for _, rel := range results {
+		result.union(rel)
+	}
+	return result, nil
+}
+
+// evaluates one branch of a group for the seeded values of some of the
+// [bound] variables. A VALUES block of the branch is joined with the seeded
+// rows; a branch without triples, subqueries or OPTIONAL groups extends the
+// seeded rows, or the single empty solution, with its BINDs
+func (ctx *queryContext) evaluateBranch(branch sparql.GraphGroup, bound []string, seeded projection) (*Relation, error) {
+	subq := &sparql.Query{
+		Where: sparql.WhereClause{
+			Terms:      branch.Terms,
+			Filters:    branch.Filters,
+			Optionals:  branch.Optionals,
+			Minus:      branch.Minus,
+			Exists:     branch.Exists,
+			Binds:      branch.Binds,
+			Subqueries: branch.Subqueries,
+		},
+	}
+	subq.PopulateVars()
+	for _, varname := range append(bound, branch.Vars()...) {
+		if !containsString(subq.Variables, varname) {
+			subq.Variables = append(subq.Variables, varname)
+		}
+	}
+	subq.Select.Vars = subq.Variables
+
+	partitions := []sparql.ValuesClause{{}}
+	if len(branch.Values) > 0 {
+		partitions = branch.Values[0].Partition()
+	}
+	onlyBinds := len(branch.Terms) == 0 && len(branch.Subqueries) == 0 && len(branch.Optionals) == 0
+
+	var results []*Relation
+	for _, values := range partitions {
+		vars, rows, err := ctx.joinValues(seeded, values)
+		if err != nil {
+			return nil, err
+		}
+		if len(vars) == 0 {
+			rows = nil
+			if onlyBinds {
+				rows = [][]Key{{}}
+			}
+		}
+
+		dg := makeDependencyGraph(subq, vars)
+		qp, err := ctx.db.formQueryPlan(dg, subq)
+		if err != nil {
+			return nil, err
+		}
+		sub := ctx.newSubContext(qp, vars, rows)
+		for _, subop := range qp.operations {
+			if err := sub.t.canceled(); err != nil {
+				return nil, err
+			}
+			if err := subop.run(sub); err != nil {
+				return nil, err
+			}
+		}
+		sub.rel.reindex()
+		results = append(results, sub.rel)
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	result := NewRelation(subq.Variables)
+	for _, rel := range results {
+		result.union(rel)
+	}
+	return result, nil
+}
+
+// pairs each seeded row with each row of the VALUES block that has the same
+// values for the variables they share, and returns the variables and rows to
+// seed a branch with
+func (ctx *queryContext) joinValues(seeded projection, values sparql.ValuesClause) ([]string, [][]Key, error) {
+	if len(values.Vars) == 0 {
+		return seeded.vars, seeded.rows, nil
+	}
+	vars := append([]string{}, seeded.vars...)
+	positions := make([]int, len(values.Vars))
+	for idx, varname := range values.Vars {
+		positions[idx] = len(vars)
+		for pos, seededVar := range seeded.vars {
+			if seededVar == varname {
+				positions[idx] = pos
+			}
+		}
+		if positions[idx] == len(vars) {
+			vars = append(vars, varname)
+		}
+	}
+
+	valueRows, err := ctx.valuesKeys(values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seededRows := seeded.rows
+	if len(seeded.vars) == 0 {
+		seededRows = [][]Key{{}}
+	}
+	var rows [][]Key
+	for _, seededRow := range seededRows {
+	valueLoop:
+		for _, valueRow := range valueRows {
+			row := make([]Key, len(vars))
+			copy(row, seededRow)
+			for idx, pos := range positions {
+				if pos < len(seededRow) && row[pos] != valueRow[idx] {
+					continue valueLoop
+				}
+				row[pos] = valueRow[idx]
+			}
+			rows = append(rows, row)
+		}
+	}
+	return vars, rows, nil
+}
+
+// evaluates a subquery on the snapshot of this context, independently of the
+// values in its relation, and returns its solutions as a relation of the
+// selected variables
+func (ctx *queryContext) evaluateSubquery(q *sparql.Query) (*Relation, error) {
+	runq, orderBy, groups := prepareQuery(q)
+	limit := rowLimit(q)
+
+	unionedRows := btree.New(4, "")
+	for _, branch := range unionBranches(runq) {
+		dg := makeDependencyGraph(branch, branch.Where.Values.Vars)
+		qp, err := ctx.db.formQueryPlan(dg, branch)
+		if err != nil {
+			cleanResultRows(unionedRows)
+			return nil, err
+		}
+		sub := ctx.newSubContext(qp, nil, nil)
+		if err := sub.execute(); err != nil {
+			cleanResultRows(unionedRows)
+			return nil, err
+		}
+		if groups != nil {
+			if _, err := groups.addSolutions(sub); err != nil {
+				cleanResultRows(unionedRows)
+				return nil, err
+			}
+			continue
+		}
+		for _, row := range sub.getResults(limit) {
+			if old := unionedRows.ReplaceOrInsert(row); old != nil {
+				finishResultRow(old.(*ResultRow))
+			}
+		}
+	}
+	if groups != nil {
+		for _, row := range groups.rows(runq, runq.Select.Vars) {
+			if old := unionedRows.ReplaceOrInsert(row); old != nil {
+				finishResultRow(old.(*ResultRow))
+			}
+		}
+	}
+
+	rows := make([]*ResultRow, 0, unionedRows.Len())
+	unionedRows.Ascend(func(i btree.Item) bool {
+		rows = append(rows, i.(*ResultRow))
+		return true
+	})
+	rows, page := pageRows(q, runq, orderBy, rows)
+	defer func() {
+		for _, row := range rows {
+			finishResultRow(row)
+		}
+	}()
+
+	values := make([][]Key, len(page))
+	for idx, row := range page {
+		values[idx] = make([]Key, len(q.Select.Vars))
+		for pos := range q.Select.Vars {
+			if row.row[pos] == (turtle.URI{}) {
+				continue
+			}
+			key, err := ctx.t.getValueKey(row.row[pos])
+			if err != nil {
+				return nil, err
+			}
+			values[idx][pos] = key
+		}
+	}
+	result := NewRelation(q.Select.Vars)
+	result.addRows(q.Select.Vars, values)
+	result.reindex()
+	return result, nil
+}
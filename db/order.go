@@ -0,0 +1,130 @@
+package db
+
+import (
+	"sort"
+	"strings"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+)
+
+// returns the number of distinct rows that a single database needs to
+// produce for the query, or -1 if all rows are needed. Without ORDER BY the
+// rows of several databases or UNION branches are paged in the order of
+// ResultRow.Less, so each of them keeps its first offset+limit rows in that
+// order; all of its rows are still decoded to find them. Aggregate queries
+// always need all solutions
+func rowLimit(q *sparql.Query) int {
+	if !q.HasLimit || len(q.OrderBy) > 0 || q.IsAggregate() {
+		return -1
+	}
+	return q.Offset + q.Limit
+}
+
+// the bindings of a result row; vars are the names of the row's columns
+type resultRowBindings struct {
+	vars []string
+	row  *ResultRow
+}
+
+func (b resultRowBindings) lookup(varname string) (turtle.URI, error) {
+	for idx, name := range b.vars {
+		if name == varname && b.row.row[idx] != (turtle.URI{}) {
+			return b.row.row[idx], nil
+		}
+	}
+	return turtle.URI{}, errUnbound
+}
+
+// sorts rows (whose columns are vars) by the ORDER BY conditions. Rows with
+// equal order keys are ordered by ResultRow.Less, so the order is
+// deterministic
+func sortResultRows(rows []*ResultRow, vars []string, conditions []sparql.OrderCondition) {
+	ev := newEvaluator(nil)
+	keys := make(map[*ResultRow][]exprValue, len(rows))
+	for _, row := range rows {
+		rowkeys := make([]exprValue, len(conditions))
+		for idx, cond := range conditions {
+			// an expression that cannot be evaluated sorts like an
+			// unbound value
+			if val, err := ev.eval(cond.Expression, resultRowBindings{vars, row}); err == nil {
+				rowkeys[idx] = val
+			}
+		}
+		keys[row] = rowkeys
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		ki, kj := keys[rows[i]], keys[rows[j]]
+		for idx, cond := range conditions {
+			cmp := orderCompare(ki[idx], kj[idx])
+			if cond.Descending {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return rows[i].Less(rows[j], nil)
+	})
+}
+
+// compares two values for ORDER BY. As in SPARQL, unbound values come first,
+// then IRIs, then literals
+func orderCompare(left, right exprValue) int {
+	lrank, rrank := orderRank(left), orderRank(right)
+	switch {
+	case lrank != rrank:
+		return lrank - rrank
+	case left.kind == valueUnbound:
+		return 0
+	case left.kind == valueURI:
+		return strings.Compare(left.uri.String(), right.uri.String())
+	}
+	if cmp, err := compareValues(left, right); err == nil {
+		return cmp
+	}
+	return strings.Compare(left.String(), right.String())
+}
+
+func orderRank(v exprValue) int {
+	switch v.kind {
+	case valueUnbound:
+		return 0
+	case valueURI:
+		return 1
+	}
+	return 2
+}
+
+// removes the rows that are equal to an earlier row in their first n
+// columns
+func distinctResultRows(rows []*ResultRow, n int) []*ResultRow {
+	seen := make(map[string]struct{}, len(rows))
+	distinct := rows[:0]
+	for _, row := range rows {
+		key := termsKey(row.row[:n])
+		if _, found := seen[key]; found {
+			finishResultRow(row)
+			continue
+		}
+		seen[key] = struct{}{}
+		distinct = append(distinct, row)
+	}
+	return distinct
+}
+
+// returns a string that is equal for equal lists of terms
+func termsKey(terms []turtle.URI) string {
+	var key strings.Builder
+	for _, uri := range terms {
+		key.WriteString(uri.Namespace)
+		key.WriteByte(0)
+		key.WriteString(uri.Value)
+		key.WriteByte(0)
+		key.WriteString(uri.Datatype)
+		key.WriteByte(0)
+		key.WriteString(uri.Lang)
+		key.WriteByte(0)
+	}
+	return key.String()
+}
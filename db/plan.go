@@ -22,13 +22,75 @@ const (
 // it into a query plan
 
 func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, error) {
+	dg.order(db.costModel())
 	qp := newQueryPlan(dg, q)
+	for _, varname := range dg.bound {
+		qp.addTopLevel(varname)
+	}
 
-	for _, term := range dg.plan {
+	// subqueries are evaluated independently of the rest of the group. Those
+	// that share variables with the terms run first, so that their variables
+	// are bound for the terms; the others are joined with the solutions of
+	// the terms afterwards
+	var crossJoined []operation
+	for _, sub := range q.Where.Subqueries {
+		op := &joinSubquery{query: sub, term: queryTerm{variables: sub.Select.Vars}}
+		shared := false
+		for _, varname := range sub.Select.Vars {
+			if _, required := dg.variables[varname]; !required {
+				qp.optionalVars[varname] = true
+			} else {
+				shared = true
+			}
+			qp.addTopLevel(varname)
+		}
+		if shared || len(dg.plan) == 0 {
+			qp.operations = append(qp.operations, op)
+		} else {
+			crossJoined = append(crossJoined, op)
+		}
+	}
+
+	// cyclic groups of terms are resolved at once, where the first of their
+	// terms would have been
+	var (
+		leapfrogs  = make(map[int]*leapfrogJoin)
+		inLeapfrog = make(map[int]bool)
+	)
+	if db.leapfrogJoin {
+		for _, group := range dg.cyclicGroups() {
+			var terms []queryTerm
+			for _, idx := range group {
+				terms = append(terms, dg.plan[idx])
+				inLeapfrog[idx] = true
+			}
+			op := newLeapfrogJoin(terms)
+			leapfrogs[group[0]] = op
+			qp.estimates[op] = dg.estimates[group[len(group)-1]]
+		}
+	}
+
+	for planIdx, term := range dg.plan {
+		if op, found := leapfrogs[planIdx]; found {
+			qp.operations = append(qp.operations, op)
+			first := op.term.variables[0]
+			if !qp.hasVar(first) {
+				qp.addTopLevel(first)
+			}
+			for _, varname := range op.term.variables[1:] {
+				if !qp.hasVar(varname) {
+					qp.addLink(first, varname)
+				}
+			}
+			continue
+		} else if inLeapfrog[planIdx] {
+			continue
+		}
 		var (
 			subjectIsVariable = term.Subject.IsVariable()
 			objectIsVariable  = term.Object.IsVariable()
-			// for now just look at first item in path
+			// paths are split at their variable predicates, so a variable
+			// predicate is always the only item in the path
 			predicateIsVariable  = term.Predicates[0].Predicate.IsVariable()
 			subjectVar           = term.Subject.String()
 			objectVar            = term.Object.String()
@@ -44,6 +106,9 @@ func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, e
 		hasResolvedPredicate = qp.hasVar(predicateVar)
 
 		switch {
+		case numvars == 0:
+			// s p o
+			newop = &checkTriple{term: term}
 		// definitions: do these first
 		case numvars == 1 && subjectIsVariable:
 			newop = &resolveSubject{term: term}
@@ -64,15 +129,28 @@ func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, e
 			}
 		// terms with 3 variables
 		case subjectIsVariable && objectIsVariable && predicateIsVariable:
+			var from string
 			switch {
 			case hasResolvedSubject:
 				newop = &resolveVarTripleFromSubject{term: term}
+				from = subjectVar
 			case hasResolvedObject:
 				newop = &resolveVarTripleFromObject{term: term}
+				from = objectVar
 			case hasResolvedPredicate:
 				newop = &resolveVarTripleFromPredicate{term: term}
+				from = predicateVar
 			default: // all are vars
 				newop = &resolveVarTripleAll{term: term}
+				from = subjectVar
+				qp.addTopLevel(subjectVar)
+			}
+			// the other variables of the triple are resolved along with it,
+			// e.g. at the next step of a path
+			for _, varname := range []string{subjectVar, predicateVar, objectVar} {
+				if !qp.hasVar(varname) {
+					qp.addLink(from, varname)
+				}
 			}
 		// subject/object variable terms
 		case subjectIsVariable && objectIsVariable && !predicateIsVariable:
@@ -106,6 +184,7 @@ func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, e
 				qp.addLink(subjectVar, objectVar)
 			default:
 				newop = &resolveSubjectObjectFromPred{term: term}
+				qp.addTopLevel(subjectVar)
 				qp.addLink(subjectVar, objectVar)
 			}
 		case !subjectIsVariable && !objectIsVariable && predicateIsVariable:
@@ -116,10 +195,16 @@ func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, e
 		case subjectIsVariable && !objectIsVariable && predicateIsVariable:
 			// ?s ?p o
 			newop = &resolveSubjectPredFromObject{term: term}
+			if !hasResolvedSubject {
+				qp.addTopLevel(subjectVar)
+			}
 			qp.addLink(subjectVar, predicateVar)
 		case !subjectIsVariable && objectIsVariable && predicateIsVariable:
 			// s ?p ?o
 			newop = &resolvePredObjectFromSubject{term: term}
+			if !hasResolvedObject {
+				qp.addTopLevel(objectVar)
+			}
 			qp.addLink(objectVar, predicateVar)
 		case subjectIsVariable:
 			// ?s p o
@@ -137,10 +222,86 @@ func (db *DB) formQueryPlan(dg *dependencyGraph, q *sparql.Query) (*queryPlan, e
 			return qp, errors.New(fmt.Sprintf("Nothing chosen for %s. This shouldn't happen", term))
 		}
 		qp.operations = append(qp.operations, newop)
+		qp.estimates[newop] = dg.estimates[planIdx]
+		for _, varname := range term.variables {
+			if same, found := dg.sameVars[varname]; found {
+				qp.operations = append(qp.operations, &restrictSameValue{varname: same, alias: varname})
+			}
+		}
+	}
+	qp.operations = append(qp.operations, crossJoined...)
+
+	// routes are found once the endpoints of their terms are resolved
+	for _, term := range dg.plan {
+		if term.Route != "" {
+			qp.operations = append(qp.operations, &bindRoute{term: term})
+		}
+	}
+
+	// OPTIONAL groups are left-joined after all required terms are resolved.
+	// Their variables may be unbound in the results
+	for _, group := range q.Where.Optionals {
+		op := &leftJoinOptional{group: group, term: queryTerm{variables: group.Vars()}}
+		for _, varname := range op.term.variables {
+			if _, required := dg.variables[varname]; !required && !containsString(dg.bound, varname) && !containsString(dg.subqueryVars, varname) {
+				qp.optionalVars[varname] = true
+			}
+		}
+		qp.operations = append(qp.operations, op)
 	}
+
+	// MINUS and FILTER [NOT] EXISTS only remove rows, so they run once the
+	// rest of the group has been evaluated
+	for _, group := range q.Where.Minus {
+		qp.operations = append(qp.operations, &antiJoinMinus{group: group})
+	}
+	for _, group := range q.Where.Exists {
+		qp.operations = append(qp.operations, &semiJoinExists{group: group})
+	}
+
+	// BINDs extend the complete solutions of the group, and may leave their
+	// variable unbound
+	for _, bind := range q.Where.Binds {
+		qp.operations = append(qp.operations, &bindExpression{bind: bind})
+		qp.optionalVars[bind.Var] = true
+	}
+
+	qp.addFilters(q.Where.Filters)
 	return qp, nil
 }
 
+// pushes each filter down to directly after the operation that binds the last
+// of its variables, so that rows are removed as early as possible. Filters
+// on variables that are never bound are evaluated at the end
+func (qp *queryPlan) addFilters(filters []sparql.Filter) {
+	for _, filter := range filters {
+		var (
+			vars     = filter.Vars()
+			bound    = make(map[string]bool)
+			position = len(qp.operations)
+		)
+		for _, varname := range qp.dg.bound {
+			bound[varname] = true
+		}
+	opLoop:
+		for idx, op := range qp.operations {
+			for _, varname := range op.GetTerm().variables {
+				bound[varname] = true
+			}
+			for _, varname := range vars {
+				if !bound[varname] {
+					continue opLoop
+				}
+			}
+			position = idx + 1
+			break
+		}
+		qp.operations = append(qp.operations, nil)
+		copy(qp.operations[position+1:], qp.operations[position:])
+		qp.operations[position] = &filterRows{filter: filter}
+	}
+}
+
 // contains all useful state information for executing a query
 type queryPlan struct {
 	operations []operation
@@ -148,18 +309,35 @@ type queryPlan struct {
 	dg         *dependencyGraph
 	query      *sparql.Query
 	vars       map[string]string
+	// variables that may be unbound in the results: those that only appear
+	// in OPTIONAL groups or subqueries, and those of BINDs
+	optionalVars map[string]bool
+	// the estimated number of rows after each operation that resolves a
+	// term of the dependency graph
+	estimates map[operation]float64
 }
 
 func newQueryPlan(dg *dependencyGraph, q *sparql.Query) *queryPlan {
 	plan := &queryPlan{
-		selectVars: dg.selectVars,
-		dg:         dg,
-		query:      q,
-		vars:       make(map[string]string),
+		selectVars:   dg.selectVars,
+		dg:           dg,
+		query:        q,
+		vars:         make(map[string]string),
+		optionalVars: make(map[string]bool),
+		estimates:    make(map[operation]float64),
 	}
 	return plan
 }
 
+// returns the variables of the query, followed by those that the plan
+// introduces to join the steps of paths
+func (qp *queryPlan) variables() []string {
+	if len(qp.dg.pathVars) == 0 {
+		return qp.query.Variables
+	}
+	return append(append([]string{}, qp.query.Variables...), qp.dg.pathVars...)
+}
+
 func (qp *queryPlan) dumpVarchain() {
 	for k, v := range qp.vars {
 		fmt.Println(k, "=>", v)
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+
+	"github.com/coocood/freecache"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Results of queries are cached by the SHA-256 hash of the query and the
+// generation of the database they were found in. Every committed transaction
+// starts a new generation, so results found before the graph changed are
+// never read again; they are evicted as the cache fills up.
+
+// QueryCacheStats describes how the query cache of a database is used
+type QueryCacheStats struct {
+	// lookups that found cached results, and lookups that did not
+	Hits   int64
+	Misses int64
+	// the number of cached results
+	Entries int64
+	// the number of transactions committed to the database since it was opened
+	Generation uint64
+}
+
+// returns the statistics of the query cache of each database. Databases
+// without a query cache are left out
+func (hod *HodDB) QueryCacheStats() map[string]QueryCacheStats {
+	var stats = make(map[string]QueryCacheStats)
+	hod.dbs.Range(func(name, _db interface{}) bool {
+		db := _db.(*DB)
+		if db.queryCacheEnabled {
+			stats[name.(string)] = db.queryCacheStats()
+		}
+		return true
+	})
+	return stats
+}
+
+func (db *DB) queryCacheStats() QueryCacheStats {
+	hits := db.queryCache.HitCount()
+	return QueryCacheStats{
+		Hits:       hits,
+		Misses:     db.queryCache.LookupCount() - hits,
+		Entries:    db.queryCache.EntryCount(),
+		Generation: db.currentGeneration(),
+	}
+}
+
+func (db *DB) currentGeneration() uint64 {
+	return atomic.LoadUint64(&db.generation)
+}
+
+// starts a new generation of the database; called once a transaction is
+// committed
+func (db *DB) nextGeneration() {
+	atomic.AddUint64(&db.generation, 1)
+}
+
+// the key of the results of the query with the given hash in a generation
+func queryCacheKey(hash []byte, generation uint64) []byte {
+	key := make([]byte, len(hash)+8)
+	copy(key, hash)
+	binary.LittleEndian.PutUint64(key[len(hash):], generation)
+	return key
+}
+
+// runs the query on the database, unless its results for the current
+// generation are in the query cache. A nil hash does not use the cache. If
+// paged is true, only the rows of the page of the query are returned
+func (db *DB) runCachedQuery(goctx context.Context, q *sparql.Query, hash []byte, paged bool) ([]*ResultRow, queryStats, error) {
+	run := db.runQuery
+	if paged {
+		run = db.runQueryPage
+	}
+	if !db.queryCacheEnabled || hash == nil {
+		return run(goctx, q)
+	}
+	// read before the query takes its snapshots, which therefore hold every
+	// transaction of this generation. They may also hold one that is being
+	// committed, whose results are then saved in this generation until it
+	// starts the next one; after that they are never read again
+	key := queryCacheKey(hash, db.currentGeneration())
+	if cached, err := db.queryCache.Get(key); err == nil {
+		rows, err := decodeResultRows(cached)
+		if err == nil {
+			return rows, queryStats{NumResults: len(rows)}, nil
+		}
+		log.Warning(errors.Wrap(err, "Could not decode cached query results"))
+	}
+
+	rows, stats, err := run(goctx, q)
+	if err != nil || canceled(goctx) != nil {
+		return rows, stats, err
+	}
+	if encoded, err := encodeResultRows(rows); err != nil {
+		log.Warning(errors.Wrap(err, "Could not encode query results for the cache"))
+	} else if err := db.queryCache.Set(key, encoded, 0); err != nil && err != freecache.ErrLargeEntry {
+		log.Warning(errors.Wrap(err, "Could not cache query results"))
+	}
+	return rows, stats, nil
+}
+
+// encodes the rows as an array of arrays of URIs
+func encodeResultRows(rows []*ResultRow) ([]byte, error) {
+	var (
+		b   = msgp.AppendArrayHeader(nil, uint32(len(rows)))
+		err error
+	)
+	for _, row := range rows {
+		b = msgp.AppendArrayHeader(b, uint32(len(row.row)))
+		for _, uri := range row.row {
+			if b, err = uri.MarshalMsg(b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+func decodeResultRows(b []byte) ([]*ResultRow, error) {
+	numRows, b, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*ResultRow, 0, numRows)
+	for i := uint32(0); i < numRows; i++ {
+		var count uint32
+		if count, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+			break
+		}
+		row := getResultRow(int(count))
+		rows = append(rows, row)
+		for idx := range row.row {
+			var uri turtle.URI
+			if b, err = uri.UnmarshalMsg(b); err != nil {
+				break
+			}
+			row.row[idx] = uri
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		for _, row := range rows {
+			finishResultRow(row)
+		}
+		return nil, err
+	}
+	return rows, nil
+}
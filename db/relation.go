@@ -8,10 +8,15 @@ type Relation struct {
 	rows []*Row
 
 	multiindex map[string]map[Key]*roaring.Bitmap
+	// the rows in which each variable is unbound, as of the last reindex
+	unbound map[string]*roaring.Bitmap
 
 	// map variable name to position in row
 	vars map[string]int
 	keys []string
+	// set once rows have been added, so that a relation whose rows have all
+	// been removed is not mistaken for one that has not been filled yet
+	populated bool
 }
 
 func NewRelation(vars []string) *Relation {
@@ -19,6 +24,7 @@ func NewRelation(vars []string) *Relation {
 		keys:       vars,
 		vars:       make(map[string]int),
 		multiindex: make(map[string]map[Key]*roaring.Bitmap),
+		unbound:    make(map[string]*roaring.Bitmap),
 	}
 	for idx, varname := range vars {
 		rel.vars[varname] = idx
@@ -28,6 +34,7 @@ func NewRelation(vars []string) *Relation {
 }
 
 func (rel *Relation) add1Value(key1 string, values *keymap) {
+	rel.populated = true
 	key1pos, found := rel.vars[key1]
 	if !found {
 		rel.vars[key1] = len(rel.vars) + 1
@@ -59,6 +66,7 @@ func (rel *Relation) add1Value(key1 string, values *keymap) {
 }
 
 func (rel *Relation) add2Values(key1, key2 string, values [][]Key) {
+	rel.populated = true
 	key1pos, found := rel.vars[key1]
 	if !found {
 		rel.vars[key1] = len(rel.vars) + 1
@@ -103,6 +111,7 @@ func (rel *Relation) add2Values(key1, key2 string, values [][]Key) {
 }
 
 func (rel *Relation) add3Values(key1, key2, key3 string, values [][]Key) {
+	rel.populated = true
 	key1pos, found := rel.vars[key1]
 	if !found {
 		rel.vars[key1] = len(rel.vars) + 1
@@ -170,10 +179,18 @@ func (rel *Relation) join(other *Relation, on []string, ctx *queryContext) {
 	}
 
 	var joinedRows = make([]*Row, 0, len(rel.rows))
+	// joining on no variables pairs every row with every row of other
+	var all = roaring.New()
+	if len(on) == 0 {
+		all.AddRange(0, uint64(len(other.rows)))
+	}
 innerRows:
 	for _, innerRow := range rel.rows {
 		// find all the rows in [other] that share the values
 		var otherBitmaps []*roaring.Bitmap
+		if len(on) == 0 {
+			otherBitmaps = append(otherBitmaps, all)
+		}
 		for _, joinVarName := range on {
 			myVarPos := rel.vars[joinVarName]
 			innerRowValue := innerRow.valueAt(myVarPos)
@@ -204,6 +221,252 @@ innerRows:
 	rel.rows = joinedRows
 }
 
+// adds rows containing values for the given variables, skipping rows that
+// already exist in the relation. Generalizes add2Values and add3Values
+func (rel *Relation) addRows(vars []string, values [][]Key) {
+	rel.populated = true
+	var positions = make([]int, len(vars))
+	for idx, varname := range vars {
+		pos, found := rel.vars[varname]
+		if !found {
+			pos = len(rel.vars) + 1
+			rel.vars[varname] = pos
+			rel.multiindex[varname] = make(map[Key]*roaring.Bitmap)
+		}
+		positions[idx] = pos
+	}
+
+	var bitmaps = make([]*roaring.Bitmap, len(vars))
+rowLoop:
+	for _, valuerow := range values {
+		exists := true
+		for idx, varname := range vars {
+			bitmaps[idx] = rel.multiindex[varname][valuerow[idx]]
+			exists = exists && bitmaps[idx] != nil
+		}
+		if exists && len(vars) > 0 && !roaring.FastAnd(bitmaps...).IsEmpty() {
+			continue rowLoop
+		}
+
+		row := NewRow()
+		for idx, value := range valuerow {
+			row.addValue(positions[idx], value)
+		}
+		rel.rows = append(rel.rows, row)
+		for idx, varname := range vars {
+			if bitmaps[idx] == nil {
+				rel.multiindex[varname][valuerow[idx]] = roaring.New()
+			}
+			rel.multiindex[varname][valuerow[idx]].AddInt(len(rel.rows) - 1)
+		}
+	}
+}
+
+// the distinct combinations of values of some variables
+type projection struct {
+	vars []string
+	rows [][]Key
+}
+
+// splits the rows by which of the given variables they bind, and returns the
+// distinct combinations of values of the bound variables of each set
+func (rel *Relation) projectByBound(vars []string) []projection {
+	var (
+		projections []projection
+		byBound     = make(map[string]int)
+		seen        = make(map[string]struct{})
+		buf         = make([]byte, 0, 9*len(vars))
+	)
+	for _, row := range rel.rows {
+		buf = buf[:0]
+		for _, varname := range vars {
+			if row.valueAt(rel.vars[varname]) == emptyKey {
+				buf = append(buf, 0)
+			} else {
+				buf = append(buf, 1)
+			}
+		}
+		pos, found := byBound[string(buf)]
+		if !found {
+			pos = len(projections)
+			byBound[string(buf)] = pos
+			var bound []string
+			for idx, varname := range vars {
+				if buf[idx] == 1 {
+					bound = append(bound, varname)
+				}
+			}
+			projections = append(projections, projection{vars: bound})
+		}
+
+		values := make([]Key, 0, len(projections[pos].vars))
+		for _, varname := range projections[pos].vars {
+			value := row.valueAt(rel.vars[varname])
+			values = append(values, value)
+			buf = append(buf, value[:]...)
+		}
+		if _, found := seen[string(buf)]; found {
+			continue
+		}
+		seen[string(buf)] = struct{}{}
+		projections[pos].rows = append(projections[pos].rows, values)
+	}
+	return projections
+}
+
+// adds all rows of the other relation to this one, mapping values by variable
+// name. Variables of the other relation that this relation does not have are
+// dropped
+func (rel *Relation) union(other *Relation) {
+	rel.populated = true
+	for _, otherRow := range other.rows {
+		row := NewRow()
+		for otherVarname, otherIdx := range other.vars {
+			if pos, found := rel.vars[otherVarname]; found {
+				row.addValue(pos, otherRow.valueAt(otherIdx))
+			}
+		}
+		rel.rows = append(rel.rows, row)
+	}
+	rel.reindex()
+}
+
+// left outer join: like join, but the rows of this relation that have no
+// compatible row in the other relation are kept, with the other relation's
+// variables left unbound. A variable that is unbound in either row is
+// compatible with any value, and is filled from the other row. If accept is
+// non-nil, joined rows it rejects do not count as matches. Joining on no
+// variables pairs every row with every row of the other relation
+func (rel *Relation) leftJoin(other *Relation, on []string, accept func(row *Row) bool) {
+	var joinedRows = make([]*Row, 0, len(rel.rows))
+	for _, innerRow := range rel.rows {
+		matched := false
+		iter := rel.compatibleRows(innerRow, other, on).Iterator()
+		for iter.HasNext() {
+			row := other.rows[iter.Next()]
+			newRow := innerRow.copy()
+			for otherVarname, otherIdx := range other.vars {
+				if pos, found := rel.vars[otherVarname]; found {
+					if value := row.valueAt(otherIdx); value != emptyKey {
+						newRow.addValue(pos, value)
+					}
+				}
+			}
+			if accept != nil && !accept(newRow) {
+				newRow.release()
+				continue
+			}
+			joinedRows = append(joinedRows, newRow)
+			matched = true
+		}
+		if matched {
+			innerRow.release()
+		} else {
+			joinedRows = append(joinedRows, innerRow)
+		}
+	}
+	rel.rows = joinedRows
+	rel.reindex()
+}
+
+// keeps the rows of the relation that are compatible with at least one row of
+// other (a semi-join), or, if anti is true, the rows that are compatible with
+// none of them (an anti-join). This is used for FILTER EXISTS and FILTER NOT
+// EXISTS, where other has been evaluated for the values of the [on] variables
+func (rel *Relation) semiJoin(other *Relation, on []string, anti bool) {
+	rel.filter(func(row *Row) bool {
+		matched := !rel.compatibleRows(row, other, on).IsEmpty()
+		return matched != anti
+	})
+}
+
+// removes the rows of the relation that are compatible with a row of other
+// and share at least one bound variable with it (MINUS)
+func (rel *Relation) minus(other *Relation, on []string) {
+	rel.filter(func(row *Row) bool {
+		iter := rel.compatibleRows(row, other, on).Iterator()
+		for iter.HasNext() {
+			otherRow := other.rows[iter.Next()]
+			for _, varname := range on {
+				if row.valueAt(rel.vars[varname]) != emptyKey && otherRow.valueAt(other.vars[varname]) != emptyKey {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// returns the rows of other that are compatible with row: for each of the
+// [on] variables, either one of the rows leaves it unbound or both have the
+// same value. Unbound (optional) variables are compatible with any value
+func (rel *Relation) compatibleRows(row *Row, other *Relation, on []string) *roaring.Bitmap {
+	var otherBitmaps []*roaring.Bitmap
+	for _, joinVarName := range on {
+		value := row.valueAt(rel.vars[joinVarName])
+		if value == emptyKey {
+			continue
+		}
+		matching, unbound := other.multiindex[joinVarName][value], other.unbound[joinVarName]
+		switch {
+		case matching == nil && unbound == nil:
+			return roaring.New()
+		case unbound == nil:
+			otherBitmaps = append(otherBitmaps, matching)
+		case matching == nil:
+			otherBitmaps = append(otherBitmaps, unbound)
+		default:
+			otherBitmaps = append(otherBitmaps, roaring.Or(matching, unbound))
+		}
+	}
+	if len(otherBitmaps) == 0 {
+		all := roaring.New()
+		all.AddRange(0, uint64(len(other.rows)))
+		return all
+	}
+	return roaring.FastAnd(otherBitmaps...)
+}
+
+// keeps only the rows for which keep returns true, and rebuilds the index
+func (rel *Relation) filter(keep func(row *Row) bool) {
+	var keptRows = make([]*Row, 0, len(rel.rows))
+	for _, row := range rel.rows {
+		if keep(row) {
+			keptRows = append(keptRows, row)
+		} else {
+			row.release()
+		}
+	}
+	rel.rows = keptRows
+	rel.reindex()
+}
+
+// rebuilds the multiindex from the current set of rows
+func (rel *Relation) reindex() {
+	for varname := range rel.vars {
+		rel.multiindex[varname] = make(map[Key]*roaring.Bitmap)
+	}
+	rel.unbound = make(map[string]*roaring.Bitmap)
+	for idx, row := range rel.rows {
+		for varname, pos := range rel.vars {
+			value := row.valueAt(pos)
+			if value == emptyKey {
+				if rel.unbound[varname] == nil {
+					rel.unbound[varname] = roaring.New()
+				}
+				rel.unbound[varname].AddInt(idx)
+				continue
+			}
+			bitmap := rel.multiindex[varname][value]
+			if bitmap == nil {
+				bitmap = roaring.New()
+				rel.multiindex[varname][value] = bitmap
+			}
+			bitmap.AddInt(idx)
+		}
+	}
+}
+
 func (rel *Relation) dumpRows(prefix string, ctx *queryContext) {
 	for _, row := range rel.rows {
 		//rel.dumpRow(row, ctx)
@@ -1,5 +1,6 @@
 //go:generate msgp
 //msgp:ignore LinkResultMap
+//msgp:shim time.Duration as:int64 using:int64/time.Duration
 package db
 
 import (
@@ -14,6 +15,7 @@ import (
 	"github.com/gtfierro/hod/turtle"
 
 	"github.com/gtfierro/btree"
+	"github.com/tinylib/msgp/msgp"
 )
 
 var emptyResultMapList = []ResultMap{}
@@ -22,7 +24,57 @@ var emptyLinkResultmapList = []LinkResultMap{}
 type QueryResult struct {
 	selectVars []string
 	Rows       []ResultMap
-	Count      int
+	// the selected links of the entities in Rows
+	Links   LinkResultMap
+	Count   int
+	Elapsed time.Duration
+	Errors  []string
+	// how the query went on each database it ran on, by name
+	Databases map[string]DatabaseStatus
+	// true if the query failed on some of the databases; the rows are those
+	// of the others
+	Partial bool
+}
+
+// DatabaseStatus is the outcome of a query on one of the databases it ran on
+type DatabaseStatus struct {
+	// the rows, or solutions of an aggregate query, that the database
+	// produced, before duplicates from other databases are removed
+	Rows    int
+	Elapsed time.Duration
+	// true if an INSERT or DELETE changed the database
+	Updated bool
+	// why the query failed on the database, if it did
+	Error string
+}
+
+// AskResult is the answer to an ASK query: whether the WHERE clause has a
+// solution
+type AskResult struct {
+	Result  bool
+	Elapsed time.Duration
+	Errors  []string
+}
+
+// ConstructResult holds the triples built by a CONSTRUCT query from the
+// solutions of its WHERE clause
+type ConstructResult struct {
+	Triples []turtle.Triple
+	// the namespace prefixes of the queried databases, used when the
+	// triples are serialized
+	Namespaces map[string]string
+	Elapsed    time.Duration
+	Errors     []string
+}
+
+// DescribeResult holds the incoming and outgoing edges of the entities
+// described by a DESCRIBE query
+type DescribeResult struct {
+	Entities []turtle.URI
+	Triples  []turtle.Triple
+	// the namespace prefixes of the queried databases, used when the
+	// triples are serialized
+	Namespaces map[string]string
 	Elapsed    time.Duration
 	Errors     []string
 }
@@ -37,11 +89,7 @@ func (qr *QueryResult) fromRows(rows []*ResultRow, vars []string, toMap bool) {
 	qr.Count = len(rows)
 	if toMap {
 		for _, row := range rows {
-			m := make(ResultMap)
-			for idx, vname := range vars {
-				m[vname] = row.row[idx]
-			}
-			qr.Rows = append(qr.Rows, m)
+			qr.Rows = append(qr.Rows, row.toResultMap(vars))
 			finishResultRow(row)
 		}
 	}
@@ -102,11 +150,20 @@ func (qr QueryResult) DumpToCSV(usePrefixes bool, db *HodDB, w io.Writer) error
 		for _, row := range qr.Rows {
 			var line = make([]string, len(qr.selectVars))
 			for idx, varname := range qr.selectVars {
-				if usePrefixes {
-					line[idx] = db.abbreviate(row[varname])
-				} else {
-					line[idx] = row[varname].String()
+				values := []turtle.URI{row[varname]}
+				if route, ok := ParseRoute(row[varname]); ok {
+					values = route
 				}
+				var fields = make([]string, len(values))
+				for vidx, value := range values {
+					if usePrefixes {
+						fields[vidx] = db.abbreviate(value)
+					} else {
+						fields[vidx] = value.String()
+					}
+				}
+				// the elements of a route are separated by spaces
+				line[idx] = strings.Join(fields, " ")
 			}
 			if err := csvwriter.Write(line); err != nil {
 				return err
@@ -121,7 +178,50 @@ func (qr QueryResult) DumpToCSV(usePrefixes bool, db *HodDB, w io.Writer) error
 	return nil
 }
 
+// ResultMap maps the selected variables to their values for one result row.
+// Variables that are unbound in the row (e.g. from an OPTIONAL group that
+// did not match) are not present in the map
 type ResultMap map[string]turtle.URI
+
+// values are marshaled as before, except for routes, which become lists of
+// URIs
+func (m ResultMap) MarshalJSON() ([]byte, error) {
+	var n = make(map[string]interface{}, len(m))
+	for varname, value := range m {
+		if route, ok := ParseRoute(value); ok {
+			n[varname] = route
+		} else {
+			n[varname] = value
+		}
+	}
+	return json.Marshal(n)
+}
+
+// RouteDatatype is the datatype of the values bound by ROUTE. The value of
+// the literal is the JSON encoding of the entities and predicates of the route
+const RouteDatatype = "https://github.com/gtfierro/hod#route"
+
+func routeLiteral(route []turtle.URI) (turtle.URI, error) {
+	value, err := json.Marshal(route)
+	if err != nil {
+		return turtle.URI{}, err
+	}
+	return turtle.TypedLiteral(string(value), RouteDatatype), nil
+}
+
+// ParseRoute returns the entities and predicates of a value bound by ROUTE,
+// in order. ok is false if the value is not a route
+func ParseRoute(value turtle.URI) (route []turtle.URI, ok bool) {
+	if value.Datatype != RouteDatatype {
+		return nil, false
+	}
+	if err := json.Unmarshal([]byte(value.Value), &route); err != nil {
+		return nil, false
+	}
+	return route, true
+}
+
+// LinkResultMap maps entities to their selected links
 type LinkResultMap map[turtle.URI]map[string]string
 
 func (m LinkResultMap) MarshalJSON() ([]byte, error) {
@@ -132,41 +232,216 @@ func (m LinkResultMap) MarshalJSON() ([]byte, error) {
 	return json.Marshal(n)
 }
 
+// LinkResultMap is written by hand for msgp, because its keys are not
+// strings: it is encoded as an array of [entity, links] pairs, or nil for a
+// query that selects no links
+
+// DecodeMsg implements msgp.Decodable
+func (m *LinkResultMap) DecodeMsg(dc *msgp.Reader) error {
+	if dc.IsNil() {
+		*m = nil
+		return dc.ReadNil()
+	}
+	size, err := dc.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+	*m = make(LinkResultMap, size)
+	for ; size > 0; size-- {
+		if pair, err := dc.ReadArrayHeader(); err != nil {
+			return err
+		} else if pair != 2 {
+			return msgp.ArrayError{Wanted: 2, Got: pair}
+		}
+		var entity turtle.URI
+		if err := entity.DecodeMsg(dc); err != nil {
+			return err
+		}
+		linkCount, err := dc.ReadMapHeader()
+		if err != nil {
+			return err
+		}
+		links := make(map[string]string, linkCount)
+		for ; linkCount > 0; linkCount-- {
+			key, err := dc.ReadString()
+			if err != nil {
+				return err
+			}
+			if links[key], err = dc.ReadString(); err != nil {
+				return err
+			}
+		}
+		(*m)[entity] = links
+	}
+	return nil
+}
+
+// EncodeMsg implements msgp.Encodable
+func (m LinkResultMap) EncodeMsg(en *msgp.Writer) error {
+	if m == nil {
+		return en.WriteNil()
+	}
+	if err := en.WriteArrayHeader(uint32(len(m))); err != nil {
+		return err
+	}
+	for entity, links := range m {
+		if err := en.WriteArrayHeader(2); err != nil {
+			return err
+		}
+		if err := entity.EncodeMsg(en); err != nil {
+			return err
+		}
+		if err := en.WriteMapHeader(uint32(len(links))); err != nil {
+			return err
+		}
+		for key, value := range links {
+			if err := en.WriteString(key); err != nil {
+				return err
+			}
+			if err := en.WriteString(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (m LinkResultMap) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.Require(b, m.Msgsize())
+	if m == nil {
+		return msgp.AppendNil(o), nil
+	}
+	o = msgp.AppendArrayHeader(o, uint32(len(m)))
+	for entity, links := range m {
+		o = msgp.AppendArrayHeader(o, 2)
+		var err error
+		if o, err = entity.MarshalMsg(o); err != nil {
+			return o, err
+		}
+		o = msgp.AppendMapHeader(o, uint32(len(links)))
+		for key, value := range links {
+			o = msgp.AppendString(o, key)
+			o = msgp.AppendString(o, value)
+		}
+	}
+	return o, nil
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (m *LinkResultMap) UnmarshalMsg(bts []byte) ([]byte, error) {
+	if msgp.IsNil(bts) {
+		*m = nil
+		return msgp.ReadNilBytes(bts)
+	}
+	size, bts, err := msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		return bts, err
+	}
+	*m = make(LinkResultMap, size)
+	for ; size > 0; size-- {
+		var pair uint32
+		if pair, bts, err = msgp.ReadArrayHeaderBytes(bts); err != nil {
+			return bts, err
+		} else if pair != 2 {
+			return bts, msgp.ArrayError{Wanted: 2, Got: pair}
+		}
+		var entity turtle.URI
+		if bts, err = entity.UnmarshalMsg(bts); err != nil {
+			return bts, err
+		}
+		var linkCount uint32
+		if linkCount, bts, err = msgp.ReadMapHeaderBytes(bts); err != nil {
+			return bts, err
+		}
+		links := make(map[string]string, linkCount)
+		for ; linkCount > 0; linkCount-- {
+			var key, value string
+			if key, bts, err = msgp.ReadStringBytes(bts); err != nil {
+				return bts, err
+			}
+			if value, bts, err = msgp.ReadStringBytes(bts); err != nil {
+				return bts, err
+			}
+			links[key] = value
+		}
+		(*m)[entity] = links
+	}
+	return bts, nil
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by
+// the serialized message
+func (m LinkResultMap) Msgsize() int {
+	s := msgp.ArrayHeaderSize
+	for entity, links := range m {
+		s += msgp.ArrayHeaderSize + entity.Msgsize() + msgp.MapHeaderSize
+		for key, value := range links {
+			s += msgp.StringPrefixSize + len(key) + msgp.StringPrefixSize + len(value)
+		}
+	}
+	return s
+}
+
+// the values of the selected variables in a solution of a query, in the order
+// they are selected
 type ResultRow struct {
-	row   []turtle.URI
-	count int
+	row []turtle.URI
 }
 
+// rows are ordered by comparing their values column by column
 func (rr ResultRow) Less(than btree.Item, ctx interface{}) bool {
 	row := than.(*ResultRow)
-	before := false
-	for idx, item := range rr.row[:rr.count] {
-		if before {
-			return before
+	for idx, item := range rr.row {
+		other := row.row[idx]
+		if item.Namespace != other.Namespace {
+			return item.Namespace < other.Namespace
+		}
+		if item.Value != other.Value {
+			return item.Value < other.Value
+		}
+		if item.Datatype != other.Datatype {
+			return item.Datatype < other.Datatype
+		}
+		if item.Lang != other.Lang {
+			return item.Lang < other.Lang
+		}
+	}
+	return false
+}
+
+// an unbound value in a ResultRow is represented by the empty URI
+func (rr ResultRow) toResultMap(vars []string) ResultMap {
+	m := make(ResultMap, len(vars))
+	for idx, vname := range vars {
+		if rr.row[idx] != (turtle.URI{}) {
+			m[vname] = rr.row[idx]
 		}
-		before = item.Value < row.row[idx].Value || item.Namespace < row.row[idx].Namespace
 	}
-	return before
+	return m
 }
 
-var _emptyResultRow = make([]turtle.URI, 16)
 var _RESULTROWPOOL = sync.Pool{
 	New: func() interface{} {
-		return &ResultRow{
-			row:   make([]turtle.URI, 16),
-			count: 0,
-		}
+		return new(ResultRow)
 	},
 }
 
+// returns a row of num unbound values. Rows are reused once they are finished,
+// and grown when they have fewer values than needed
 func getResultRow(num int) *ResultRow {
 	r := _RESULTROWPOOL.Get().(*ResultRow)
-	r.count = num
+	if cap(r.row) < num {
+		r.row = make([]turtle.URI, num)
+	}
+	r.row = r.row[:num]
+	for idx := range r.row {
+		r.row[idx] = turtle.URI{}
+	}
 	return r
 }
 
 func finishResultRow(r *ResultRow) {
-	r.count = 0
 	_RESULTROWPOOL.Put(r)
 }
 
@@ -5,70 +5,1165 @@ package db
 // DO NOT EDIT
 
 import (
+	"time"
+
 	"github.com/gtfierro/hod/turtle"
 	"github.com/tinylib/msgp/msgp"
 )
 
+// DecodeMsg implements msgp.Decodable
+func (z *AskResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zsat uint32
+	zsat, err = dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	for zsat > 0 {
+		zsat--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Result":
+			z.Result, err = dc.ReadBool()
+			if err != nil {
+				return
+			}
+		case "Elapsed":
+			{
+				var zvhn int64
+				zvhn, err = dc.ReadInt64()
+				z.Elapsed = time.Duration(zvhn)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zylv uint32
+			zylv, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zylv) {
+				z.Errors = (z.Errors)[:zylv]
+			} else {
+				z.Errors = make([]string, zylv)
+			}
+			for zyyo := range z.Errors {
+				z.Errors[zyyo], err = dc.ReadString()
+				if err != nil {
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *AskResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Result"
+	err = en.Append(0x83, 0xa6, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74)
+	if err != nil {
+		return err
+	}
+	err = en.WriteBool(z.Result)
+	if err != nil {
+		return
+	}
+	// write "Elapsed"
+	err = en.Append(0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt64(int64(z.Elapsed))
+	if err != nil {
+		return
+	}
+	// write "Errors"
+	err = en.Append(0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Errors)))
+	if err != nil {
+		return
+	}
+	for zyyo := range z.Errors {
+		err = en.WriteString(z.Errors[zyyo])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AskResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Result"
+	o = append(o, 0x83, 0xa6, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74)
+	o = msgp.AppendBool(o, z.Result)
+	// string "Elapsed"
+	o = append(o, 0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	o = msgp.AppendInt64(o, int64(z.Elapsed))
+	// string "Errors"
+	o = append(o, 0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Errors)))
+	for zyyo := range z.Errors {
+		o = msgp.AppendString(o, z.Errors[zyyo])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AskResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zuqi uint32
+	zuqi, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zuqi > 0 {
+		zuqi--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Result":
+			z.Result, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Elapsed":
+			{
+				var zntq int64
+				zntq, bts, err = msgp.ReadInt64Bytes(bts)
+				z.Elapsed = time.Duration(zntq)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zqib uint32
+			zqib, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zqib) {
+				z.Errors = (z.Errors)[:zqib]
+			} else {
+				z.Errors = make([]string, zqib)
+			}
+			for zyyo := range z.Errors {
+				z.Errors[zyyo], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AskResult) Msgsize() (s int) {
+	s = 1 + 7 + msgp.BoolSize + 8 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize
+	for zyyo := range z.Errors {
+		s += msgp.StringPrefixSize + len(z.Errors[zyyo])
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ConstructResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zkva uint32
+	zkva, err = dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	for zkva > 0 {
+		zkva--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Triples":
+			var zopb uint32
+			zopb, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Triples) >= int(zopb) {
+				z.Triples = (z.Triples)[:zopb]
+			} else {
+				z.Triples = make([]turtle.Triple, zopb)
+			}
+			for zoow := range z.Triples {
+				err = z.Triples[zoow].DecodeMsg(dc)
+				if err != nil {
+					return
+				}
+			}
+		case "Namespaces":
+			var zpeo uint32
+			zpeo, err = dc.ReadMapHeader()
+			if err != nil {
+				return
+			}
+			if z.Namespaces == nil && zpeo > 0 {
+				z.Namespaces = make(map[string]string, zpeo)
+			} else if len(z.Namespaces) > 0 {
+				for key, _ := range z.Namespaces {
+					delete(z.Namespaces, key)
+				}
+			}
+			for zpeo > 0 {
+				zpeo--
+				var zpty string
+				var zlvk string
+				zpty, err = dc.ReadString()
+				if err != nil {
+					return
+				}
+				zlvk, err = dc.ReadString()
+				if err != nil {
+					return
+				}
+				z.Namespaces[zpty] = zlvk
+			}
+		case "Elapsed":
+			{
+				var zwoe int64
+				zwoe, err = dc.ReadInt64()
+				z.Elapsed = time.Duration(zwoe)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zeei uint32
+			zeei, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zeei) {
+				z.Errors = (z.Errors)[:zeei]
+			} else {
+				z.Errors = make([]string, zeei)
+			}
+			for zkln := range z.Errors {
+				z.Errors[zkln], err = dc.ReadString()
+				if err != nil {
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ConstructResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Triples"
+	err = en.Append(0x84, 0xa7, 0x54, 0x72, 0x69, 0x70, 0x6c, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Triples)))
+	if err != nil {
+		return
+	}
+	for zoow := range z.Triples {
+		err = z.Triples[zoow].EncodeMsg(en)
+		if err != nil {
+			return
+		}
+	}
+	// write "Namespaces"
+	err = en.Append(0xaa, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteMapHeader(uint32(len(z.Namespaces)))
+	if err != nil {
+		return
+	}
+	for zpty, zlvk := range z.Namespaces {
+		err = en.WriteString(zpty)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(zlvk)
+		if err != nil {
+			return
+		}
+	}
+	// write "Elapsed"
+	err = en.Append(0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt64(int64(z.Elapsed))
+	if err != nil {
+		return
+	}
+	// write "Errors"
+	err = en.Append(0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Errors)))
+	if err != nil {
+		return
+	}
+	for zkln := range z.Errors {
+		err = en.WriteString(z.Errors[zkln])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ConstructResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Triples"
+	o = append(o, 0x84, 0xa7, 0x54, 0x72, 0x69, 0x70, 0x6c, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Triples)))
+	for zoow := range z.Triples {
+		o, err = z.Triples[zoow].MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
+	// string "Namespaces"
+	o = append(o, 0xaa, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Namespaces)))
+	for zpty, zlvk := range z.Namespaces {
+		o = msgp.AppendString(o, zpty)
+		o = msgp.AppendString(o, zlvk)
+	}
+	// string "Elapsed"
+	o = append(o, 0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	o = msgp.AppendInt64(o, int64(z.Elapsed))
+	// string "Errors"
+	o = append(o, 0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Errors)))
+	for zkln := range z.Errors {
+		o = msgp.AppendString(o, z.Errors[zkln])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ConstructResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zoqe uint32
+	zoqe, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zoqe > 0 {
+		zoqe--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Triples":
+			var zlov uint32
+			zlov, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Triples) >= int(zlov) {
+				z.Triples = (z.Triples)[:zlov]
+			} else {
+				z.Triples = make([]turtle.Triple, zlov)
+			}
+			for zoow := range z.Triples {
+				bts, err = z.Triples[zoow].UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "Namespaces":
+			var zxjj uint32
+			zxjj, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if z.Namespaces == nil && zxjj > 0 {
+				z.Namespaces = make(map[string]string, zxjj)
+			} else if len(z.Namespaces) > 0 {
+				for key, _ := range z.Namespaces {
+					delete(z.Namespaces, key)
+				}
+			}
+			for zxjj > 0 {
+				var zpty string
+				var zlvk string
+				zxjj--
+				zpty, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+				zlvk, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Namespaces[zpty] = zlvk
+			}
+		case "Elapsed":
+			{
+				var zbzv int64
+				zbzv, bts, err = msgp.ReadInt64Bytes(bts)
+				z.Elapsed = time.Duration(zbzv)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zzyi uint32
+			zzyi, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zzyi) {
+				z.Errors = (z.Errors)[:zzyi]
+			} else {
+				z.Errors = make([]string, zzyi)
+			}
+			for zkln := range z.Errors {
+				z.Errors[zkln], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ConstructResult) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize
+	for zoow := range z.Triples {
+		s += z.Triples[zoow].Msgsize()
+	}
+	s += 11 + msgp.MapHeaderSize
+	if z.Namespaces != nil {
+		for zpty, zlvk := range z.Namespaces {
+			_ = zlvk
+			s += msgp.StringPrefixSize + len(zpty) + msgp.StringPrefixSize + len(zlvk)
+		}
+	}
+	s += 8 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize
+	for zkln := range z.Errors {
+		s += msgp.StringPrefixSize + len(z.Errors[zkln])
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *DatabaseStatus) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zqvi uint32
+	zqvi, err = dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	for zqvi > 0 {
+		zqvi--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Rows":
+			z.Rows, err = dc.ReadInt()
+			if err != nil {
+				return
+			}
+		case "Elapsed":
+			{
+				var zuzj int64
+				zuzj, err = dc.ReadInt64()
+				z.Elapsed = time.Duration(zuzj)
+			}
+			if err != nil {
+				return
+			}
+		case "Updated":
+			z.Updated, err = dc.ReadBool()
+			if err != nil {
+				return
+			}
+		case "Error":
+			z.Error, err = dc.ReadString()
+			if err != nil {
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *DatabaseStatus) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Rows"
+	err = en.Append(0x84, 0xa4, 0x52, 0x6f, 0x77, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt(z.Rows)
+	if err != nil {
+		return
+	}
+	// write "Elapsed"
+	err = en.Append(0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt64(int64(z.Elapsed))
+	if err != nil {
+		return
+	}
+	// write "Updated"
+	err = en.Append(0xa7, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteBool(z.Updated)
+	if err != nil {
+		return
+	}
+	// write "Error"
+	err = en.Append(0xa5, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Error)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DatabaseStatus) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Rows"
+	o = append(o, 0x84, 0xa4, 0x52, 0x6f, 0x77, 0x73)
+	o = msgp.AppendInt(o, z.Rows)
+	// string "Elapsed"
+	o = append(o, 0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	o = msgp.AppendInt64(o, int64(z.Elapsed))
+	// string "Updated"
+	o = append(o, 0xa7, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Updated)
+	// string "Error"
+	o = append(o, 0xa5, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Error)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DatabaseStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zyzi uint32
+	zyzi, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zyzi > 0 {
+		zyzi--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Rows":
+			z.Rows, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Elapsed":
+			{
+				var zglc int64
+				zglc, bts, err = msgp.ReadInt64Bytes(bts)
+				z.Elapsed = time.Duration(zglc)
+			}
+			if err != nil {
+				return
+			}
+		case "Updated":
+			z.Updated, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				return
+			}
+		case "Error":
+			z.Error, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DatabaseStatus) Msgsize() (s int) {
+	s = 1 + 5 + msgp.IntSize + 8 + msgp.Int64Size + 8 + msgp.BoolSize + 6 + msgp.StringPrefixSize + len(z.Error)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *DescribeResult) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zudu uint32
+	zudu, err = dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	for zudu > 0 {
+		zudu--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Entities":
+			var ztlt uint32
+			ztlt, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Entities) >= int(ztlt) {
+				z.Entities = (z.Entities)[:ztlt]
+			} else {
+				z.Entities = make([]turtle.URI, ztlt)
+			}
+			for zxhx := range z.Entities {
+				err = z.Entities[zxhx].DecodeMsg(dc)
+				if err != nil {
+					return
+				}
+			}
+		case "Triples":
+			var zjdf uint32
+			zjdf, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Triples) >= int(zjdf) {
+				z.Triples = (z.Triples)[:zjdf]
+			} else {
+				z.Triples = make([]turtle.Triple, zjdf)
+			}
+			for zefj := range z.Triples {
+				err = z.Triples[zefj].DecodeMsg(dc)
+				if err != nil {
+					return
+				}
+			}
+		case "Namespaces":
+			var zikt uint32
+			zikt, err = dc.ReadMapHeader()
+			if err != nil {
+				return
+			}
+			if z.Namespaces == nil && zikt > 0 {
+				z.Namespaces = make(map[string]string, zikt)
+			} else if len(z.Namespaces) > 0 {
+				for key, _ := range z.Namespaces {
+					delete(z.Namespaces, key)
+				}
+			}
+			for zikt > 0 {
+				zikt--
+				var zgaj string
+				var zlxg string
+				zgaj, err = dc.ReadString()
+				if err != nil {
+					return
+				}
+				zlxg, err = dc.ReadString()
+				if err != nil {
+					return
+				}
+				z.Namespaces[zgaj] = zlxg
+			}
+		case "Elapsed":
+			{
+				var zkjo int64
+				zkjo, err = dc.ReadInt64()
+				z.Elapsed = time.Duration(zkjo)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zxoe uint32
+			zxoe, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zxoe) {
+				z.Errors = (z.Errors)[:zxoe]
+			} else {
+				z.Errors = make([]string, zxoe)
+			}
+			for zpwz := range z.Errors {
+				z.Errors[zpwz], err = dc.ReadString()
+				if err != nil {
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *DescribeResult) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "Entities"
+	err = en.Append(0x85, 0xa8, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Entities)))
+	if err != nil {
+		return
+	}
+	for zxhx := range z.Entities {
+		err = z.Entities[zxhx].EncodeMsg(en)
+		if err != nil {
+			return
+		}
+	}
+	// write "Triples"
+	err = en.Append(0xa7, 0x54, 0x72, 0x69, 0x70, 0x6c, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Triples)))
+	if err != nil {
+		return
+	}
+	for zefj := range z.Triples {
+		err = z.Triples[zefj].EncodeMsg(en)
+		if err != nil {
+			return
+		}
+	}
+	// write "Namespaces"
+	err = en.Append(0xaa, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteMapHeader(uint32(len(z.Namespaces)))
+	if err != nil {
+		return
+	}
+	for zgaj, zlxg := range z.Namespaces {
+		err = en.WriteString(zgaj)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(zlxg)
+		if err != nil {
+			return
+		}
+	}
+	// write "Elapsed"
+	err = en.Append(0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt64(int64(z.Elapsed))
+	if err != nil {
+		return
+	}
+	// write "Errors"
+	err = en.Append(0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Errors)))
+	if err != nil {
+		return
+	}
+	for zpwz := range z.Errors {
+		err = en.WriteString(z.Errors[zpwz])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DescribeResult) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "Entities"
+	o = append(o, 0x85, 0xa8, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Entities)))
+	for zxhx := range z.Entities {
+		o, err = z.Entities[zxhx].MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
+	// string "Triples"
+	o = append(o, 0xa7, 0x54, 0x72, 0x69, 0x70, 0x6c, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Triples)))
+	for zefj := range z.Triples {
+		o, err = z.Triples[zefj].MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
+	// string "Namespaces"
+	o = append(o, 0xaa, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Namespaces)))
+	for zgaj, zlxg := range z.Namespaces {
+		o = msgp.AppendString(o, zgaj)
+		o = msgp.AppendString(o, zlxg)
+	}
+	// string "Elapsed"
+	o = append(o, 0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	o = msgp.AppendInt64(o, int64(z.Elapsed))
+	// string "Errors"
+	o = append(o, 0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Errors)))
+	for zpwz := range z.Errors {
+		o = msgp.AppendString(o, z.Errors[zpwz])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DescribeResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zufw uint32
+	zufw, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for zufw > 0 {
+		zufw--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Entities":
+			var zupo uint32
+			zupo, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Entities) >= int(zupo) {
+				z.Entities = (z.Entities)[:zupo]
+			} else {
+				z.Entities = make([]turtle.URI, zupo)
+			}
+			for zxhx := range z.Entities {
+				bts, err = z.Entities[zxhx].UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "Triples":
+			var zohb uint32
+			zohb, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Triples) >= int(zohb) {
+				z.Triples = (z.Triples)[:zohb]
+			} else {
+				z.Triples = make([]turtle.Triple, zohb)
+			}
+			for zefj := range z.Triples {
+				bts, err = z.Triples[zefj].UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "Namespaces":
+			var zvdu uint32
+			zvdu, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if z.Namespaces == nil && zvdu > 0 {
+				z.Namespaces = make(map[string]string, zvdu)
+			} else if len(z.Namespaces) > 0 {
+				for key, _ := range z.Namespaces {
+					delete(z.Namespaces, key)
+				}
+			}
+			for zvdu > 0 {
+				var zgaj string
+				var zlxg string
+				zvdu--
+				zgaj, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+				zlxg, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Namespaces[zgaj] = zlxg
+			}
+		case "Elapsed":
+			{
+				var zjid int64
+				zjid, bts, err = msgp.ReadInt64Bytes(bts)
+				z.Elapsed = time.Duration(zjid)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zdgg uint32
+			zdgg, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zdgg) {
+				z.Errors = (z.Errors)[:zdgg]
+			} else {
+				z.Errors = make([]string, zdgg)
+			}
+			for zpwz := range z.Errors {
+				z.Errors[zpwz], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DescribeResult) Msgsize() (s int) {
+	s = 1 + 9 + msgp.ArrayHeaderSize
+	for zxhx := range z.Entities {
+		s += z.Entities[zxhx].Msgsize()
+	}
+	s += 8 + msgp.ArrayHeaderSize
+	for zefj := range z.Triples {
+		s += z.Triples[zefj].Msgsize()
+	}
+	s += 11 + msgp.MapHeaderSize
+	if z.Namespaces != nil {
+		for zgaj, zlxg := range z.Namespaces {
+			_ = zlxg
+			s += msgp.StringPrefixSize + len(zgaj) + msgp.StringPrefixSize + len(zlxg)
+		}
+	}
+	s += 8 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize
+	for zpwz := range z.Errors {
+		s += msgp.StringPrefixSize + len(z.Errors[zpwz])
+	}
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *QueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
-	var zcmr uint32
-	zcmr, err = dc.ReadMapHeader()
+	var zkbv uint32
+	zkbv, err = dc.ReadMapHeader()
 	if err != nil {
 		return
 	}
-	for zcmr > 0 {
-		zcmr--
+	for zkbv > 0 {
+		zkbv--
 		field, err = dc.ReadMapKeyPtr()
 		if err != nil {
 			return
 		}
 		switch msgp.UnsafeString(field) {
 		case "Rows":
-			var zajw uint32
-			zajw, err = dc.ReadArrayHeader()
+			var zimr uint32
+			zimr, err = dc.ReadArrayHeader()
 			if err != nil {
 				return
 			}
-			if cap(z.Rows) >= int(zajw) {
-				z.Rows = (z.Rows)[:zajw]
+			if cap(z.Rows) >= int(zimr) {
+				z.Rows = (z.Rows)[:zimr]
 			} else {
-				z.Rows = make([]ResultMap, zajw)
+				z.Rows = make([]ResultMap, zimr)
 			}
-			for zxvk := range z.Rows {
-				var zwht uint32
-				zwht, err = dc.ReadMapHeader()
+			for zaev := range z.Rows {
+				var zjfv uint32
+				zjfv, err = dc.ReadMapHeader()
 				if err != nil {
 					return
 				}
-				if z.Rows[zxvk] == nil && zwht > 0 {
-					z.Rows[zxvk] = make(ResultMap, zwht)
-				} else if len(z.Rows[zxvk]) > 0 {
-					for key, _ := range z.Rows[zxvk] {
-						delete(z.Rows[zxvk], key)
+				if z.Rows[zaev] == nil && zjfv > 0 {
+					z.Rows[zaev] = make(ResultMap, zjfv)
+				} else if len(z.Rows[zaev]) > 0 {
+					for key, _ := range z.Rows[zaev] {
+						delete(z.Rows[zaev], key)
 					}
 				}
-				for zwht > 0 {
-					zwht--
-					var zbzg string
-					var zbai turtle.URI
-					zbzg, err = dc.ReadString()
+				for zjfv > 0 {
+					zjfv--
+					var znrz string
+					var zach turtle.URI
+					znrz, err = dc.ReadString()
 					if err != nil {
 						return
 					}
-					err = zbai.DecodeMsg(dc)
+					err = zach.DecodeMsg(dc)
 					if err != nil {
 						return
 					}
-					z.Rows[zxvk][zbzg] = zbai
+					z.Rows[zaev][znrz] = zach
 				}
 			}
+		case "Links":
+			err = z.Links.DecodeMsg(dc)
+			if err != nil {
+				return
+			}
 		case "Count":
 			z.Count, err = dc.ReadInt()
 			if err != nil {
 				return
 			}
+		case "Elapsed":
+			{
+				var zfad int64
+				zfad, err = dc.ReadInt64()
+				z.Elapsed = time.Duration(zfad)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var zkca uint32
+			zkca, err = dc.ReadArrayHeader()
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(zkca) {
+				z.Errors = (z.Errors)[:zkca]
+			} else {
+				z.Errors = make([]string, zkca)
+			}
+			for zcdp := range z.Errors {
+				z.Errors[zcdp], err = dc.ReadString()
+				if err != nil {
+					return
+				}
+			}
+		case "Databases":
+			var zmaw uint32
+			zmaw, err = dc.ReadMapHeader()
+			if err != nil {
+				return
+			}
+			if z.Databases == nil && zmaw > 0 {
+				z.Databases = make(map[string]DatabaseStatus, zmaw)
+			} else if len(z.Databases) > 0 {
+				for key, _ := range z.Databases {
+					delete(z.Databases, key)
+				}
+			}
+			for zmaw > 0 {
+				zmaw--
+				var zmjg string
+				var zxbn DatabaseStatus
+				zmjg, err = dc.ReadString()
+				if err != nil {
+					return
+				}
+				err = zxbn.DecodeMsg(dc)
+				if err != nil {
+					return
+				}
+				z.Databases[zmjg] = zxbn
+			}
+		case "Partial":
+			z.Partial, err = dc.ReadBool()
+			if err != nil {
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -81,9 +1176,9 @@ func (z *QueryResult) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *QueryResult) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 7
 	// write "Rows"
-	err = en.Append(0x82, 0xa4, 0x52, 0x6f, 0x77, 0x73)
+	err = en.Append(0x87, 0xa4, 0x52, 0x6f, 0x77, 0x73)
 	if err != nil {
 		return err
 	}
@@ -91,22 +1186,31 @@ func (z *QueryResult) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
-	for zxvk := range z.Rows {
-		err = en.WriteMapHeader(uint32(len(z.Rows[zxvk])))
+	for zaev := range z.Rows {
+		err = en.WriteMapHeader(uint32(len(z.Rows[zaev])))
 		if err != nil {
 			return
 		}
-		for zbzg, zbai := range z.Rows[zxvk] {
-			err = en.WriteString(zbzg)
+		for znrz, zach := range z.Rows[zaev] {
+			err = en.WriteString(znrz)
 			if err != nil {
 				return
 			}
-			err = zbai.EncodeMsg(en)
+			err = zach.EncodeMsg(en)
 			if err != nil {
 				return
 			}
 		}
 	}
+	// write "Links"
+	err = en.Append(0xa5, 0x4c, 0x69, 0x6e, 0x6b, 0x73)
+	if err != nil {
+		return err
+	}
+	err = z.Links.EncodeMsg(en)
+	if err != nil {
+		return
+	}
 	// write "Count"
 	err = en.Append(0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 	if err != nil {
@@ -116,29 +1220,109 @@ func (z *QueryResult) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
+	// write "Elapsed"
+	err = en.Append(0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteInt64(int64(z.Elapsed))
+	if err != nil {
+		return
+	}
+	// write "Errors"
+	err = en.Append(0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Errors)))
+	if err != nil {
+		return
+	}
+	for zcdp := range z.Errors {
+		err = en.WriteString(z.Errors[zcdp])
+		if err != nil {
+			return
+		}
+	}
+	// write "Databases"
+	err = en.Append(0xa9, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73)
+	if err != nil {
+		return err
+	}
+	err = en.WriteMapHeader(uint32(len(z.Databases)))
+	if err != nil {
+		return
+	}
+	for zmjg, zxbn := range z.Databases {
+		err = en.WriteString(zmjg)
+		if err != nil {
+			return
+		}
+		err = zxbn.EncodeMsg(en)
+		if err != nil {
+			return
+		}
+	}
+	// write "Partial"
+	err = en.Append(0xa7, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c)
+	if err != nil {
+		return err
+	}
+	err = en.WriteBool(z.Partial)
+	if err != nil {
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *QueryResult) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 7
 	// string "Rows"
-	o = append(o, 0x82, 0xa4, 0x52, 0x6f, 0x77, 0x73)
+	o = append(o, 0x87, 0xa4, 0x52, 0x6f, 0x77, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.Rows)))
-	for zxvk := range z.Rows {
-		o = msgp.AppendMapHeader(o, uint32(len(z.Rows[zxvk])))
-		for zbzg, zbai := range z.Rows[zxvk] {
-			o = msgp.AppendString(o, zbzg)
-			o, err = zbai.MarshalMsg(o)
+	for zaev := range z.Rows {
+		o = msgp.AppendMapHeader(o, uint32(len(z.Rows[zaev])))
+		for znrz, zach := range z.Rows[zaev] {
+			o = msgp.AppendString(o, znrz)
+			o, err = zach.MarshalMsg(o)
 			if err != nil {
 				return
 			}
 		}
 	}
+	// string "Links"
+	o = append(o, 0xa5, 0x4c, 0x69, 0x6e, 0x6b, 0x73)
+	o, err = z.Links.MarshalMsg(o)
+	if err != nil {
+		return
+	}
 	// string "Count"
 	o = append(o, 0xa5, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 	o = msgp.AppendInt(o, z.Count)
+	// string "Elapsed"
+	o = append(o, 0xa7, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64)
+	o = msgp.AppendInt64(o, int64(z.Elapsed))
+	// string "Errors"
+	o = append(o, 0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Errors)))
+	for zcdp := range z.Errors {
+		o = msgp.AppendString(o, z.Errors[zcdp])
+	}
+	// string "Databases"
+	o = append(o, 0xa9, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Databases)))
+	for zmjg, zxbn := range z.Databases {
+		o = msgp.AppendString(o, zmjg)
+		o, err = zxbn.MarshalMsg(o)
+		if err != nil {
+			return
+		}
+	}
+	// string "Partial"
+	o = append(o, 0xa7, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c)
+	o = msgp.AppendBool(o, z.Partial)
 	return
 }
 
@@ -146,62 +1330,125 @@ func (z *QueryResult) MarshalMsg(b []byte) (o []byte, err error) {
 func (z *QueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
-	var zhct uint32
-	zhct, bts, err = msgp.ReadMapHeaderBytes(bts)
+	var ztnt uint32
+	ztnt, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		return
 	}
-	for zhct > 0 {
-		zhct--
+	for ztnt > 0 {
+		ztnt--
 		field, bts, err = msgp.ReadMapKeyZC(bts)
 		if err != nil {
 			return
 		}
 		switch msgp.UnsafeString(field) {
 		case "Rows":
-			var zcua uint32
-			zcua, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zibi uint32
+			zibi, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				return
 			}
-			if cap(z.Rows) >= int(zcua) {
-				z.Rows = (z.Rows)[:zcua]
+			if cap(z.Rows) >= int(zibi) {
+				z.Rows = (z.Rows)[:zibi]
 			} else {
-				z.Rows = make([]ResultMap, zcua)
+				z.Rows = make([]ResultMap, zibi)
 			}
-			for zxvk := range z.Rows {
-				var zxhx uint32
-				zxhx, bts, err = msgp.ReadMapHeaderBytes(bts)
+			for zaev := range z.Rows {
+				var zycc uint32
+				zycc, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					return
 				}
-				if z.Rows[zxvk] == nil && zxhx > 0 {
-					z.Rows[zxvk] = make(ResultMap, zxhx)
-				} else if len(z.Rows[zxvk]) > 0 {
-					for key, _ := range z.Rows[zxvk] {
-						delete(z.Rows[zxvk], key)
+				if z.Rows[zaev] == nil && zycc > 0 {
+					z.Rows[zaev] = make(ResultMap, zycc)
+				} else if len(z.Rows[zaev]) > 0 {
+					for key, _ := range z.Rows[zaev] {
+						delete(z.Rows[zaev], key)
 					}
 				}
-				for zxhx > 0 {
-					var zbzg string
-					var zbai turtle.URI
-					zxhx--
-					zbzg, bts, err = msgp.ReadStringBytes(bts)
+				for zycc > 0 {
+					var znrz string
+					var zach turtle.URI
+					zycc--
+					znrz, bts, err = msgp.ReadStringBytes(bts)
 					if err != nil {
 						return
 					}
-					bts, err = zbai.UnmarshalMsg(bts)
+					bts, err = zach.UnmarshalMsg(bts)
 					if err != nil {
 						return
 					}
-					z.Rows[zxvk][zbzg] = zbai
+					z.Rows[zaev][znrz] = zach
 				}
 			}
+		case "Links":
+			bts, err = z.Links.UnmarshalMsg(bts)
+			if err != nil {
+				return
+			}
 		case "Count":
 			z.Count, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
 				return
 			}
+		case "Elapsed":
+			{
+				var ztki int64
+				ztki, bts, err = msgp.ReadInt64Bytes(bts)
+				z.Elapsed = time.Duration(ztki)
+			}
+			if err != nil {
+				return
+			}
+		case "Errors":
+			var ziyb uint32
+			ziyb, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if cap(z.Errors) >= int(ziyb) {
+				z.Errors = (z.Errors)[:ziyb]
+			} else {
+				z.Errors = make([]string, ziyb)
+			}
+			for zcdp := range z.Errors {
+				z.Errors[zcdp], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+			}
+		case "Databases":
+			var znkl uint32
+			znkl, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				return
+			}
+			if z.Databases == nil && znkl > 0 {
+				z.Databases = make(map[string]DatabaseStatus, znkl)
+			} else if len(z.Databases) > 0 {
+				for key, _ := range z.Databases {
+					delete(z.Databases, key)
+				}
+			}
+			for znkl > 0 {
+				var zmjg string
+				var zxbn DatabaseStatus
+				znkl--
+				zmjg, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					return
+				}
+				bts, err = zxbn.UnmarshalMsg(bts)
+				if err != nil {
+					return
+				}
+				z.Databases[zmjg] = zxbn
+			}
+		case "Partial":
+			z.Partial, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -216,46 +1463,57 @@ func (z *QueryResult) UnmarshalMsg(bts []byte) (o []byte, err error) {
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *QueryResult) Msgsize() (s int) {
 	s = 1 + 5 + msgp.ArrayHeaderSize
-	for zxvk := range z.Rows {
+	for zaev := range z.Rows {
 		s += msgp.MapHeaderSize
-		if z.Rows[zxvk] != nil {
-			for zbzg, zbai := range z.Rows[zxvk] {
-				_ = zbai
-				s += msgp.StringPrefixSize + len(zbzg) + zbai.Msgsize()
+		if z.Rows[zaev] != nil {
+			for znrz, zach := range z.Rows[zaev] {
+				_ = zach
+				s += msgp.StringPrefixSize + len(znrz) + zach.Msgsize()
 			}
 		}
 	}
-	s += 6 + msgp.IntSize
+	s += 6 + z.Links.Msgsize() + 6 + msgp.IntSize + 8 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize
+	for zcdp := range z.Errors {
+		s += msgp.StringPrefixSize + len(z.Errors[zcdp])
+	}
+	s += 10 + msgp.MapHeaderSize
+	if z.Databases != nil {
+		for zmjg, zxbn := range z.Databases {
+			_ = zxbn
+			s += msgp.StringPrefixSize + len(zmjg) + zxbn.Msgsize()
+		}
+	}
+	s += 8 + msgp.BoolSize
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
 func (z *ResultMap) DecodeMsg(dc *msgp.Reader) (err error) {
-	var zcxo uint32
-	zcxo, err = dc.ReadMapHeader()
+	var zanm uint32
+	zanm, err = dc.ReadMapHeader()
 	if err != nil {
 		return
 	}
-	if (*z) == nil && zcxo > 0 {
-		(*z) = make(ResultMap, zcxo)
+	if (*z) == nil && zanm > 0 {
+		(*z) = make(ResultMap, zanm)
 	} else if len((*z)) > 0 {
 		for key, _ := range *z {
 			delete((*z), key)
 		}
 	}
-	for zcxo > 0 {
-		zcxo--
-		var zpks string
-		var zjfb turtle.URI
-		zpks, err = dc.ReadString()
+	for zanm > 0 {
+		zanm--
+		var zvhf string
+		var zwki turtle.URI
+		zvhf, err = dc.ReadString()
 		if err != nil {
 			return
 		}
-		err = zjfb.DecodeMsg(dc)
+		err = zwki.DecodeMsg(dc)
 		if err != nil {
 			return
 		}
-		(*z)[zpks] = zjfb
+		(*z)[zvhf] = zwki
 	}
 	return
 }
@@ -266,12 +1524,12 @@ func (z ResultMap) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
-	for zeff, zrsw := range z {
-		err = en.WriteString(zeff)
+	for zstt, ziss := range z {
+		err = en.WriteString(zstt)
 		if err != nil {
 			return
 		}
-		err = zrsw.EncodeMsg(en)
+		err = ziss.EncodeMsg(en)
 		if err != nil {
 			return
 		}
@@ -283,9 +1541,9 @@ func (z ResultMap) EncodeMsg(en *msgp.Writer) (err error) {
 func (z ResultMap) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	o = msgp.AppendMapHeader(o, uint32(len(z)))
-	for zeff, zrsw := range z {
-		o = msgp.AppendString(o, zeff)
-		o, err = zrsw.MarshalMsg(o)
+	for zstt, ziss := range z {
+		o = msgp.AppendString(o, zstt)
+		o, err = ziss.MarshalMsg(o)
 		if err != nil {
 			return
 		}
@@ -295,31 +1553,31 @@ func (z ResultMap) MarshalMsg(b []byte) (o []byte, err error) {
 
 // UnmarshalMsg implements msgp.Unmarshaler
 func (z *ResultMap) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zobc uint32
-	zobc, bts, err = msgp.ReadMapHeaderBytes(bts)
+	var zbal uint32
+	zbal, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		return
 	}
-	if (*z) == nil && zobc > 0 {
-		(*z) = make(ResultMap, zobc)
+	if (*z) == nil && zbal > 0 {
+		(*z) = make(ResultMap, zbal)
 	} else if len((*z)) > 0 {
 		for key, _ := range *z {
 			delete((*z), key)
 		}
 	}
-	for zobc > 0 {
-		var zxpk string
-		var zdnj turtle.URI
-		zobc--
-		zxpk, bts, err = msgp.ReadStringBytes(bts)
+	for zbal > 0 {
+		var znkw string
+		var zxdg turtle.URI
+		zbal--
+		znkw, bts, err = msgp.ReadStringBytes(bts)
 		if err != nil {
 			return
 		}
-		bts, err = zdnj.UnmarshalMsg(bts)
+		bts, err = zxdg.UnmarshalMsg(bts)
 		if err != nil {
 			return
 		}
-		(*z)[zxpk] = zdnj
+		(*z)[znkw] = zxdg
 	}
 	o = bts
 	return
@@ -329,9 +1587,9 @@ func (z *ResultMap) UnmarshalMsg(bts []byte) (o []byte, err error) {
 func (z ResultMap) Msgsize() (s int) {
 	s = msgp.MapHeaderSize
 	if z != nil {
-		for zsnv, zkgt := range z {
-			_ = zkgt
-			s += msgp.StringPrefixSize + len(zsnv) + zkgt.Msgsize()
+		for zlti, ztwa := range z {
+			_ = ztwa
+			s += msgp.StringPrefixSize + len(zlti) + ztwa.Msgsize()
 		}
 	}
 	return
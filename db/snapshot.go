@@ -17,6 +17,7 @@ type snapshot struct {
 	predSnapshot     *leveldb.Snapshot
 	graphSnapshot    *leveldb.Snapshot
 	extendedSnapshot *leveldb.Snapshot
+	linkSnapshot     *leveldb.Snapshot
 }
 
 func (db *DB) snapshot() (snap *snapshot, err error) {
@@ -40,6 +41,9 @@ func (db *DB) snapshot() (snap *snapshot, err error) {
 			if snap.extendedSnapshot != nil {
 				snap.extendedSnapshot.Release()
 			}
+			if snap.linkSnapshot != nil {
+				snap.linkSnapshot.Release()
+			}
 			return nil, err
 		} else {
 			return dbsnap, nil
@@ -60,6 +64,9 @@ func (db *DB) snapshot() (snap *snapshot, err error) {
 	if snap.extendedSnapshot, err = getSnapshot(db.extendedDB); err != nil {
 		return nil, err
 	}
+	if snap.linkSnapshot, err = getSnapshot(db.linkDB); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -69,6 +76,7 @@ func (snap *snapshot) Close() {
 	snap.predSnapshot.Release()
 	snap.graphSnapshot.Release()
 	snap.extendedSnapshot.Release()
+	snap.linkSnapshot.Release()
 }
 
 func (snap *snapshot) done() error {
@@ -77,6 +85,7 @@ func (snap *snapshot) done() error {
 	snap.predSnapshot.Release()
 	snap.graphSnapshot.Release()
 	snap.extendedSnapshot.Release()
+	snap.linkSnapshot.Release()
 	return nil
 }
 
@@ -212,6 +221,8 @@ func (snap *snapshot) MustGetEntityIndexFromHash(hash Key) *EntityExtendedIndex
 // takes the inverse of every relationship. If no inverse exists, returns nil
 func (snap *snapshot) reversePathPattern(path []sparql.PathPattern) []sparql.PathPattern {
 	var reverse = make([]sparql.PathPattern, len(path))
+	snap.db.relLock.RLock()
+	defer snap.db.relLock.RUnlock()
 	for idx, pred := range path {
 		if inverse, found := snap.db.relationships[pred.Predicate]; found {
 			pred.Predicate = inverse
@@ -224,7 +235,9 @@ func (snap *snapshot) reversePathPattern(path []sparql.PathPattern) []sparql.Pat
 }
 
 func (snap *snapshot) getReverseRelationship(forward turtle.URI) (reverse turtle.URI, found bool) {
+	snap.db.relLock.RLock()
 	reverse, found = snap.db.relationships[forward]
+	snap.db.relLock.RUnlock()
 	return
 }
 
@@ -288,7 +301,9 @@ func (snap *snapshot) followPathFromObject(object *Entity, results *keymap, sear
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return
+					// the index holds everything reachable from this entity, so
+					// it does not need to be searched further
+					continue
 				}
 			}
 			endpoints, found := entity.InEdges[string(predHash[:])]
@@ -313,7 +328,7 @@ func (snap *snapshot) followPathFromObject(object *Entity, results *keymap, sear
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return
+					continue
 				}
 			}
 			edges, found := entity.InEdges[string(predHash[:])]
@@ -389,7 +404,7 @@ func (snap *snapshot) followPathFromSubject(subject *Entity, results *keymap, se
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return
+					continue
 				}
 			}
 
@@ -415,7 +430,7 @@ func (snap *snapshot) followPathFromSubject(subject *Entity, results *keymap, se
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return
+					continue
 				}
 			}
 			edges, found := entity.OutEdges[string(predHash[:])]
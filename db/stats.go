@@ -0,0 +1,304 @@
+package db
+
+import (
+	"math"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
+)
+
+// a guess at the number of times an unbounded repetition (p+, p*) follows its
+// path from an entity
+const repetitionDepth = 3
+
+// statistics about the graph that the query planner uses to estimate how many
+// rows each term of a query produces. They are gathered when the database is
+// opened and updated by each transaction; a value is never changed once it is
+// in use, so updates replace it
+type graphStats struct {
+	// the number of entities that are the subject or object of an edge, and
+	// the number of edges in the graph
+	entities int
+	edges    int
+	// the edges of each predicate
+	predicates map[turtle.URI]predicateStats
+	// the number of instances of each class, from rdf:type
+	classes map[turtle.URI]int
+}
+
+type predicateStats struct {
+	// the number of edges, and of the distinct subjects and objects they join
+	edges, subjects, objects int
+}
+
+func newGraphStats() *graphStats {
+	return &graphStats{
+		predicates: make(map[turtle.URI]predicateStats),
+		classes:    make(map[turtle.URI]int),
+	}
+}
+
+func newPredicateStats(pred *PredicateEntity) predicateStats {
+	ps := predicateStats{subjects: len(pred.Subjects), objects: len(pred.Objects)}
+	for _, objects := range pred.Subjects {
+		ps.edges += len(objects)
+	}
+	return ps
+}
+
+// the average number of objects of a subject of the predicate
+func (ps predicateStats) fanOut() float64 {
+	if ps.subjects == 0 {
+		return 0
+	}
+	return float64(ps.edges) / float64(ps.subjects)
+}
+
+// the average number of subjects of an object of the predicate
+func (ps predicateStats) fanIn() float64 {
+	if ps.objects == 0 {
+		return 0
+	}
+	return float64(ps.edges) / float64(ps.objects)
+}
+
+// returns a copy of the statistics that updates can change
+func (stats *graphStats) copy() *graphStats {
+	c := &graphStats{
+		entities:   stats.entities,
+		edges:      stats.edges,
+		predicates: make(map[turtle.URI]predicateStats, len(stats.predicates)),
+		classes:    make(map[turtle.URI]int, len(stats.classes)),
+	}
+	for uri, ps := range stats.predicates {
+		c.predicates[uri] = ps
+	}
+	for uri, count := range stats.classes {
+		c.classes[uri] = count
+	}
+	return c
+}
+
+// replaces the statistics of the predicate. If it is rdf:type, the instances
+// of the classes are counted again; getURI resolves the classes
+func (stats *graphStats) setPredicate(uri turtle.URI, pred *PredicateEntity, getURI func(Key) (turtle.URI, error)) error {
+	ps := newPredicateStats(pred)
+	stats.edges += ps.edges - stats.predicates[uri].edges
+	stats.predicates[uri] = ps
+	if uri != RDF_TYPE {
+		return nil
+	}
+	stats.classes = make(map[turtle.URI]int, len(pred.Objects))
+	for classHash, instances := range pred.Objects {
+		var hash Key
+		hash.FromSlice([]byte(classHash))
+		class, err := getURI(hash)
+		if err != nil {
+			return err
+		}
+		stats.classes[class] = len(instances)
+	}
+	return nil
+}
+
+// gathers the statistics of the graph from the predicate index
+func (db *DB) loadStats() error {
+	stats := newGraphStats()
+	getURI := func(hash Key) (turtle.URI, error) {
+		uri, err := db.pkDB.Get(hash[:], nil)
+		if err != nil {
+			return turtle.URI{}, errors.Wrapf(err, "Could not get URI for %s", hash)
+		}
+		return turtle.ParseURI(string(uri)), nil
+	}
+
+	iter := db.predDB.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		var hash Key
+		hash.FromSlice(iter.Key())
+		pred := NewPredicateEntity()
+		if _, err := pred.UnmarshalMsg(iter.Value()); err != nil {
+			return errors.Wrapf(err, "Could not decode predicate %s", hash)
+		}
+		uri, err := getURI(hash)
+		if err != nil {
+			return err
+		}
+		if err := stats.setPredicate(uri, pred, getURI); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "Could not read predicate index")
+	}
+
+	entities := db.graphDB.NewIterator(nil, nil)
+	defer entities.Release()
+	for entities.Next() {
+		entity := NewEntity()
+		if _, err := entity.UnmarshalMsg(entities.Value()); err != nil {
+			return errors.Wrap(err, "Could not decode entity")
+		}
+		if entity.hasEdges() {
+			stats.entities++
+		}
+	}
+	if err := entities.Error(); err != nil {
+		return errors.Wrap(err, "Could not read graph index")
+	}
+
+	db.statsLock.Lock()
+	db.stats = stats
+	db.statsLock.Unlock()
+	return nil
+}
+
+// returns the current statistics of the graph. They must not be changed
+func (db *DB) statistics() *graphStats {
+	db.statsLock.RLock()
+	defer db.statsLock.RUnlock()
+	return db.stats
+}
+
+// estimates the number of rows produced by the terms of a query from the
+// statistics of the graph
+type costModel struct {
+	stats *graphStats
+	// returns the relationship declared as the owl:inverseOf a predicate
+	inverse func(turtle.URI) (turtle.URI, bool)
+}
+
+func (db *DB) costModel() *costModel {
+	return &costModel{
+		stats: db.statistics(),
+		inverse: func(predicate turtle.URI) (inverse turtle.URI, found bool) {
+			db.relLock.RLock()
+			inverse, found = db.relationships[predicate]
+			db.relLock.RUnlock()
+			return
+		},
+	}
+}
+
+// how a path joins entities: the number of pairs it joins, and the average
+// number of entities it reaches from a subject (fanOut) or an object (fanIn)
+type pathEstimate struct {
+	pairs, fanOut, fanIn float64
+}
+
+func (cm *costModel) estimatePath(path []sparql.PathPattern) pathEstimate {
+	var est pathEstimate
+	for idx, pattern := range path {
+		step := cm.estimatePattern(pattern)
+		if idx == 0 {
+			est = step
+			continue
+		}
+		est.pairs *= step.fanOut
+		est.fanOut *= step.fanOut
+		est.fanIn *= step.fanIn
+	}
+	return est
+}
+
+func (cm *costModel) estimatePattern(pattern sparql.PathPattern) pathEstimate {
+	var est pathEstimate
+	switch {
+	case pattern.IsGroup():
+		for _, alt := range pattern.Alternatives {
+			altEst := cm.estimatePath(alt)
+			est.pairs += altEst.pairs
+			est.fanOut += altEst.fanOut
+			est.fanIn += altEst.fanIn
+		}
+	case pattern.Predicate.IsVariable():
+		est.pairs = float64(cm.stats.edges)
+		if cm.stats.entities > 0 {
+			est.fanOut = est.pairs / float64(cm.stats.entities)
+			est.fanIn = est.fanOut
+		}
+	default:
+		ps := cm.stats.predicates[pattern.Predicate]
+		est = pathEstimate{pairs: float64(ps.edges), fanOut: ps.fanOut(), fanIn: ps.fanIn()}
+	}
+
+	// ^p is p followed from object to subject, which is the declared inverse
+	// of p, if there is one
+	if pattern.Inverse {
+		inverse, found := cm.inverse(pattern.Predicate)
+		if ps, known := cm.stats.predicates[inverse]; found && known && !pattern.IsGroup() {
+			est = pathEstimate{pairs: float64(ps.edges), fanOut: ps.fanOut(), fanIn: ps.fanIn()}
+		} else {
+			est.fanOut, est.fanIn = est.fanIn, est.fanOut
+		}
+	}
+
+	if pattern.Pattern == sparql.PATTERN_SINGLE {
+		return est
+	}
+	// a repetition reaches the entities of each number of steps between its
+	// bounds
+	min, max := pattern.Bounds()
+	if max < 0 || max > repetitionDepth {
+		max = repetitionDepth
+	}
+	var reach = pathEstimate{}
+	if min == 0 {
+		reach = pathEstimate{pairs: float64(cm.stats.entities), fanOut: 1, fanIn: 1}
+	}
+	for steps := 1; steps <= max; steps++ {
+		if steps < min {
+			continue
+		}
+		reach.pairs += est.pairs * math.Pow(est.fanOut, float64(steps-1))
+		reach.fanOut += math.Pow(est.fanOut, float64(steps))
+		reach.fanIn += math.Pow(est.fanIn, float64(steps))
+	}
+	return reach
+}
+
+// estimates the number of rows the term produces for each row of the
+// relation it is joined with, given which of its variables already have
+// values. A term whose subject and object both have values only keeps the
+// fraction of rows whose subject and object are joined by its path
+func (cm *costModel) termCost(term *queryTerm, bound func(string) bool) float64 {
+	var (
+		est           = cm.estimatePath(term.Predicates)
+		subjectFixed  = !term.Subject.IsVariable() || bound(term.Subject.String())
+		objectFixed   = !term.Object.IsVariable() || bound(term.Object.String())
+		predicateType = len(term.Predicates) == 1 && term.Predicates[0].IsSimple() && term.Predicates[0].Predicate == RDF_TYPE
+	)
+	switch {
+	case subjectFixed && objectFixed:
+		if cm.stats.entities == 0 {
+			return 0
+		}
+		return math.Min(1, est.fanOut/float64(cm.stats.entities))
+	case subjectFixed:
+		return est.fanOut
+	case objectFixed && predicateType && !term.Object.IsVariable():
+		return float64(cm.stats.classes[term.Object])
+	case objectFixed:
+		return est.fanIn
+	}
+	return est.pairs
+}
+
+// returns the statistics of the graph as they will be once the transaction
+// is committed
+func (tx *transaction) statsAfter() (*graphStats, error) {
+	stats := tx.db.statistics().copy()
+	for hash, pred := range tx.predbatch {
+		uri, err := tx.getURI(hash)
+		if err != nil {
+			return nil, err
+		}
+		if err := stats.setPredicate(uri, pred, tx.getURI); err != nil {
+			return nil, err
+		}
+	}
+	stats.entities += tx.connectedEntities
+	return stats, nil
+}
@@ -21,6 +21,10 @@ var (
 		Namespace: OWL_NAMESPACE,
 		Value:     "inverseOf",
 	}
+	RDF_TYPE = turtle.URI{
+		Namespace: RDF_NAMESPACE,
+		Value:     "type",
+	}
 )
 
 // wrapper around the internal k/v store transaction
@@ -30,12 +34,15 @@ type transaction struct {
 	graph                *leveldb.Transaction
 	ext                  *leveldb.Transaction
 	pred                 *leveldb.Transaction
+	link                 *leveldb.Transaction
 	predbatch            map[Key]*PredicateEntity
 	triplesAdded         int
+	connectedEntities    int
 	hashes               map[turtle.URI]Key
 	inverseRelationships map[Key]Key
 	t                    *traversal
 	cache                *dbcache
+	db                   *DB
 }
 
 func (db *DB) openTransaction() (tx *transaction, err error) {
@@ -44,6 +51,7 @@ func (db *DB) openTransaction() (tx *transaction, err error) {
 		inverseRelationships: make(map[Key]Key),
 		predbatch:            make(map[Key]*PredicateEntity),
 		cache:                db.cache,
+		db:                   db,
 	}
 	t := &traversal{under: tx}
 	tx.t = t
@@ -64,6 +72,9 @@ func (db *DB) openTransaction() (tx *transaction, err error) {
 			if tx.pred != nil {
 				tx.pred.Discard()
 			}
+			if tx.link != nil {
+				tx.link.Discard()
+			}
 			return nil, err
 		} else {
 			return ltx, err
@@ -84,6 +95,9 @@ func (db *DB) openTransaction() (tx *transaction, err error) {
 	if tx.pred, err = getTransaction(db.predDB); err != nil {
 		return
 	}
+	if tx.link, err = getTransaction(db.linkDB); err != nil {
+		return
+	}
 	return
 }
 
@@ -93,6 +107,7 @@ func (tx *transaction) discard() {
 	tx.graph.Discard()
 	tx.ext.Discard()
 	tx.pred.Discard()
+	tx.link.Discard()
 }
 
 func (tx *transaction) commit() error {
@@ -116,6 +131,10 @@ func (tx *transaction) commit() error {
 		tx.discard()
 		return err
 	}
+	if err := tx.link.Commit(); err != nil {
+		tx.discard()
+		return err
+	}
 	return nil
 }
 
@@ -131,7 +150,41 @@ func (tx *transaction) done() error {
 	if err := tx.pred.Write(b, nil); err != nil {
 		return err
 	}
-	return tx.commit()
+	relationships := tx.relationshipURIs()
+	stats, err := tx.statsAfter()
+	if err != nil {
+		return err
+	}
+	if err := tx.commit(); err != nil {
+		return err
+	}
+	tx.db.relLock.Lock()
+	for forward, reverse := range relationships {
+		tx.db.relationships[forward] = reverse
+	}
+	tx.db.relLock.Unlock()
+	tx.db.statsLock.Lock()
+	tx.db.stats = stats
+	tx.db.statsLock.Unlock()
+	tx.db.nextGeneration()
+	return nil
+}
+
+// returns the inverseOf relationships found by the transaction
+func (tx *transaction) relationshipURIs() map[turtle.URI]turtle.URI {
+	relationships := make(map[turtle.URI]turtle.URI, len(tx.inverseRelationships))
+	for forwardHash, reverseHash := range tx.inverseRelationships {
+		forward, err := tx.getURI(forwardHash)
+		if err != nil {
+			continue
+		}
+		reverse, err := tx.getURI(reverseHash)
+		if err != nil {
+			continue
+		}
+		relationships[forward] = reverse
+	}
+	return relationships
 }
 
 func (tx *transaction) getHash(uri turtle.URI) (Key, error) {
@@ -159,6 +212,17 @@ func (tx *transaction) getURI(hash Key) (turtle.URI, error) {
 	return uri, nil
 }
 
+// counts an entity that gained its first edge or lost its last one, given
+// whether it had edges before the change
+func (tx *transaction) countConnected(ent *Entity, wasConnected bool) {
+	switch connected := ent.hasEdges(); {
+	case connected && !wasConnected:
+		tx.connectedEntities++
+	case !connected && wasConnected:
+		tx.connectedEntities--
+	}
+}
+
 func (tx *transaction) putEntity(ent *Entity) error {
 	bytes, err := ent.MarshalMsg(nil)
 	if err != nil {
@@ -188,12 +252,16 @@ func (tx *transaction) getEntityByHash(hash Key) (*Entity, error) {
 	return entity, nil
 }
 
+// entities that are not in the extended index yet get an empty index
 func (tx *transaction) getExtendedIndexByHash(hash Key) (*EntityExtendedIndex, error) {
+	ent := NewEntityExtendedIndex()
+	ent.PK = hash
 	bytes, err := tx.ext.Get(hash[:], nil)
-	if err != nil {
+	if err == leveldb.ErrNotFound {
+		return ent, nil
+	} else if err != nil {
 		return nil, err
 	}
-	ent := NewEntityExtendedIndex()
 	_, err = ent.UnmarshalMsg(bytes)
 	return ent, err
 }
@@ -271,23 +339,7 @@ func (tx *transaction) addTriples(dataset turtle.DataSet) error {
 
 	// pull out all of the inverse edges from the database and add to inverseRelationships
 	reverseEdgeFindStart := time.Now()
-	var predicatesAdded int
-	pred, err := tx.getPredicateByURI(INVERSEOF)
-	if err != nil && err != leveldb.ErrNotFound {
-		logrus.WithError(err).Error("Could not load INVERSEOF pred")
-	} else if err == nil {
-		for subject, objectMap := range pred.Subjects {
-			for object := range objectMap {
-				var sh, oh Key
-				sh.FromSlice([]byte(subject))
-				oh.FromSlice([]byte(object))
-				tx.inverseRelationships[sh] = oh
-				tx.inverseRelationships[oh] = sh
-				predicatesAdded += 1
-			}
-		}
-	}
-
+	predicatesAdded := tx.loadInverseRelationships()
 	reverseEdgeFindEnd := time.Now()
 
 	// add the inverse edges to the graph index
@@ -340,12 +392,16 @@ func (tx *transaction) addTriples(dataset turtle.DataSet) error {
 	extendedBuildStart := time.Now()
 	// for all *new* predicates, roll the edges forward for all entities in the transaction.
 	for predicateHash := range newPredicates {
-		tx.rollupPredicate(predicateHash)
+		if err := tx.rollupPredicate(predicateHash); err != nil {
+			return errors.Wrap(err, "Could not build extended index")
+		}
 		if reversePredicate, found := tx.inverseRelationships[predicateHash]; found {
 			//fmt.Println(reversePredicate)
 			// for all entities
 			// add the roll-forward index
-			tx.rollupPredicate(reversePredicate)
+			if err := tx.rollupPredicate(reversePredicate); err != nil {
+				return errors.Wrap(err, "Could not build extended index")
+			}
 		}
 	}
 	extendedBuildEnd := time.Now()
@@ -366,6 +422,174 @@ func (tx *transaction) addTriples(dataset turtle.DataSet) error {
 	return nil
 }
 
+// adds the inverseOf relationships stored in the database to
+// tx.inverseRelationships. Returns the number of relationships found
+func (tx *transaction) loadInverseRelationships() int {
+	var numRelationships int
+	pred, err := tx.getPredicateByURI(INVERSEOF)
+	if err != nil && err != leveldb.ErrNotFound {
+		logrus.WithError(err).Error("Could not load INVERSEOF pred")
+	} else if err == nil {
+		for subject, objectMap := range pred.Subjects {
+			for object := range objectMap {
+				var sh, oh Key
+				sh.FromSlice([]byte(subject))
+				oh.FromSlice([]byte(object))
+				tx.inverseRelationships[sh] = oh
+				tx.inverseRelationships[oh] = sh
+				numRelationships += 1
+			}
+		}
+	}
+	return numRelationships
+}
+
+// removes the triples from the graph: the edges of the subject and object
+// entities, the predicate index and, if the predicate has an inverse, the
+// inverse edges. Because a relationship and its inverse are stored as one,
+// deleting either direction deletes both. The extended index of the affected
+// predicates is rebuilt. Entities stay in the database even if they have no
+// edges left. Returns the number of triples that were removed
+func (tx *transaction) deleteTriples(dataset turtle.DataSet) (int, error) {
+	tx.loadInverseRelationships()
+
+	var removed int
+	changedPredicates := make(map[Key]struct{})
+	for _, triple := range dataset.Triples {
+		subjectHash, foundSubject, err := tx.existingHash(triple.Subject)
+		if err != nil {
+			return removed, err
+		}
+		predicateHash, foundPredicate, err := tx.existingHash(triple.Predicate)
+		if err != nil {
+			return removed, err
+		}
+		objectHash, foundObject, err := tx.existingHash(triple.Object)
+		if err != nil {
+			return removed, err
+		}
+		if !foundSubject || !foundPredicate || !foundObject {
+			continue
+		}
+		pred, err := tx.getPredicateByHash(predicateHash)
+		if err != nil {
+			return removed, errors.Wrapf(err, "Could not load predicate %s", triple.Predicate)
+		}
+		if !pred.HasSubjectObject(subjectHash, objectHash) {
+			continue
+		}
+
+		if err := tx.removeEdge(subjectHash, predicateHash, objectHash); err != nil {
+			return removed, errors.Wrapf(err, "Could not delete triple (%s)", triple)
+		}
+		changedPredicates[predicateHash] = struct{}{}
+		if reversePredicate, found := tx.inverseRelationships[predicateHash]; found {
+			if err := tx.removeEdge(objectHash, reversePredicate, subjectHash); err != nil {
+				return removed, errors.Wrapf(err, "Could not delete inverse of triple (%s)", triple)
+			}
+			changedPredicates[reversePredicate] = struct{}{}
+		}
+		removed += 1
+	}
+
+	if len(changedPredicates) == 0 {
+		return removed, nil
+	}
+	// the "+" closures of the changed predicates can't be patched
+	// incrementally, so they are rebuilt
+	if err := tx.clearPlusEdges(changedPredicates); err != nil {
+		return removed, err
+	}
+	for predicateHash := range changedPredicates {
+		if err := tx.rollupPredicate(predicateHash); err != nil {
+			return removed, errors.Wrap(err, "Could not rebuild extended index")
+		}
+	}
+	return removed, nil
+}
+
+// returns the hash of the URI and whether or not it is in the database
+func (tx *transaction) existingHash(uri turtle.URI) (hash Key, found bool, err error) {
+	val, err := tx.entity.Get(uri.Bytes(), nil)
+	if err == leveldb.ErrNotFound {
+		return hash, false, nil
+	} else if err != nil {
+		return hash, false, errors.Wrapf(err, "Could not check existence of %s", uri)
+	}
+	copy(hash[:], val)
+	return hash, true, nil
+}
+
+// removes the edge from the graph index and the predicate index
+func (tx *transaction) removeEdge(subjectHash, predicateHash, objectHash Key) error {
+	pred, err := tx.getPredicateByHash(predicateHash)
+	if err != nil {
+		return err
+	}
+	if pred.RemoveSubjectObject(subjectHash, objectHash) {
+		tx.predbatch[pred.PK] = pred
+	}
+
+	subject, err := tx.getEntityByHash(subjectHash)
+	if err != nil {
+		return err
+	}
+	if subject.RemoveOutEdge(predicateHash, objectHash) {
+		tx.countConnected(subject, true)
+		if err = tx.putEntity(subject); err != nil {
+			return err
+		}
+	}
+	object, err := tx.getEntityByHash(objectHash)
+	if err != nil {
+		return err
+	}
+	if object.RemoveInEdge(predicateHash, subjectHash) {
+		tx.countConnected(object, true)
+		if err = tx.putEntity(object); err != nil {
+			return err
+		}
+	}
+
+	tx.cache.evict(subjectHash)
+	tx.cache.evict(objectHash)
+	tx.cache.evict(predicateHash)
+	return nil
+}
+
+// removes the "+" edges of the given predicates from every entry in the
+// extended index
+func (tx *transaction) clearPlusEdges(predicates map[Key]struct{}) error {
+	var changed []*EntityExtendedIndex
+	iter := tx.ext.NewIterator(nil, nil)
+	for iter.Next() {
+		index := NewEntityExtendedIndex()
+		if _, err := index.UnmarshalMsg(iter.Value()); err != nil {
+			iter.Release()
+			return errors.Wrap(err, "Error deserializing extended index from transaction")
+		}
+		modified := false
+		for predicateHash := range predicates {
+			if index.RemovePlusEdges(predicateHash) {
+				modified = true
+			}
+		}
+		if modified {
+			changed = append(changed, index)
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "Could not iterate extended index")
+	}
+	for _, index := range changed {
+		if err := tx.saveExtendedIndex(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // things to do:
 // - check for inverseOf relationships (do as a second pass?) and mark these for reverse edges
 // - track the namespaces we find
@@ -413,12 +637,15 @@ func (tx *transaction) addTriple(triple turtle.Triple) error {
 	if err != nil {
 		return err
 	}
+	subjectConnected, objectConnected := subject.hasEdges(), object.hasEdges()
 	if subject.AddOutEdge(predicateHash, object.PK) {
+		tx.countConnected(subject, subjectConnected)
 		if err = tx.putEntity(subject); err != nil {
 			return err
 		}
 	}
 	if object.AddInEdge(predicateHash, subject.PK) {
+		tx.countConnected(object, objectConnected)
 		if err = tx.putEntity(object); err != nil {
 			return err
 		}
@@ -524,13 +751,21 @@ func (tx *transaction) rollupPredicate(predicateHash Key) error {
 		}
 
 		stack := list.New()
-		tx.t.followPathFromSubject(subject, results, stack, forwardPath)
+		if err := tx.t.followPathFromSubject(subject, results, stack, forwardPath); err != nil {
+			return err
+		}
 		for results.Len() > 0 {
-			objectIndex, err := tx.getExtendedIndexByHash(results.Max())
+			objectHash := results.DeleteMax()
+			objectIndex, err := tx.getExtendedIndexByHash(objectHash)
 			if err != nil {
 				return err
 			}
-			subjectIndex.AddOutPlusEdge(predicateHash, results.DeleteMax())
+			// on a cycle the subject reaches itself; use the same index so
+			// that saving the subject below does not lose the edge
+			if objectHash == subjectHash {
+				objectIndex = subjectIndex
+			}
+			subjectIndex.AddOutPlusEdge(predicateHash, objectHash)
 			objectIndex.AddInPlusEdge(predicateHash, subjectHash)
 			if err := tx.saveExtendedIndex(objectIndex); err != nil {
 				return err
@@ -563,13 +798,19 @@ func (tx *transaction) rollupPredicate(predicateHash Key) error {
 		}
 
 		stack := list.New()
-		tx.t.followPathFromObject(object, results, stack, forwardPath)
+		if err := tx.t.followPathFromObject(object, results, stack, forwardPath); err != nil {
+			return err
+		}
 		for results.Len() > 0 {
-			subjectIndex, err := tx.getExtendedIndexByHash(results.Max())
+			subjectHash := results.DeleteMax()
+			subjectIndex, err := tx.getExtendedIndexByHash(subjectHash)
 			if err != nil {
 				return err
 			}
-			objectIndex.AddInPlusEdge(predicateHash, results.DeleteMax())
+			if subjectHash == objectHash {
+				subjectIndex = objectIndex
+			}
+			objectIndex.AddInPlusEdge(predicateHash, subjectHash)
 			subjectIndex.AddOutPlusEdge(predicateHash, objectHash)
 			if err := tx.saveExtendedIndex(subjectIndex); err != nil {
 				return err
@@ -2,6 +2,7 @@ package db
 
 import (
 	"container/list"
+	"context"
 	sparql "github.com/gtfierro/hod/lang/ast"
 	"github.com/gtfierro/hod/turtle"
 	"github.com/pkg/errors"
@@ -26,6 +27,16 @@ type traversable interface {
 type traversal struct {
 	under traversable
 	cache *dbcache
+	// values computed during a query (e.g. by BIND) that are not in the graph
+	computed *computedValues
+	// the context of the query; searches stop once it is done
+	goctx context.Context
+}
+
+// returns a QueryCanceledError if the query that the traversal is for has
+// been canceled
+func (t *traversal) canceled() error {
+	return canceled(t.goctx)
 }
 
 func (t *traversal) getHash(uri turtle.URI) (Key, error) {
@@ -44,6 +55,9 @@ func (t *traversal) getHash(uri turtle.URI) (Key, error) {
 }
 
 func (t *traversal) getURI(hash Key) (turtle.URI, error) {
+	if isComputedKey(hash) {
+		return t.computed.getURI(hash)
+	}
 	if t.cache == nil {
 		return t.under.getURI(hash)
 	}
@@ -58,6 +72,16 @@ func (t *traversal) getURI(hash Key) (turtle.URI, error) {
 	}
 }
 
+// returns the key of the value: its hash if it is in the graph, and
+// otherwise a key that is only valid for this traversal
+func (t *traversal) getValueKey(uri turtle.URI) (Key, error) {
+	hash, err := t.getHash(uri)
+	if errors.Cause(err) == leveldb.ErrNotFound && t.computed != nil {
+		return t.computed.getKey(uri), nil
+	}
+	return hash, err
+}
+
 func (t *traversal) getEntityByURI(uri turtle.URI) (*Entity, error) {
 	if t.cache == nil {
 		return t.under.getEntityByURI(uri)
@@ -136,6 +160,29 @@ func (t *traversal) getPredicateByHash(hash Key) (*PredicateEntity, error) {
 	}
 }
 
+// returns the edges of the predicate as sorted adjacency lists, which are
+// cached along with the predicate
+func (t *traversal) getPredicateEdges(uri turtle.URI) (*predicateEdges, error) {
+	hash, err := t.getHash(uri)
+	if err != nil {
+		return nil, err
+	}
+	if t.cache != nil {
+		if edges, found := t.cache.getPredicateEdgesByHash(hash); found {
+			return edges, nil
+		}
+	}
+	pred, err := t.getPredicateByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	edges := newPredicateEdges(pred)
+	if t.cache != nil {
+		t.cache.setPredicateEdgesByHash(hash, edges)
+	}
+	return edges, nil
+}
+
 // takes the inverse of every relationship. If no inverse exists, returns nil
 func (t *traversal) reversePathPattern(path []sparql.PathPattern) []sparql.PathPattern {
 	var reverse = make([]sparql.PathPattern, len(path))
@@ -150,6 +197,306 @@ func (t *traversal) reversePathPattern(path []sparql.PathPattern) []sparql.PathP
 	return reversePath(reverse)
 }
 
+// replaces inverse predicates (^p) with the relationship declared as their
+// owl:inverseOf, so that they can use the same indexes as any other predicate.
+// Inverses without a declared relationship are followed along the InEdges
+func (t *traversal) normalizePath(path []sparql.PathPattern) []sparql.PathPattern {
+	simple := true
+	for _, pp := range path {
+		simple = simple && pp.IsSimple()
+	}
+	if simple {
+		return path
+	}
+	normalized := make([]sparql.PathPattern, len(path))
+	for idx, pp := range path {
+		normalized[idx] = t.normalizePathPattern(pp)
+	}
+	return normalized
+}
+
+func (t *traversal) normalizePathPattern(pp sparql.PathPattern) sparql.PathPattern {
+	if pp.IsGroup() {
+		alts := make([][]sparql.PathPattern, len(pp.Alternatives))
+		for idx, alt := range pp.Alternatives {
+			alts[idx] = t.normalizePath(alt)
+		}
+		pp.Alternatives = alts
+	} else if pp.Inverse {
+		if inverse, found := t.under.getReverseRelationship(pp.Predicate); found {
+			pp.Predicate = inverse
+			pp.Inverse = false
+		}
+	}
+	return pp
+}
+
+// follows a path pattern that is not a simple predicate (an alternation, an
+// inverse or a bounded repetition) from the given entity, placing the results
+// in the keymap. If [forward] is false, the pattern is followed from object to
+// subject. This does not use the "+" edges of the extended index
+func (t *traversal) followPattern(entity *Entity, results *keymap, pattern sparql.PathPattern, forward bool) error {
+	start := newKeymap()
+	start.Add(entity.PK)
+	reachable, err := t.reachableByPattern(start, pattern, forward)
+	if err != nil {
+		return err
+	}
+	reachable.Iter(results.Add)
+	return nil
+}
+
+// returns the entities reachable from any of the entities in [from] by the
+// path pattern
+func (t *traversal) reachableByPattern(from *keymap, pattern sparql.PathPattern, forward bool) (*keymap, error) {
+	// ^p follows p in the other direction
+	forward = forward != pattern.Inverse
+	step := func(from *keymap) (*keymap, error) {
+		if !pattern.IsGroup() {
+			return t.reachableByPredicate(from, pattern.Predicate, forward)
+		}
+		next := newKeymap()
+		for _, alt := range pattern.Alternatives {
+			reached, err := t.reachableBySequence(from, alt, forward)
+			if err != nil {
+				return nil, err
+			}
+			reached.Iter(next.Add)
+		}
+		return next, nil
+	}
+
+	if pattern.Pattern == sparql.PATTERN_SINGLE {
+		return step(from)
+	}
+	min, max := pattern.Bounds()
+	results := newKeymap()
+	if min == 0 {
+		from.Iter(results.Add)
+	}
+	// breadth-first search, one round per repetition. Until the minimum
+	// number of repetitions is reached, the frontier holds everything reachable
+	// by exactly that many steps. After that, an entity already in the results
+	// has had its successors found in an earlier round, so only new entities are
+	// expanded. This also bounds the search when there is no maximum
+	frontier := from
+	for depth := 1; frontier.Len() > 0 && (max < 0 || depth <= max); depth++ {
+		if err := t.canceled(); err != nil {
+			return nil, err
+		}
+		next, err := step(frontier)
+		if err != nil {
+			return nil, err
+		}
+		if depth < min {
+			frontier = next
+			continue
+		}
+		frontier = newKeymap()
+		next.Iter(func(key Key) {
+			if !results.Has(key) {
+				results.Add(key)
+				frontier.Add(key)
+			}
+		})
+	}
+	return results, nil
+}
+
+// returns the entities reachable by the sequence of path patterns
+func (t *traversal) reachableBySequence(from *keymap, path []sparql.PathPattern, forward bool) (*keymap, error) {
+	if !forward {
+		path = reversePath(path)
+	}
+	var err error
+	for _, pattern := range path {
+		if from, err = t.reachableByPattern(from, pattern, forward); err != nil {
+			return nil, err
+		}
+	}
+	return from, nil
+}
+
+// returns the entities one edge away from the entities in [from]. Predicates
+// that are not in the graph have no edges
+func (t *traversal) reachableByPredicate(from *keymap, predicate turtle.URI, forward bool) (*keymap, error) {
+	next := newKeymap()
+	predHash, err := t.getHash(predicate)
+	if errors.Cause(err) == leveldb.ErrNotFound {
+		return next, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Not found: %v", predicate)
+	}
+	from.Iter(func(key Key) {
+		if err != nil {
+			return
+		}
+		entity, e := t.getEntityByHash(key)
+		if errors.Cause(e) == leveldb.ErrNotFound {
+			return
+		} else if e != nil {
+			err = e
+			return
+		}
+		edges := entity.OutEdges
+		if !forward {
+			edges = entity.InEdges
+		}
+		for _, entityHash := range edges[string(predHash[:])] {
+			next.Add(entityHash)
+		}
+	})
+	return next, err
+}
+
+// a step of a route found by following a path. Routes found by the same
+// search share their prefixes, so each step only points back to the step it
+// was reached from
+type routeStep struct {
+	entity Key
+	// the predicate followed to reach the entity; unset on the first step
+	predicate Key
+	prev      *routeStep
+}
+
+// returns the entities and predicates of the route that ends at the step, in
+// the order they were followed
+func (t *traversal) routeURIs(step *routeStep) ([]turtle.URI, error) {
+	var route []turtle.URI
+	for ; step != nil; step = step.prev {
+		entity, err := t.getURI(step.entity)
+		if err != nil {
+			return nil, err
+		}
+		route = append(route, entity)
+		if step.prev == nil {
+			break
+		}
+		predicate, err := t.getURI(step.predicate)
+		if err != nil {
+			return nil, err
+		}
+		route = append(route, predicate)
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route, nil
+}
+
+// the same search as reachableByPattern, but it keeps the step by which each
+// entity was first reached so that the route to it can be recovered. Steps are
+// kept in the order they were found, so the shortest route wins and the result
+// does not depend on map order
+func (t *traversal) routesByPattern(from []*routeStep, pattern sparql.PathPattern, forward bool) ([]*routeStep, error) {
+	forward = forward != pattern.Inverse
+	step := func(from []*routeStep) ([]*routeStep, error) {
+		if !pattern.IsGroup() {
+			return t.routesByPredicate(from, pattern.Predicate, forward)
+		}
+		var (
+			next []*routeStep
+			seen = make(map[Key]bool)
+		)
+		for _, alt := range pattern.Alternatives {
+			reached, err := t.routesBySequence(from, alt, forward)
+			if err != nil {
+				return nil, err
+			}
+			for _, route := range reached {
+				if !seen[route.entity] {
+					seen[route.entity] = true
+					next = append(next, route)
+				}
+			}
+		}
+		return next, nil
+	}
+
+	if pattern.Pattern == sparql.PATTERN_SINGLE {
+		return step(from)
+	}
+	var (
+		min, max = pattern.Bounds()
+		results  []*routeStep
+		found    = make(map[Key]bool)
+	)
+	if min == 0 {
+		for _, route := range from {
+			found[route.entity] = true
+			results = append(results, route)
+		}
+	}
+	frontier := from
+	for depth := 1; len(frontier) > 0 && (max < 0 || depth <= max); depth++ {
+		if err := t.canceled(); err != nil {
+			return nil, err
+		}
+		next, err := step(frontier)
+		if err != nil {
+			return nil, err
+		}
+		if depth < min {
+			frontier = next
+			continue
+		}
+		frontier = nil
+		for _, route := range next {
+			if !found[route.entity] {
+				found[route.entity] = true
+				results = append(results, route)
+				frontier = append(frontier, route)
+			}
+		}
+	}
+	return results, nil
+}
+
+func (t *traversal) routesBySequence(from []*routeStep, path []sparql.PathPattern, forward bool) ([]*routeStep, error) {
+	if !forward {
+		path = reversePath(path)
+	}
+	var err error
+	for _, pattern := range path {
+		if from, err = t.routesByPattern(from, pattern, forward); err != nil {
+			return nil, err
+		}
+	}
+	return from, nil
+}
+
+func (t *traversal) routesByPredicate(from []*routeStep, predicate turtle.URI, forward bool) ([]*routeStep, error) {
+	var (
+		next []*routeStep
+		seen = make(map[Key]bool)
+	)
+	predHash, err := t.getHash(predicate)
+	if errors.Cause(err) == leveldb.ErrNotFound {
+		return next, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Not found: %v", predicate)
+	}
+	for _, route := range from {
+		entity, err := t.getEntityByHash(route.entity)
+		if errors.Cause(err) == leveldb.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		edges := entity.OutEdges
+		if !forward {
+			edges = entity.InEdges
+		}
+		for _, entityHash := range edges[string(predHash[:])] {
+			if !seen[entityHash] {
+				seen[entityHash] = true
+				next = append(next, &routeStep{entity: entityHash, predicate: predHash, prev: route})
+			}
+		}
+	}
+	return next, nil
+}
+
 // follow the pattern from the given object's InEdges, placing the results in the btree
 func (t *traversal) followPathFromObject(object *Entity, results *keymap, searchstack *list.List, pattern sparql.PathPattern) error {
 	stack := list.New()
@@ -171,6 +518,9 @@ func (t *traversal) followPathFromObject(object *Entity, results *keymap, search
 	defer traversedBTreePool.Put(traversed)
 
 	for stack.Len() > 0 {
+		if err := t.canceled(); err != nil {
+			return err
+		}
 		entity := stack.Remove(stack.Front()).(*Entity)
 		if traversed.Has(entity.PK) {
 			continue
@@ -211,7 +561,9 @@ func (t *traversal) followPathFromObject(object *Entity, results *keymap, search
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return nil
+					// the index holds everything reachable from this entity, so
+					// it does not need to be searched further
+					continue
 				}
 			}
 			endpoints, found := entity.InEdges[string(predHash[:])]
@@ -242,7 +594,7 @@ func (t *traversal) followPathFromObject(object *Entity, results *keymap, search
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return nil
+					continue
 				}
 			}
 			edges, found := entity.InEdges[string(predHash[:])]
@@ -280,6 +632,9 @@ func (t *traversal) followPathFromSubject(subject *Entity, results *keymap, sear
 	defer traversedBTreePool.Put(traversed)
 
 	for stack.Len() > 0 {
+		if err := t.canceled(); err != nil {
+			return err
+		}
 		entity := stack.Remove(stack.Front()).(*Entity)
 		if traversed.Has(entity.PK) {
 			continue
@@ -324,7 +679,7 @@ func (t *traversal) followPathFromSubject(subject *Entity, results *keymap, sear
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return nil
+					continue
 				}
 			}
 
@@ -356,7 +711,7 @@ func (t *traversal) followPathFromSubject(subject *Entity, results *keymap, sear
 					for _, entityHash := range endpoints {
 						results.Add(entityHash)
 					}
-					return nil
+					continue
 				}
 			}
 			edges, found := entity.OutEdges[string(predHash[:])]
@@ -400,7 +755,7 @@ func (t *traversal) getSubjectFromPredObject(objectHash Key, path []sparql.PathP
 	var traversed = traversedBTreePool.Get()
 	defer traversedBTreePool.Put(traversed)
 	// reverse the path because we are getting from the object
-	path = reversePath(path)
+	path = reversePath(t.normalizePath(path))
 
 	for idx, segment := range path {
 		// clear out the tree
@@ -409,6 +764,9 @@ func (t *traversal) getSubjectFromPredObject(objectHash Key, path []sparql.PathP
 		}
 		reachable := newKeymap()
 		for stack.Len() > 0 {
+			if err := t.canceled(); err != nil {
+				return nil, err
+			}
 			entity := stack.Remove(stack.Front()).(*Entity)
 			// if we have already traversed this entity, skip it
 			if traversed.Has(entity.PK) {
@@ -416,7 +774,11 @@ func (t *traversal) getSubjectFromPredObject(objectHash Key, path []sparql.PathP
 			}
 			// mark this entity as traversed
 			traversed.ReplaceOrInsert(entity.PK)
-			t.followPathFromObject(entity, reachable, stack, segment)
+			if segment.IsSimple() && segment.Pattern != sparql.PATTERN_BOUNDED {
+				t.followPathFromObject(entity, reachable, stack, segment)
+			} else if err := t.followPattern(entity, reachable, segment, false); err != nil {
+				return nil, err
+			}
 		}
 
 		// if we aren't done, then we push these items onto the stack
@@ -449,6 +811,7 @@ func (t *traversal) getObjectFromSubjectPred(subjectHash Key, path []sparql.Path
 	stack.PushFront(subEntity)
 	var traversed = traversedBTreePool.Get()
 	defer traversedBTreePool.Put(traversed)
+	path = t.normalizePath(path)
 
 	// we have our starting entity; follow the first segment of the path and save everything we can reach from there.
 	// Then, from that set, search the second segment of the path, etc. We save the last reachable set
@@ -459,6 +822,11 @@ func (t *traversal) getObjectFromSubjectPred(subjectHash Key, path []sparql.Path
 		}
 		reachable := newKeymap()
 		for stack.Len() > 0 {
+			// a canceled search finds nothing; the query fails once the
+			// operation that started it returns
+			if t.canceled() != nil {
+				return newKeymap()
+			}
 			entity := stack.Remove(stack.Front()).(*Entity)
 			// if we have already traversed this entity, skip it
 			if traversed.Has(entity.PK) {
@@ -466,7 +834,12 @@ func (t *traversal) getObjectFromSubjectPred(subjectHash Key, path []sparql.Path
 			}
 			// mark this entity as traversed
 			traversed.ReplaceOrInsert(entity.PK)
-			t.followPathFromSubject(entity, reachable, stack, segment)
+			if segment.IsSimple() && segment.Pattern != sparql.PATTERN_BOUNDED {
+				t.followPathFromSubject(entity, reachable, stack, segment)
+			} else if err := t.followPattern(entity, reachable, segment, true); err != nil {
+				log.Error(err)
+				return nil
+			}
 		}
 
 		// if we aren't done, then we push these items onto the stack
@@ -488,6 +861,9 @@ func (t *traversal) getObjectFromSubjectPred(subjectHash Key, path []sparql.Path
 
 // Given a predicate, it returns pairs of (subject, object) that are connected by that relationship
 func (t *traversal) getSubjectObjectFromPred(path []sparql.PathPattern) (soPair [][]Key, err error) {
+	if len(path) > 1 || !path[0].IsSimple() || path[0].Pattern != sparql.PATTERN_SINGLE {
+		return t.getSubjectObjectFromPath(path)
+	}
 	var pe *PredicateEntity
 	pe, err = t.getPredicateByURI(path[0].Predicate)
 	if err != nil {
@@ -505,6 +881,42 @@ func (t *traversal) getSubjectObjectFromPred(path []sparql.PathPattern) (soPair
 	return soPair, nil
 }
 
+// returns the pairs of (subject, object) connected by a path that is more than
+// a single predicate. The path is followed from every entity that can start it
+func (t *traversal) getSubjectObjectFromPath(path []sparql.PathPattern) (soPair [][]Key, err error) {
+	path = t.normalizePath(path)
+	subjects := newKeymap()
+	// if the path starts by following a predicate at least once, only the
+	// subjects of that predicate can start it
+	first := path[0]
+	if min, _ := first.Bounds(); first.IsSimple() && min > 0 {
+		pe, err := t.getPredicateByURI(first.Predicate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Can't find predicate %v", first.Predicate)
+		}
+		var sh Key
+		for subject := range pe.Subjects {
+			sh.FromSlice([]byte(subject))
+			subjects.Add(sh)
+		}
+	} else if err := t.iterAllEntities(func(key Key, _ *Entity) bool {
+		subjects.Add(key)
+		return false
+	}); err != nil {
+		return nil, err
+	}
+	subjects.Iter(func(subject Key) {
+		objects := t.getObjectFromSubjectPred(subject, path)
+		if objects == nil {
+			return
+		}
+		objects.Iter(func(object Key) {
+			soPair = append(soPair, []Key{subject, object})
+		})
+	})
+	return soPair, nil
+}
+
 func (t *traversal) getPredicateFromSubjectObject(subject, object *Entity) *keymap {
 	reachable := newKeymap()
 
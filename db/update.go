@@ -0,0 +1,86 @@
+package db
+
+import (
+	"time"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
+)
+
+// applies the DELETE and INSERT templates of the query for each of the rows
+// produced by its WHERE clause. Deletions happen before insertions, and both
+// happen in the same transaction
+func (db *DB) handleUpdate(q *sparql.Query, rows []ResultMap) (queryStats, error) {
+	updateStart := time.Now()
+	var stats queryStats
+
+	deletions := db.instantiateTemplate(q.Delete.Terms, q.Prefixes, rows)
+	additions := db.instantiateTemplate(q.Insert.Terms, q.Prefixes, rows)
+	if len(deletions.Triples) == 0 && len(additions.Triples) == 0 {
+		return stats, nil
+	}
+
+	tx, err := db.openTransaction()
+	if err != nil {
+		return stats, err
+	}
+	if len(deletions.Triples) > 0 {
+		if stats.NumDeleted, err = tx.deleteTriples(deletions); err != nil {
+			tx.discard()
+			return stats, err
+		}
+	}
+	if len(additions.Triples) > 0 {
+		if err := tx.addTriples(additions); err != nil {
+			tx.discard()
+			return stats, err
+		}
+		stats.NumInserted = len(additions.Triples)
+	}
+	if err := tx.done(); err != nil {
+		tx.discard()
+		return stats, err
+	}
+	// the transaction only queues evictions for the entities it wrote, so
+	// a query right after the update could still see the cached extended
+	// indexes of their ancestors
+	db.cache.evictGraph()
+	if stats.NumDeleted > 0 {
+		if err := db.removeFromTextIndex(deletions); err != nil {
+			return stats, err
+		}
+	}
+	if err := db.buildTextIndex(additions); err != nil {
+		return stats, err
+	}
+	if err := db.saveIndexes(); err != nil {
+		return stats, err
+	}
+	stats.InsertTime = time.Since(updateStart)
+	return stats, nil
+}
+
+// fills in the variables of the template triples with the values from each
+// row, and expands the prefixes of the other terms using the prefixes of the
+// query. Triples with variables that are not bound in a row are skipped
+func (db *DB) instantiateTemplate(template []sparql.Triple, prefixes map[string]string, rows []ResultMap) turtle.DataSet {
+	var dataset turtle.DataSet
+	bind := func(uri turtle.URI, row ResultMap) (turtle.URI, bool) {
+		if !uri.IsVariable() {
+			return db.expandPrefixed(prefixes, uri), true
+		}
+		value, found := row[uri.String()]
+		return value, found
+	}
+	for _, row := range rows {
+		for _, term := range template {
+			subject, foundSubject := bind(term.Subject, row)
+			predicate, foundPredicate := bind(term.Predicates[0].Predicate, row)
+			object, foundObject := bind(term.Object, row)
+			if foundSubject && foundPredicate && foundObject {
+				dataset.AddTripleURIs(subject, predicate, object)
+			}
+		}
+	}
+	return dataset
+}
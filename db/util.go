@@ -1,13 +1,15 @@
 package db
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
-	"hash/fnv"
+	"encoding/json"
 	"sort"
 	"time"
 
 	sparql "github.com/gtfierro/hod/lang/ast"
 	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
 	"github.com/zhangxinngang/murmur"
 )
 
@@ -33,7 +35,8 @@ func hashRow(row *Row) uint32 {
 func hashRowWithPos(row *Row, positions []int) uint32 {
 	var b []byte
 	for _, pos := range positions {
-		b = append(b, row.content[pos*8:pos*8+8]...)
+		value := row.valueAt(pos)
+		b = append(b, value[:]...)
 	}
 	return murmur.Murmur3(b)
 }
@@ -122,41 +125,42 @@ func rowIsFull(row []Key) bool {
 	return true
 }
 
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 func reversePath(path []sparql.PathPattern) []sparql.PathPattern {
 	newpath := make([]sparql.PathPattern, len(path))
 	// for in-place, replace newpath with path
 	if len(newpath) == 1 {
 		return path
 	}
-	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+	for i, j := 0, len(path)-1; i <= j; i, j = i+1, j-1 {
 		newpath[i], newpath[j] = path[j], path[i]
 	}
 	return newpath
 }
 
-func hashQuery(q *sparql.Query) []byte {
-	h := fnv.New64a()
-	var selectVars = make(sort.StringSlice, len(q.Select.Vars))
-	for idx, varname := range q.Select.Vars {
-		selectVars[idx] = varname
-	}
-	for _, hv := range selectVars {
-		h.Write([]byte(hv))
-	}
-
-	var triples []string
-	q.IterTriples(func(triple sparql.Triple) sparql.Triple {
-		triples = append(triples, triple.String())
-		return triple
+// hashes the canonical form of the query, in which the triples of the WHERE
+// clause are sorted; their order does not change the results. The hash is
+// the key of the cached results, so it has to be collision resistant
+func hashQuery(q *sparql.Query) ([]byte, error) {
+	canonical := q.Copy()
+	canonical.Where.Terms = append([]sparql.Triple{}, q.Where.Terms...)
+	sort.Slice(canonical.Where.Terms, func(i, j int) bool {
+		return canonical.Where.Terms[i].String() < canonical.Where.Terms[j].String()
 	})
-
-	x := sort.StringSlice(triples)
-	x.Sort()
-	for _, hv := range x {
-		h.Write([]byte(hv))
+	encoded, err := json.Marshal(canonical)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not hash query")
 	}
-
-	return h.Sum(nil)
+	hash := sha256.Sum256(encoded)
+	return hash[:], nil
 }
 
 type queryStats struct {
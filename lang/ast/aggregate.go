@@ -0,0 +1,252 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gtfierro/hod/lang/token"
+	"github.com/pkg/errors"
+)
+
+// AggregateExpression is a call to one of the aggregate functions COUNT, SUM,
+// MIN, MAX, AVG, SAMPLE or GROUP_CONCAT. Aggregates are evaluated over the
+// groups of a query and can only be used in the SELECT, HAVING and ORDER BY
+// clauses
+type AggregateExpression struct {
+	Function string
+	Distinct bool
+	// nil for COUNT(*)
+	Arg Expression
+	// the separator of GROUP_CONCAT
+	Separator string
+}
+
+func NewAggregate(name interface{}, distinct bool, arg interface{}) (Expression, error) {
+	agg := AggregateExpression{
+		Function: strings.ToUpper(string(name.(*token.Token).Lit)),
+		Distinct: distinct,
+	}
+	if arg != nil {
+		agg.Arg = arg.(Expression)
+	}
+	return agg, nil
+}
+
+func NewGroupConcat(distinct bool, arg, separator interface{}) (Expression, error) {
+	agg := AggregateExpression{
+		Function:  "GROUP_CONCAT",
+		Distinct:  distinct,
+		Arg:       arg.(Expression),
+		Separator: " ",
+	}
+	if separator != nil {
+		sep, err := NewStringLiteral(separator)
+		if err != nil {
+			return agg, err
+		}
+		agg.Separator = sep.(LiteralExpression).Value
+	}
+	return agg, nil
+}
+
+func (e AggregateExpression) Vars() []string {
+	if e.Arg == nil {
+		return nil
+	}
+	return e.Arg.Vars()
+}
+
+func (e AggregateExpression) Transform(f func(Expression) Expression) Expression {
+	if e.Arg != nil {
+		e.Arg = e.Arg.Transform(f)
+	}
+	return f(e)
+}
+
+func (e AggregateExpression) String() string {
+	s := e.Function + "("
+	if e.Distinct {
+		s += "DISTINCT "
+	}
+	if e.Arg == nil {
+		s += "*"
+	} else {
+		s += e.Arg.String()
+	}
+	if e.Function == "GROUP_CONCAT" {
+		s += "; SEPARATOR=" + strconv.Quote(e.Separator)
+	}
+	return s + ")"
+}
+
+// Projection is an item of the SELECT clause: a variable, a variable with
+// its links (?var[key]), or an expression bound to a new variable with
+// (expr AS ?var)
+type Projection struct {
+	Var string
+	// nil for a plain variable
+	Expression Expression
+	// the links selected for the variable, if any
+	Links *LinkSelection
+}
+
+func NewProjection(expr, _var interface{}) (Projection, error) {
+	p := Projection{Var: _var.(string)}
+	if expr != nil {
+		p.Expression = expr.(Expression)
+	}
+	return p, nil
+}
+
+func NewProjectionList(p interface{}) ([]Projection, error) {
+	return []Projection{p.(Projection)}, nil
+}
+
+func AppendProjectionList(list, p interface{}) ([]Projection, error) {
+	return append(list.([]Projection), p.(Projection)), nil
+}
+
+func NewProjectionSelectClause(list interface{}) (SelectClause, error) {
+	var sc SelectClause
+	for _, p := range list.([]Projection) {
+		for _, varname := range sc.Vars {
+			if varname == p.Var {
+				return sc, errors.Errorf("Variable %s is selected more than once", p.Var)
+			}
+		}
+		sc.Vars = append(sc.Vars, p.Var)
+		if p.Expression != nil {
+			sc.Projections = append(sc.Projections, p)
+		}
+		if p.Links != nil {
+			sc.Links = append(sc.Links, *p.Links)
+		}
+	}
+	return sc, nil
+}
+
+// returns the expression bound to the variable in the SELECT clause, or nil
+func (sc SelectClause) Projection(varname string) Expression {
+	for _, p := range sc.Projections {
+		if p.Var == varname {
+			return p.Expression
+		}
+	}
+	return nil
+}
+
+// returns true if the query groups its solutions, either with GROUP BY or by
+// using aggregates
+func (q Query) IsAggregate() bool {
+	return len(q.GroupBy) > 0 || len(q.Having) > 0 || len(q.Aggregates()) > 0
+}
+
+// returns the distinct aggregates used in the SELECT, HAVING and ORDER BY
+// clauses of the query
+func (q Query) Aggregates() []AggregateExpression {
+	var aggs []AggregateExpression
+	seen := make(map[string]bool)
+	collect := func(expr Expression) {
+		expr.Transform(func(e Expression) Expression {
+			if agg, ok := e.(AggregateExpression); ok && !seen[agg.String()] {
+				seen[agg.String()] = true
+				aggs = append(aggs, agg)
+			}
+			return e
+		})
+	}
+	for _, p := range q.Select.Projections {
+		collect(p.Expression)
+	}
+	for _, expr := range q.Having {
+		collect(expr)
+	}
+	for _, cond := range q.OrderBy {
+		collect(cond.Expression)
+	}
+	return aggs
+}
+
+// returns true if the expression uses an aggregate
+func ContainsAggregate(expr Expression) bool {
+	found := false
+	expr.Transform(func(e Expression) Expression {
+		if _, ok := e.(AggregateExpression); ok {
+			found = true
+		}
+		return e
+	})
+	return found
+}
+
+// checks that aggregates are only used where they can be evaluated and that
+// grouped queries only select the variables they group by
+func (q Query) checkAggregates() error {
+	var err error
+	q.IterFilters(func(filter Filter) Filter {
+		if ContainsAggregate(filter.Expression) {
+			err = errors.Errorf("Aggregate in %s is not allowed in FILTER", filter)
+		}
+		return filter
+	})
+	q.IterBinds(func(bind Bind) Bind {
+		if ContainsAggregate(bind.Expression) {
+			err = errors.Errorf("Aggregate in %s is not allowed in BIND", bind)
+		}
+		return bind
+	})
+	if err != nil {
+		return err
+	}
+	for _, agg := range q.Aggregates() {
+		if agg.Arg != nil && ContainsAggregate(agg.Arg) {
+			return errors.Errorf("Aggregates cannot be nested in %s", agg)
+		}
+	}
+	if !q.IsAggregate() {
+		if len(q.Select.Projections) > 0 {
+			return errors.New("Expressions in SELECT must use aggregates")
+		}
+		return nil
+	}
+	if q.Select.AllVars {
+		return errors.New("SELECT * cannot be used with GROUP BY or aggregates")
+	}
+	grouped := make(map[string]bool)
+	for _, varname := range q.GroupBy {
+		grouped[varname] = true
+	}
+	for _, varname := range q.Select.Vars {
+		expr := q.Select.Projection(varname)
+		if expr == nil && !grouped[varname] {
+			return errors.Errorf("Variable %s must be in GROUP BY or in an aggregate", varname)
+		}
+		if expr == nil {
+			continue
+		}
+		for _, v := range withoutAggregates(expr).Vars() {
+			if !grouped[v] {
+				return errors.Errorf("Variable %s in %s must be in GROUP BY or in an aggregate", v, expr)
+			}
+		}
+	}
+	for _, expr := range q.Having {
+		for _, v := range withoutAggregates(expr).Vars() {
+			if !grouped[v] && q.Select.Projection(v) == nil {
+				return errors.Errorf("Variable %s in HAVING must be in GROUP BY or in an aggregate", v)
+			}
+		}
+	}
+	return nil
+}
+
+// replaces the aggregates in expr with constants, leaving the parts of the
+// expression that are evaluated once per group
+func withoutAggregates(expr Expression) Expression {
+	return expr.Transform(func(e Expression) Expression {
+		if _, ok := e.(AggregateExpression); ok {
+			return LiteralExpression{Type: LITERAL_INTEGER, Value: "0"}
+		}
+		return e
+	})
+}
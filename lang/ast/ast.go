@@ -5,6 +5,8 @@ import (
 	"github.com/gtfierro/hod/lang/token"
 	"github.com/gtfierro/hod/turtle"
 	"github.com/kr/pretty"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +16,9 @@ const (
 	SELECT_QUERY QueryType = 1 << iota
 	INSERT_QUERY
 	DELETE_QUERY
+	ASK_QUERY
+	CONSTRUCT_QUERY
+	DESCRIBE_QUERY
 )
 
 var debug = false
@@ -30,9 +35,20 @@ type Query struct {
 	From      FromClause
 	Count     bool
 	Insert    InsertClause
+	Delete    DeleteClause
+	Construct ConstructClause
+	Describe  DescribeClause
 	Where     WhereClause
 	Variables []string
 	Type      QueryType
+	// GROUP BY, HAVING, ORDER BY, LIMIT and OFFSET
+	SolutionModifier
+	// BASE and PREFIX declarations
+	Prologue
+	// EXPLAIN or EXPLAIN ANALYZE
+	Explain ExplainMode
+	// NOCACHE: the results are neither read from nor saved to the query cache
+	NoCache bool
 }
 
 func (q Query) Dump() {
@@ -46,6 +62,15 @@ func (q Query) IsInsert() bool {
 	return (q.Type & INSERT_QUERY) == INSERT_QUERY
 }
 
+func (q Query) IsDelete() bool {
+	return (q.Type & DELETE_QUERY) == DELETE_QUERY
+}
+
+// true if the query changes the graph
+func (q Query) IsUpdate() bool {
+	return q.IsInsert() || q.IsDelete()
+}
+
 func (q Query) IsSelect() bool {
 	return (q.Type & SELECT_QUERY) == SELECT_QUERY
 }
@@ -56,9 +81,13 @@ func (q Query) CopyWithNewTerms(terms []Triple) Query {
 		From:      q.From,
 		Variables: q.Variables,
 		Type:      q.Type,
+
+		SolutionModifier: q.SolutionModifier,
+		Prologue:         q.Prologue,
 	}
 	newq.Where.Terms = make([]Triple, len(terms))
 	copy(newq.Where.Terms, terms)
+	newq.Where.Values = q.Where.Values
 
 	newq.Insert.Terms = make([]Triple, len(terms))
 	copy(newq.Insert.Terms, terms)
@@ -72,20 +101,140 @@ func (q Query) Copy() *Query {
 		Variables: q.Variables,
 		Where:     q.Where,
 		Insert:    q.Insert,
+		Delete:    q.Delete,
+		Construct: q.Construct,
+		Describe:  q.Describe,
 		Count:     q.Count,
 		Type:      q.Type,
+		Explain:   q.Explain,
+		NoCache:   q.NoCache,
+
+		SolutionModifier: q.SolutionModifier,
+		Prologue:         q.Prologue,
+	}
+}
+
+// returns a copy of the query that shares none of the triples, groups,
+// conditions or values that MapURIs rewrites, so that the copy can be
+// expanded without changing the query
+func (q Query) DeepCopy() *Query {
+	newq := q.Copy()
+	newq.Select.Vars = append([]string(nil), q.Select.Vars...)
+	newq.Select.Projections = append([]Projection(nil), q.Select.Projections...)
+	newq.Where = q.Where.deepCopy()
+	newq.Insert.Terms = copyTriples(q.Insert.Terms)
+	newq.Delete.Terms = copyTriples(q.Delete.Terms)
+	newq.Construct.Terms = copyTriples(q.Construct.Terms)
+	newq.Describe.Terms = append([]turtle.URI(nil), q.Describe.Terms...)
+	newq.OrderBy = append([]OrderCondition(nil), q.OrderBy...)
+	newq.Having = append([]Expression(nil), q.Having...)
+	return newq
+}
+
+func (where WhereClause) deepCopy() WhereClause {
+	newwhere := WhereClause{
+		Terms:      copyTriples(where.Terms),
+		Filters:    append([]Filter(nil), where.Filters...),
+		Optionals:  copyGroups(where.Optionals),
+		Minus:      copyGroups(where.Minus),
+		Exists:     copyExistsGroups(where.Exists),
+		Binds:      append([]Bind(nil), where.Binds...),
+		Subqueries: copyQueries(where.Subqueries),
+		Values:     where.Values.deepCopy(),
 	}
+	if where.GraphGroup != nil {
+		group := where.GraphGroup.deepCopy()
+		newwhere.GraphGroup = &group
+	}
+	return newwhere
 }
 
-func NewQuery(selectclause, whereclause interface{}, count bool) (Query, error) {
+func (grp GraphGroup) deepCopy() GraphGroup {
+	newgrp := GraphGroup{
+		Terms:      copyTriples(grp.Terms),
+		Filters:    append([]Filter(nil), grp.Filters...),
+		Optionals:  copyGroups(grp.Optionals),
+		Unions:     copyGroups(grp.Unions),
+		Minus:      copyGroups(grp.Minus),
+		Exists:     copyExistsGroups(grp.Exists),
+		Binds:      append([]Bind(nil), grp.Binds...),
+		Subqueries: copyQueries(grp.Subqueries),
+	}
+	for _, values := range grp.Values {
+		newgrp.Values = append(newgrp.Values, values.deepCopy())
+	}
+	return newgrp
+}
+
+func (vc ValuesClause) deepCopy() ValuesClause {
+	newvc := ValuesClause{Vars: vc.Vars}
+	for _, row := range vc.Rows {
+		newvc.Rows = append(newvc.Rows, append([]turtle.URI(nil), row...))
+	}
+	return newvc
+}
+
+func copyTriples(triples []Triple) []Triple {
+	if triples == nil {
+		return nil
+	}
+	newtriples := make([]Triple, len(triples))
+	for idx, triple := range triples {
+		newtriples[idx] = triple.Copy()
+	}
+	return newtriples
+}
+
+func copyGroups(groups []GraphGroup) []GraphGroup {
+	if groups == nil {
+		return nil
+	}
+	newgroups := make([]GraphGroup, len(groups))
+	for idx, group := range groups {
+		newgroups[idx] = group.deepCopy()
+	}
+	return newgroups
+}
+
+func copyExistsGroups(groups []ExistsGroup) []ExistsGroup {
+	if groups == nil {
+		return nil
+	}
+	newgroups := make([]ExistsGroup, len(groups))
+	for idx, group := range groups {
+		newgroups[idx] = ExistsGroup{GraphGroup: group.GraphGroup.deepCopy(), Negated: group.Negated}
+	}
+	return newgroups
+}
+
+func copyQueries(queries []Query) []Query {
+	if queries == nil {
+		return nil
+	}
+	newqueries := make([]Query, len(queries))
+	for idx, sub := range queries {
+		newqueries[idx] = *sub.DeepCopy()
+	}
+	return newqueries
+}
+
+// NOCACHE query
+func NewNoCacheQuery(query interface{}) (Query, error) {
+	q := query.(Query)
+	q.NoCache = true
+	return q, nil
+}
+
+func NewQuery(selectclause, whereclause, modifier interface{}, count bool) (Query, error) {
 	if debug {
 		fmt.Printf("%# v", pretty.Formatter(whereclause.(WhereClause)))
 	}
 	q := Query{
-		Where:  whereclause.(WhereClause),
-		Select: selectclause.(SelectClause),
-		Count:  count,
-		Type:   SELECT_QUERY,
+		Where:            whereclause.(WhereClause),
+		Select:           selectclause.(SelectClause),
+		Count:            count,
+		Type:             SELECT_QUERY,
+		SolutionModifier: modifier.(SolutionModifier),
 	}
 	if q.From.Empty() {
 		q.From.AllDBs = true
@@ -94,7 +243,7 @@ func NewQuery(selectclause, whereclause interface{}, count bool) (Query, error)
 	if q.Select.AllVars {
 		q.Select.Vars = q.Variables
 	}
-	return q, nil
+	return q, q.checkAggregates()
 }
 
 func NewInsertQuery(insertclause, whereclause interface{}, count bool) (Query, error) {
@@ -113,21 +262,21 @@ func NewInsertQuery(insertclause, whereclause interface{}, count bool) (Query, e
 		q.From.AllDBs = true
 	}
 	q.PopulateVars()
-	if q.Select.AllVars {
-		q.Select.Vars = q.Variables
-	}
+	q.selectWhereVars()
 	return q, nil
 }
 
-func NewQueryMulti(selectclause, fromclause, whereclause interface{}, count bool) (Query, error) {
+func NewQueryMulti(selectclause, fromclause, whereclause, modifier interface{}, count bool) (Query, error) {
 	if debug {
 		fmt.Printf("%# v", pretty.Formatter(whereclause.(WhereClause)))
 	}
 	q := Query{
-		Where:  whereclause.(WhereClause),
-		From:   fromclause.(FromClause),
-		Select: selectclause.(SelectClause),
-		Count:  count,
+		Where:            whereclause.(WhereClause),
+		From:             fromclause.(FromClause),
+		Select:           selectclause.(SelectClause),
+		Count:            count,
+		Type:             SELECT_QUERY,
+		SolutionModifier: modifier.(SolutionModifier),
 	}
 	if q.From.Empty() {
 		q.From.AllDBs = true
@@ -136,7 +285,7 @@ func NewQueryMulti(selectclause, fromclause, whereclause interface{}, count bool
 	if q.Select.AllVars {
 		q.Select.Vars = q.Variables
 	}
-	return q, nil
+	return q, q.checkAggregates()
 }
 
 func NewInsertQueryMulti(insertclause, fromclause, whereclause interface{}, count bool) (Query, error) {
@@ -156,12 +305,52 @@ func NewInsertQueryMulti(insertclause, fromclause, whereclause interface{}, coun
 		q.From.AllDBs = true
 	}
 	q.PopulateVars()
-	if q.Select.AllVars {
-		q.Select.Vars = q.Variables
+	q.selectWhereVars()
+	return q, nil
+}
+
+// builds a DELETE and/or INSERT query. Any of the arguments but the delete
+// clause may be nil. Without a WHERE clause (DELETE DATA), the triples of the
+// delete clause must not contain variables
+func NewUpdateQuery(deleteclause, insertclause, fromclause, whereclause interface{}) (Query, error) {
+	q := Query{
+		Select: SelectClause{AllVars: true},
+		Delete: deleteclause.(DeleteClause),
+		Type:   DELETE_QUERY,
+	}
+	if insertclause != nil {
+		q.Insert = insertclause.(InsertClause)
+		q.Type |= INSERT_QUERY
 	}
+	if fromclause != nil {
+		q.From = fromclause.(FromClause)
+	}
+	if whereclause != nil {
+		q.Where = whereclause.(WhereClause)
+	} else {
+		for _, triple := range q.Delete.Terms {
+			if triple.Subject.IsVariable() || triple.Object.IsVariable() || triple.Predicates[0].Predicate.IsVariable() {
+				return q, fmt.Errorf("DELETE DATA cannot contain variables (%s)", triple)
+			}
+		}
+	}
+	if q.From.Empty() {
+		q.From.AllDBs = true
+	}
+	q.PopulateVars()
+	q.selectWhereVars()
 	return q, nil
 }
 
+// updates select the variables bound by the WHERE clause; variables that
+// only appear in the templates stay unbound
+func (q *Query) selectWhereVars() {
+	where := Query{Where: q.Where}
+	where.PopulateVars()
+	q.Select.AllVars = false
+	q.Select.Vars = where.Variables
+}
+
 func (q *Query) PopulateVars() {
 	vars := make(map[string]int)
 	// get all variables
@@ -171,6 +360,9 @@ func (q *Query) PopulateVars() {
 		for _, path := range triple.Predicates {
 			AddIfVar(path.Predicate, vars)
 		}
+		if triple.Route != "" {
+			vars[triple.Route] = 1
+		}
 	}
 	for _, triple := range q.Insert.Terms {
 		AddIfVar(triple.Subject, vars)
@@ -179,13 +371,28 @@ func (q *Query) PopulateVars() {
 			AddIfVar(path.Predicate, vars)
 		}
 	}
+	for _, optional := range q.Where.Optionals {
+		VarsFromGroup(optional, vars)
+	}
 	if q.Where.GraphGroup != nil {
 		VarsFromGroup(*q.Where.GraphGroup, vars)
 	}
+	for _, varname := range q.Where.Values.Vars {
+		vars[varname] = 1
+	}
+	for _, bind := range q.Where.Binds {
+		vars[bind.Var] = 1
+	}
+	for _, sub := range q.Where.Subqueries {
+		for _, varname := range sub.Select.Vars {
+			vars[varname] = 1
+		}
+	}
 	q.Variables = []string{} // clear
 	for varname := range vars {
 		q.Variables = append(q.Variables, varname)
 	}
+	sort.Strings(q.Variables)
 }
 
 func (q Query) IterTriples(f func(t Triple) Triple) {
@@ -195,11 +402,62 @@ func (q Query) IterTriples(f func(t Triple) Triple) {
 	for idx, triple := range q.Insert.Terms {
 		q.Insert.Terms[idx] = f(triple)
 	}
+	for idx, triple := range q.Delete.Terms {
+		q.Delete.Terms[idx] = f(triple)
+	}
+	for idx, triple := range q.Construct.Terms {
+		q.Construct.Terms[idx] = f(triple)
+	}
+	for _, optional := range q.Where.Optionals {
+		optional.IterTriples(f)
+	}
+	for _, group := range q.Where.Minus {
+		group.IterTriples(f)
+	}
+	for idx := range q.Where.Exists {
+		q.Where.Exists[idx].IterTriples(f)
+	}
 	if q.Where.GraphGroup != nil {
 		q.Where.GraphGroup.IterTriples(f)
 	}
 }
 
+func (q Query) IterFilters(f func(filter Filter) Filter) {
+	for idx, filter := range q.Where.Filters {
+		q.Where.Filters[idx] = f(filter)
+	}
+	for _, optional := range q.Where.Optionals {
+		optional.IterFilters(f)
+	}
+	for _, group := range q.Where.Minus {
+		group.IterFilters(f)
+	}
+	for idx := range q.Where.Exists {
+		q.Where.Exists[idx].IterFilters(f)
+	}
+	if q.Where.GraphGroup != nil {
+		q.Where.GraphGroup.IterFilters(f)
+	}
+}
+
+func (q Query) IterBinds(f func(bind Bind) Bind) {
+	for idx, bind := range q.Where.Binds {
+		q.Where.Binds[idx] = f(bind)
+	}
+	for _, optional := range q.Where.Optionals {
+		optional.IterBinds(f)
+	}
+	for _, group := range q.Where.Minus {
+		group.IterBinds(f)
+	}
+	for idx := range q.Where.Exists {
+		q.Where.Exists[idx].IterBinds(f)
+	}
+	if q.Where.GraphGroup != nil {
+		q.Where.GraphGroup.IterBinds(f)
+	}
+}
+
 func AddIfVar(uri turtle.URI, m map[string]int) {
 	if uri.IsVariable() {
 		m[uri.String()] = 1
@@ -213,29 +471,90 @@ func VarsFromGroup(group GraphGroup, m map[string]int) {
 		for _, path := range triple.Predicates {
 			AddIfVar(path.Predicate, m)
 		}
+		if triple.Route != "" {
+			m[triple.Route] = 1
+		}
+	}
+	for _, optional := range group.Optionals {
+		VarsFromGroup(optional, m)
 	}
 	for _, union := range group.Unions {
 		VarsFromGroup(union, m)
 	}
+	for _, values := range group.Values {
+		for _, varname := range values.Vars {
+			m[varname] = 1
+		}
+	}
+	for _, bind := range group.Binds {
+		m[bind.Var] = 1
+	}
+	for _, sub := range group.Subqueries {
+		for _, varname := range sub.Select.Vars {
+			m[varname] = 1
+		}
+	}
 }
 
-func (grp GraphGroup) Expand() [][]Triple {
-	var terms = make([]Triple, len(grp.Terms))
-	copy(terms, grp.Terms)
-	var groups [][]Triple
-
-	if len(grp.Unions) > 0 {
-		for _, union := range grp.Unions {
-			for _, subgroup := range union.Expand() {
-				groups = append(groups, append(terms, subgroup...))
-			}
+// Expand returns each fully-elaborated branch of the group: every
+// combination of UNION alternatives, each with the terms, filters, optional,
+// MINUS and EXISTS groups, BINDs, VALUES and subqueries that apply to it
+func (grp GraphGroup) Expand() []GraphGroup {
+	var base GraphGroup
+	base.Terms = make([]Triple, len(grp.Terms))
+	copy(base.Terms, grp.Terms)
+	base.Filters = make([]Filter, len(grp.Filters))
+	copy(base.Filters, grp.Filters)
+	base.Optionals = make([]GraphGroup, len(grp.Optionals))
+	copy(base.Optionals, grp.Optionals)
+	base.Minus = make([]GraphGroup, len(grp.Minus))
+	copy(base.Minus, grp.Minus)
+	base.Exists = make([]ExistsGroup, len(grp.Exists))
+	copy(base.Exists, grp.Exists)
+	base.Binds = make([]Bind, len(grp.Binds))
+	copy(base.Binds, grp.Binds)
+	base.Subqueries = make([]Query, len(grp.Subqueries))
+	copy(base.Subqueries, grp.Subqueries)
+	base.Values = make([]ValuesClause, len(grp.Values))
+	copy(base.Values, grp.Values)
+
+	if len(grp.Unions) == 0 {
+		return []GraphGroup{base}
+	}
+	var groups []GraphGroup
+	for _, union := range grp.Unions {
+		for _, subgroup := range union.Expand() {
+			var branch GraphGroup
+			branch.Terms = append(append([]Triple{}, base.Terms...), subgroup.Terms...)
+			branch.Filters = append(append([]Filter{}, base.Filters...), subgroup.Filters...)
+			branch.Optionals = append(append([]GraphGroup{}, base.Optionals...), subgroup.Optionals...)
+			branch.Minus = append(append([]GraphGroup{}, base.Minus...), subgroup.Minus...)
+			branch.Exists = append(append([]ExistsGroup{}, base.Exists...), subgroup.Exists...)
+			branch.Binds = append(append([]Bind{}, base.Binds...), subgroup.Binds...)
+			branch.Subqueries = append(append([]Query{}, base.Subqueries...), subgroup.Subqueries...)
+			// UNION branches have no VALUES
+			branch.Values = base.Values
+			groups = append(groups, branch)
 		}
-	} else {
-		groups = append(groups, terms)
 	}
 	return groups
 }
 
+// calls f with the VALUES block of the query and those of its OPTIONAL,
+// MINUS and EXISTS groups
+func (q Query) IterValues(f func(values ValuesClause)) {
+	f(q.Where.Values)
+	for _, optional := range q.Where.Optionals {
+		optional.IterValues(f)
+	}
+	for _, group := range q.Where.Minus {
+		group.IterValues(f)
+	}
+	for idx := range q.Where.Exists {
+		q.Where.Exists[idx].IterValues(f)
+	}
+}
+
 func (grp GraphGroup) Iter(f func(t turtle.URI)) {
 	for _, triple := range grp.Terms {
 		f(triple.Subject)
@@ -244,23 +563,96 @@ func (grp GraphGroup) Iter(f func(t turtle.URI)) {
 			f(path.Predicate)
 		}
 	}
+	for _, optional := range grp.Optionals {
+		optional.Iter(f)
+	}
 	for _, union := range grp.Unions {
 		union.Iter(f)
 	}
+	for _, group := range grp.Minus {
+		group.Iter(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].Iter(f)
+	}
 }
 
 func (grp *GraphGroup) IterTriples(f func(t Triple) Triple) {
 	for idx, triple := range grp.Terms {
 		grp.Terms[idx] = f(triple)
 	}
+	for _, optional := range grp.Optionals {
+		optional.IterTriples(f)
+	}
 	for _, union := range grp.Unions {
 		union.IterTriples(f)
 	}
+	for _, group := range grp.Minus {
+		group.IterTriples(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].IterTriples(f)
+	}
+}
+
+func (grp *GraphGroup) IterFilters(f func(filter Filter) Filter) {
+	for idx, filter := range grp.Filters {
+		grp.Filters[idx] = f(filter)
+	}
+	for _, optional := range grp.Optionals {
+		optional.IterFilters(f)
+	}
+	for _, union := range grp.Unions {
+		union.IterFilters(f)
+	}
+	for _, group := range grp.Minus {
+		group.IterFilters(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].IterFilters(f)
+	}
+}
+
+func (grp *GraphGroup) IterBinds(f func(bind Bind) Bind) {
+	for idx, bind := range grp.Binds {
+		grp.Binds[idx] = f(bind)
+	}
+	for _, optional := range grp.Optionals {
+		optional.IterBinds(f)
+	}
+	for _, union := range grp.Unions {
+		union.IterBinds(f)
+	}
+	for _, group := range grp.Minus {
+		group.IterBinds(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].IterBinds(f)
+	}
+}
+
+func (grp GraphGroup) IterValues(f func(values ValuesClause)) {
+	for _, values := range grp.Values {
+		f(values)
+	}
+	for _, optional := range grp.Optionals {
+		optional.IterValues(f)
+	}
+	for _, group := range grp.Minus {
+		group.IterValues(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].IterValues(f)
+	}
 }
 
 type SelectClause struct {
 	Vars    []string
 	AllVars bool
+	// the (expr AS ?var) items of the clause; their variables are in Vars
+	Projections []Projection
+	// the ?var[key] items of the clause; their variables are in Vars
+	Links []LinkSelection
 }
 
 func NewAllSelectClause() (SelectClause, error) {
@@ -271,6 +663,16 @@ func NewSelectClause(varlist interface{}) (SelectClause, error) {
 	return SelectClause{Vars: varlist.([]string)}, nil
 }
 
+type DeleteClause struct {
+	Terms []Triple
+}
+
+func NewDeleteClause(triples interface{}) (DeleteClause, error) {
+	return DeleteClause{
+		Terms: triples.([]Triple),
+	}, nil
+}
+
 type InsertClause struct {
 	Terms []Triple
 }
@@ -307,39 +709,111 @@ func (from FromClause) Empty() bool {
 
 type WhereClause struct {
 	Terms      []Triple
+	Filters    []Filter
+	Optionals  []GraphGroup
+	Minus      []GraphGroup
+	Exists     []ExistsGroup
+	Binds      []Bind
+	Subqueries []Query
 	GraphGroup *GraphGroup
+	// inline bindings that the rest of the clause is evaluated for
+	Values ValuesClause
 }
 
-func NewWhereClause(triples interface{}) (WhereClause, error) {
-	return WhereClause{
-		Terms: triples.([]Triple),
-	}, nil
+// builds the WHERE clause from the top-level group graph pattern: triples,
+// filters and optional groups are kept on the clause, and any UNIONs are
+// placed into the GraphGroup
+func NewWhereClause(group interface{}) (WhereClause, error) {
+	g := group.(GraphGroup)
+	where := WhereClause{
+		Terms:      g.Terms,
+		Filters:    g.Filters,
+		Optionals:  g.Optionals,
+		Minus:      g.Minus,
+		Exists:     g.Exists,
+		Binds:      g.Binds,
+		Subqueries: g.Subqueries,
+	}
+	if len(g.Unions) > 0 {
+		where.GraphGroup = &GraphGroup{Unions: g.Unions}
+	}
+	// VALUES seed the evaluation of the whole clause
+	if err := g.checkValues(); err != nil {
+		return where, err
+	}
+	if len(g.Values) == 1 {
+		where.Values = g.Values[0]
+	}
+	return where, nil
 }
 
-func NewWhereClauseWithGraphGroup(triples, group interface{}) (WhereClause, error) {
-	g := group.(GraphGroup)
-	return WhereClause{
-		Terms:      triples.([]Triple),
-		GraphGroup: &g,
-	}, nil
+type GraphGroup struct {
+	Terms   []Triple
+	Filters []Filter
+	// groups that are left-outer-joined with the rest of the group
+	Optionals []GraphGroup
+	// alternatives; a group with unions matches if any of the unions do
+	Unions []GraphGroup
+	// VALUES blocks
+	Values []ValuesClause
+	// MINUS groups; solutions compatible with a solution of one of these
+	// groups are removed
+	Minus []GraphGroup
+	// FILTER EXISTS and FILTER NOT EXISTS groups
+	Exists []ExistsGroup
+	// BINDs, evaluated in order once the rest of the group is evaluated
+	Binds []Bind
+	// nested SELECT queries, whose solutions are joined with the group
+	Subqueries []Query
 }
 
-func NewWhereClauseGraphGroup(group interface{}) (WhereClause, error) {
-	g := group.(GraphGroup)
-	return WhereClause{
-		GraphGroup: &g,
-	}, nil
+// OPTIONAL { ... }
+type OptionalGroup struct {
+	GraphGroup
 }
 
-type GraphGroup struct {
-	Terms  []Triple
-	Unions []GraphGroup
+func NewOptionalGroup(group interface{}) (OptionalGroup, error) {
+	return OptionalGroup{group.(GraphGroup)}, nil
 }
 
-func GraphGroupFromTriples(triples interface{}) (GraphGroup, error) {
-	return GraphGroup{
-		Terms: triples.([]Triple),
-	}, nil
+// MINUS { ... }
+type MinusGroup struct {
+	GraphGroup
+}
+
+func NewMinusGroup(group interface{}) (MinusGroup, error) {
+	return MinusGroup{group.(GraphGroup)}, nil
+}
+
+// FILTER EXISTS { ... } or FILTER NOT EXISTS { ... }. The group is evaluated
+// with the bindings of each solution, which is kept if the group has (or,
+// negated, does not have) a match
+type ExistsGroup struct {
+	GraphGroup
+	// true for NOT EXISTS
+	Negated bool
+}
+
+func NewExistsGroup(group interface{}, negated bool) (ExistsGroup, error) {
+	return ExistsGroup{GraphGroup: group.(GraphGroup), Negated: negated}, nil
+}
+
+func (e ExistsGroup) String() string {
+	if e.Negated {
+		return fmt.Sprintf("FILTER NOT EXISTS %v", e.Terms)
+	}
+	return fmt.Sprintf("FILTER EXISTS %v", e.Terms)
+}
+
+// the variables mentioned in the group
+func (grp GraphGroup) Vars() []string {
+	vars := make(map[string]int)
+	VarsFromGroup(grp, vars)
+	var varlist []string
+	for varname := range vars {
+		varlist = append(varlist, varname)
+	}
+	return varlist
 }
 
 func GraphGroupUnion(left, right interface{}) (GraphGroup, error) {
@@ -348,24 +822,73 @@ func GraphGroupUnion(left, right interface{}) (GraphGroup, error) {
 	}, nil
 }
 
-func AddTriplesToGraphGroup(left, triples interface{}) (GraphGroup, error) {
-	return GraphGroup{
-		Terms:  append(left.(GraphGroup).Terms, triples.([]Triple)...),
-		Unions: left.(GraphGroup).Unions,
-	}, nil
+// adds an element of a group graph pattern (a triple, a filter, a BIND, an
+// OPTIONAL, MINUS or EXISTS group, a subquery, or a nested group/UNION) to the
+// given group.
+// BINDs are evaluated after the rest of the group, so the variable of a BIND
+// cannot be used in the triples of its group
+func AddToGraphGroup(group, element interface{}) (GraphGroup, error) {
+	g := group.(GraphGroup)
+	switch elem := element.(type) {
+	case Triple:
+		vars := make(map[string]int)
+		VarsFromGroup(GraphGroup{Terms: []Triple{elem}}, vars)
+		for _, bind := range g.Binds {
+			if _, found := vars[bind.Var]; found {
+				return g, fmt.Errorf("Variable %s of %s cannot be used in a triple", bind.Var, bind)
+			}
+		}
+		g.Terms = append(g.Terms, elem)
+	case Bind:
+		if containsVar(g.Vars(), elem.Var) {
+			return g, fmt.Errorf("Variable %s of %s is already used in the group", elem.Var, elem)
+		}
+		g.Binds = append(g.Binds, elem)
+	case Filter:
+		g.Filters = append(g.Filters, elem)
+	case OptionalGroup:
+		g.Optionals = append(g.Optionals, elem.GraphGroup)
+	case ValuesClause:
+		g.Values = append(g.Values, elem)
+	case MinusGroup:
+		g.Minus = append(g.Minus, elem.GraphGroup)
+	case ExistsGroup:
+		g.Exists = append(g.Exists, elem)
+	case GraphGroup:
+		if elem.onlySubqueries() {
+			g.Subqueries = append(g.Subqueries, elem.Subqueries...)
+		} else {
+			g = g.and(elem)
+		}
+	case *token.Token:
+		// separator
+	default:
+		return g, fmt.Errorf("Unknown group element %v", element)
+	}
+	return g, nil
 }
 
-func MergeGraphGroups(left, right interface{}) (GraphGroup, error) {
-	return GraphGroup{
-		Terms:  append(left.(GraphGroup).Terms, right.(GraphGroup).Terms...),
-		Unions: append(left.(GraphGroup).Unions, right.(GraphGroup).Unions...),
-	}, nil
+// returns a group which matches both grp and other. Unions are distributed so
+// that sequential UNION blocks produce the cross product of their branches
+func (grp GraphGroup) and(other GraphGroup) GraphGroup {
+	if len(grp.Unions) == 0 {
+		grp.Unions = []GraphGroup{other}
+		return grp
+	}
+	unions := make([]GraphGroup, len(grp.Unions))
+	for idx, union := range grp.Unions {
+		unions[idx] = union.and(other)
+	}
+	grp.Unions = unions
+	return grp
 }
 
 type Triple struct {
 	Subject    turtle.URI
 	Predicates []PathPattern
 	Object     turtle.URI
+	// the variable bound to the route of each match of the path, if any
+	Route string
 }
 
 func (t Triple) String() string {
@@ -373,7 +896,11 @@ func (t Triple) String() string {
 	for _, pp := range t.Predicates {
 		s += " " + pp.String()
 	}
-	return s + " | " + t.Object.String() + ">"
+	s += " | " + t.Object.String() + ">"
+	if t.Route != "" {
+		s += " ROUTE " + t.Route
+	}
+	return s
 }
 
 func (t Triple) Copy() Triple {
@@ -383,6 +910,7 @@ func (t Triple) Copy() Triple {
 		Subject:    t.Subject,
 		Object:     t.Object,
 		Predicates: p,
+		Route:      t.Route,
 	}
 }
 
@@ -394,6 +922,19 @@ func NewTriple(subject, predicates, object interface{}) (Triple, error) {
 	}, nil
 }
 
+// NewRouteTriple binds the route of each match of the triple's path to the
+// variable: the entities and predicates it went through, in order
+func NewRouteTriple(triple, _var interface{}) (Triple, error) {
+	t := triple.(Triple)
+	for _, path := range t.Predicates {
+		if path.Predicate.IsVariable() {
+			return t, fmt.Errorf("ROUTE %s cannot follow a path with the variable %s", _var, path.Predicate)
+		}
+	}
+	t.Route = _var.(string)
+	return t, nil
+}
+
 func NewTripleBlock(triple interface{}) ([]Triple, error) {
 	return []Triple{triple.(Triple)}, nil
 }
@@ -403,7 +944,7 @@ func AppendTripleBlock(block, triple interface{}) ([]Triple, error) {
 }
 
 func NewURI(value interface{}) (turtle.URI, error) {
-	return turtle.ParseURI(value.(string)), nil
+	return parseIRI(value.(string)), nil
 }
 
 func NewVarList(_var interface{}) ([]string, error) {
@@ -430,6 +971,33 @@ func ParseQuotedString(_var interface{}) (string, error) {
 	return string(_var.(*token.Token).Lit), nil
 }
 
+// literals in triples are passed on in their Turtle form and are parsed by
+// NewURI
+func NewLangLiteral(_str, _lang interface{}) (string, error) {
+	return string(_str.(*token.Token).Lit) + string(_lang.(*token.Token).Lit), nil
+}
+
+// prefixed datatypes are expanded along with the rest of the query
+func NewTypedLiteral(_str, _datatype interface{}) (string, error) {
+	return string(_str.(*token.Token).Lit) + "^^" + string(_datatype.(*token.Token).Lit), nil
+}
+
+func NewIntegerTerm(_num interface{}) (string, error) {
+	return literalTerm(_num, turtle.XSDInteger)
+}
+
+func NewDecimalTerm(_num interface{}) (string, error) {
+	return literalTerm(_num, turtle.XSDDecimal)
+}
+
+func NewBooleanTerm(_b interface{}) (string, error) {
+	return literalTerm(_b, turtle.XSDBoolean)
+}
+
+func literalTerm(_lit interface{}, datatype string) (string, error) {
+	return strconv.Quote(string(_lit.(*token.Token).Lit)) + "^^<" + datatype + ">", nil
+}
+
 func NewPathSequence(_pred interface{}) ([]PathPattern, error) {
 	return []PathPattern{_pred.(PathPattern)}, nil
 }
@@ -444,34 +1012,177 @@ func NewPathPattern(_pred interface{}) (PathPattern, error) {
 		pred = "rdf:type"
 	}
 	return PathPattern{
-		Predicate: turtle.ParseURI(pred),
+		Predicate: parseIRI(pred),
 		Pattern:   PATTERN_SINGLE,
 	}, nil
 }
 
+// a variable in a path matches any single predicate, so it cannot be
+// repeated with a modifier
 func AddPathMod(_pred, _mod interface{}) (PathPattern, error) {
-	return PathPattern{
-		Predicate: _pred.(PathPattern).Predicate,
-		Pattern:   _mod.(Pattern),
-	}, nil
+	pp := _pred.(PathPattern)
+	if pp.Predicate.IsVariable() {
+		return pp, fmt.Errorf("Variable %s in a path cannot have the modifier %s", pp.Predicate, _mod)
+	}
+	// a pattern that already has a modifier, e.g. from (bf:feeds+)*, is
+	// wrapped in a group so that both modifiers apply
+	if pp.Pattern != PATTERN_SINGLE {
+		pp = PathPattern{
+			Alternatives: [][]PathPattern{{pp}},
+			Pattern:      PATTERN_SINGLE,
+		}
+	}
+	switch mod := _mod.(type) {
+	case Pattern:
+		pp.Pattern = mod
+	case PathRepeat:
+		if pp.Pattern = mod.pattern(); pp.Pattern == PATTERN_BOUNDED {
+			pp.Min, pp.Max = mod.Min, mod.Max
+		}
+	}
+	return pp, nil
+}
+
+func InvertPathPattern(_pred interface{}) (PathPattern, error) {
+	pp := _pred.(PathPattern)
+	pp.Inverse = !pp.Inverse
+	return pp, nil
+}
+
+func NewPathAlternatives(_seq interface{}) ([][]PathPattern, error) {
+	return [][]PathPattern{_seq.([]PathPattern)}, nil
+}
+
+func AppendPathAlternative(_alts, _seq interface{}) ([][]PathPattern, error) {
+	return append(_alts.([][]PathPattern), _seq.([]PathPattern)), nil
+}
+
+// the path of a triple: a sequence of path patterns. Alternatives at the top
+// level become a single group
+func NewPath(_alts interface{}) ([]PathPattern, error) {
+	alts := _alts.([][]PathPattern)
+	if len(alts) == 1 {
+		return alts[0], nil
+	}
+	if err := checkGroupVars(alts); err != nil {
+		return nil, err
+	}
+	return []PathPattern{{Alternatives: alts, Pattern: PATTERN_SINGLE}}, nil
 }
 
+// a parenthesized path. Parentheses around a single path pattern are dropped
+func NewPathGroup(_alts interface{}) (PathPattern, error) {
+	alts := _alts.([][]PathPattern)
+	if len(alts) == 1 && len(alts[0]) == 1 {
+		return alts[0][0], nil
+	}
+	if err := checkGroupVars(alts); err != nil {
+		return PathPattern{}, err
+	}
+	return PathPattern{Alternatives: alts, Pattern: PATTERN_SINGLE}, nil
+}
+
+// variables can be used anywhere in the sequence of a triple's path, but not
+// inside alternatives or groups
+func checkGroupVars(alts [][]PathPattern) error {
+	for _, alt := range alts {
+		for _, pp := range alt {
+			if pp.Predicate.IsVariable() {
+				return fmt.Errorf("Variable %s cannot be used in a path group or alternative", pp.Predicate)
+			}
+		}
+	}
+	return nil
+}
+
+// PathPattern is a node in a property path. A leaf is a single Predicate; a
+// group holds Alternatives, each of which is a sequence of path patterns,
+// e.g. (bf:feeds|bf:hasPart/bf:feeds). The Pattern modifier and Inverse (^)
+// apply to the whole node
 type PathPattern struct {
-	Predicate turtle.URI
-	Pattern   Pattern
+	Predicate    turtle.URI
+	Pattern      Pattern
+	Alternatives [][]PathPattern
+	Inverse      bool
+	// bounds of a PATTERN_BOUNDED repetition; a negative Max is unbounded
+	Min, Max int
+}
+
+// returns the minimum and maximum number of times the pattern is repeated. A
+// negative maximum means there is no upper bound
+func (pp PathPattern) Bounds() (min, max int) {
+	switch pp.Pattern {
+	case PATTERN_ZERO_ONE:
+		return 0, 1
+	case PATTERN_ONE_PLUS:
+		return 1, -1
+	case PATTERN_ZERO_PLUS:
+		return 0, -1
+	case PATTERN_BOUNDED:
+		return pp.Min, pp.Max
+	}
+	return 1, 1
+}
+
+func (pp PathPattern) IsGroup() bool {
+	return len(pp.Alternatives) > 0
+}
+
+// true if the pattern is a single predicate followed from subject to object
+func (pp PathPattern) IsSimple() bool {
+	return !pp.IsGroup() && !pp.Inverse
 }
 
-func PathFromVar(_var interface{}) ([]PathPattern, error) {
-	return []PathPattern{
-		PathPattern{
-			Predicate: turtle.ParseURI(_var.(string)),
-			Pattern:   PATTERN_SINGLE,
-		},
+// returns a copy of the pattern with f applied to each predicate in it
+func (pp PathPattern) MapPredicates(f func(turtle.URI) turtle.URI) PathPattern {
+	if !pp.IsGroup() {
+		pp.Predicate = f(pp.Predicate)
+		return pp
+	}
+	alts := make([][]PathPattern, len(pp.Alternatives))
+	for idx, alt := range pp.Alternatives {
+		alts[idx] = make([]PathPattern, len(alt))
+		for idx2, elt := range alt {
+			alts[idx][idx2] = elt.MapPredicates(f)
+		}
+	}
+	pp.Alternatives = alts
+	return pp
+}
+
+// a variable in a path, e.g. ?p in ?s ?p/brick:hasPoint ?o. It is bound to
+// the predicate of that step of the path
+func NewVarPathPattern(_var interface{}) (PathPattern, error) {
+	return PathPattern{
+		Predicate: turtle.ParseURI(_var.(string)),
+		Pattern:   PATTERN_SINGLE,
 	}, nil
 }
 
 func (pp PathPattern) String() string {
-	return pp.Predicate.String() + pp.Pattern.String()
+	var prefix string
+	if pp.Inverse {
+		prefix = "^"
+	}
+	if !pp.IsGroup() {
+		return prefix + pp.Predicate.String() + pp.modifier()
+	}
+	alts := make([]string, len(pp.Alternatives))
+	for idx, alt := range pp.Alternatives {
+		elts := make([]string, len(alt))
+		for idx2, elt := range alt {
+			elts[idx2] = elt.String()
+		}
+		alts[idx] = strings.Join(elts, "/")
+	}
+	return prefix + "(" + strings.Join(alts, "|") + ")" + pp.modifier()
+}
+
+func (pp PathPattern) modifier() string {
+	if pp.Pattern == PATTERN_BOUNDED {
+		return PathRepeat{Min: pp.Min, Max: pp.Max}.String()
+	}
+	return pp.Pattern.String()
 }
 
 type Pattern uint
@@ -481,6 +1192,8 @@ const (
 	PATTERN_ZERO_ONE
 	PATTERN_ONE_PLUS
 	PATTERN_ZERO_PLUS
+	// {n,m}: repeated between PathPattern.Min and PathPattern.Max times
+	PATTERN_BOUNDED
 )
 
 func (p Pattern) String() string {
@@ -493,6 +1206,59 @@ func (p Pattern) String() string {
 		return "+"
 	case PATTERN_ZERO_PLUS:
 		return "*"
+	case PATTERN_BOUNDED:
+		return "{n,m}"
 	}
 	return "unknown"
 }
+
+// the {n,m} path modifier. A negative Max means there is no upper bound
+type PathRepeat struct {
+	Min, Max int
+}
+
+// builds the modifier for {n,m}, {n}, {n,} or {,m}. A nil [_min] is 0 and a
+// nil [_max] is unbounded
+func NewPathRepeat(_min, _max interface{}) (PathRepeat, error) {
+	var (
+		rep = PathRepeat{Max: -1}
+		err error
+	)
+	if _min != nil {
+		if rep.Min, err = parseNonNegative(_min); err != nil {
+			return rep, fmt.Errorf("Invalid path repetition: %v", err)
+		}
+	}
+	if _max != nil {
+		if rep.Max, err = parseNonNegative(_max); err != nil {
+			return rep, fmt.Errorf("Invalid path repetition: %v", err)
+		}
+		if rep.Max < rep.Min {
+			return rep, fmt.Errorf("Invalid path repetition %s: maximum is less than minimum", rep)
+		}
+	}
+	return rep, nil
+}
+
+// repetitions that mean the same as ?, * or + use those patterns
+func (rep PathRepeat) pattern() Pattern {
+	switch {
+	case rep.Min == 0 && rep.Max == 1:
+		return PATTERN_ZERO_ONE
+	case rep.Min == 0 && rep.Max < 0:
+		return PATTERN_ZERO_PLUS
+	case rep.Min == 1 && rep.Max < 0:
+		return PATTERN_ONE_PLUS
+	}
+	return PATTERN_BOUNDED
+}
+
+func (rep PathRepeat) String() string {
+	switch {
+	case rep.Max < 0:
+		return fmt.Sprintf("{%d,}", rep.Min)
+	case rep.Min == rep.Max:
+		return fmt.Sprintf("{%d}", rep.Min)
+	}
+	return fmt.Sprintf("{%d,%d}", rep.Min, rep.Max)
+}
@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"fmt"
+)
+
+// whether a query is answered or explained
+type ExplainMode uint
+
+const (
+	// the query is answered
+	EXPLAIN_NONE ExplainMode = iota
+	// EXPLAIN: the plan of the query is returned without running it
+	EXPLAIN_PLAN
+	// EXPLAIN ANALYZE: the plan of the query is run, and returned along with
+	// what each of its operations did
+	EXPLAIN_ANALYZE
+)
+
+// EXPLAIN [ANALYZE] query. Updates are not explained, because analyzing them
+// would change the graph
+func NewExplainQuery(query interface{}, analyze bool) (Query, error) {
+	q := query.(Query)
+	if q.IsUpdate() {
+		return q, fmt.Errorf("EXPLAIN is not supported for INSERT and DELETE")
+	}
+	q.Explain = EXPLAIN_PLAN
+	if analyze {
+		q.Explain = EXPLAIN_ANALYZE
+	}
+	return q, nil
+}
+
+func (q Query) IsExplain() bool {
+	return q.Explain != EXPLAIN_NONE
+}
@@ -0,0 +1,278 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gtfierro/hod/lang/token"
+	"github.com/gtfierro/hod/turtle"
+)
+
+// Expression is a node in the typed expression tree used by FILTER
+type Expression interface {
+	// names of the variables referenced by this expression
+	Vars() []string
+	// returns a copy of the expression tree with f applied to each node,
+	// children first
+	Transform(f func(Expression) Expression) Expression
+	String() string
+}
+
+type Filter struct {
+	Expression Expression
+}
+
+func NewFilter(expr interface{}) (Filter, error) {
+	return Filter{Expression: expr.(Expression)}, nil
+}
+
+func (f Filter) Vars() []string {
+	return f.Expression.Vars()
+}
+
+func (f Filter) String() string {
+	return "FILTER(" + f.Expression.String() + ")"
+}
+
+// BIND(expr AS ?var): extends each solution of the group with the value of
+// the expression. The variable is unbound in solutions for which the
+// expression has an error
+type Bind struct {
+	Expression Expression
+	Var        string
+}
+
+func NewBind(expr, _var interface{}) (Bind, error) {
+	return Bind{Expression: expr.(Expression), Var: _var.(string)}, nil
+}
+
+func (b Bind) String() string {
+	return "BIND(" + b.Expression.String() + " AS " + b.Var + ")"
+}
+
+// binary operators: || && = != < > <= >= + - * /
+type BinaryExpression struct {
+	Op          string
+	Left, Right Expression
+}
+
+func NewBinaryExpression(left, op, right interface{}) (Expression, error) {
+	return BinaryExpression{
+		Op:    string(op.(*token.Token).Lit),
+		Left:  left.(Expression),
+		Right: right.(Expression),
+	}, nil
+}
+
+func (e BinaryExpression) Vars() []string {
+	return mergeVars(e.Left.Vars(), e.Right.Vars())
+}
+
+func (e BinaryExpression) Transform(f func(Expression) Expression) Expression {
+	e.Left = e.Left.Transform(f)
+	e.Right = e.Right.Transform(f)
+	return f(e)
+}
+
+func (e BinaryExpression) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+// unary operators: ! - +
+type UnaryExpression struct {
+	Op      string
+	Operand Expression
+}
+
+func NewUnaryExpression(op, operand interface{}) (Expression, error) {
+	return UnaryExpression{
+		Op:      string(op.(*token.Token).Lit),
+		Operand: operand.(Expression),
+	}, nil
+}
+
+func (e UnaryExpression) Vars() []string {
+	return e.Operand.Vars()
+}
+
+func (e UnaryExpression) Transform(f func(Expression) Expression) Expression {
+	e.Operand = e.Operand.Transform(f)
+	return f(e)
+}
+
+func (e UnaryExpression) String() string {
+	return e.Op + e.Operand.String()
+}
+
+// built-in function call, e.g. REGEX(?x, "vav"). Function names are
+// case-insensitive and are stored in upper case
+type FunctionCall struct {
+	Function string
+	Args     []Expression
+}
+
+func NewFunctionCall(name, args interface{}) (Expression, error) {
+	call := FunctionCall{
+		Function: strings.ToUpper(string(name.(*token.Token).Lit)),
+	}
+	if args != nil {
+		call.Args = args.([]Expression)
+	}
+	return call, nil
+}
+
+func (e FunctionCall) Vars() []string {
+	var vars []string
+	for _, arg := range e.Args {
+		vars = mergeVars(vars, arg.Vars())
+	}
+	return vars
+}
+
+func (e FunctionCall) Transform(f func(Expression) Expression) Expression {
+	args := make([]Expression, len(e.Args))
+	for idx, arg := range e.Args {
+		args[idx] = arg.Transform(f)
+	}
+	e.Args = args
+	return f(e)
+}
+
+func (e FunctionCall) String() string {
+	var args []string
+	for _, arg := range e.Args {
+		args = append(args, arg.String())
+	}
+	return e.Function + "(" + strings.Join(args, ", ") + ")"
+}
+
+func NewExpressionList(expr interface{}) ([]Expression, error) {
+	return []Expression{expr.(Expression)}, nil
+}
+
+func AppendExpressionList(list, expr interface{}) ([]Expression, error) {
+	return append(list.([]Expression), expr.(Expression)), nil
+}
+
+type VarExpression struct {
+	Name string
+}
+
+func NewVarExpression(_var interface{}) (Expression, error) {
+	return VarExpression{Name: _var.(string)}, nil
+}
+
+func (e VarExpression) Vars() []string {
+	return []string{e.Name}
+}
+
+func (e VarExpression) Transform(f func(Expression) Expression) Expression {
+	return f(e)
+}
+
+func (e VarExpression) String() string {
+	return e.Name
+}
+
+// an IRI constant, or a literal with a language tag or datatype
+type TermExpression struct {
+	URI turtle.URI
+}
+
+func NewTermExpression(term interface{}) (Expression, error) {
+	value, _ := ParseString(term)
+	return TermExpression{URI: parseIRI(value)}, nil
+}
+
+func NewLiteralTermExpression(str, lang, datatype interface{}) (Expression, error) {
+	var value string
+	if lang != nil {
+		value, _ = NewLangLiteral(str, lang)
+	} else {
+		value, _ = NewTypedLiteral(str, datatype)
+	}
+	return TermExpression{URI: turtle.ParseURI(value)}, nil
+}
+
+func (e TermExpression) Vars() []string {
+	return nil
+}
+
+func (e TermExpression) Transform(f func(Expression) Expression) Expression {
+	return f(e)
+}
+
+func (e TermExpression) String() string {
+	if e.URI.IsLiteral() {
+		return e.URI.String()
+	}
+	return "<" + e.URI.String() + ">"
+}
+
+type LiteralType uint
+
+const (
+	LITERAL_STRING LiteralType = iota + 1
+	LITERAL_INTEGER
+	LITERAL_DECIMAL
+	LITERAL_BOOLEAN
+)
+
+// a string, numeric or boolean constant. Value holds the lexical form
+type LiteralExpression struct {
+	Value string
+	Type  LiteralType
+}
+
+func NewStringLiteral(str interface{}) (Expression, error) {
+	lit := string(str.(*token.Token).Lit)
+	value, err := strconv.Unquote(lit)
+	if err != nil {
+		// not a valid Go escape sequence; take the contents verbatim
+		value = strings.TrimSuffix(strings.TrimPrefix(lit, "\""), "\"")
+	}
+	return LiteralExpression{Value: value, Type: LITERAL_STRING}, nil
+}
+
+func NewIntegerLiteral(num interface{}) (Expression, error) {
+	return LiteralExpression{Value: string(num.(*token.Token).Lit), Type: LITERAL_INTEGER}, nil
+}
+
+func NewDecimalLiteral(num interface{}) (Expression, error) {
+	return LiteralExpression{Value: string(num.(*token.Token).Lit), Type: LITERAL_DECIMAL}, nil
+}
+
+func NewBooleanLiteral(b interface{}) (Expression, error) {
+	return LiteralExpression{Value: string(b.(*token.Token).Lit), Type: LITERAL_BOOLEAN}, nil
+}
+
+func (e LiteralExpression) Vars() []string {
+	return nil
+}
+
+func (e LiteralExpression) Transform(f func(Expression) Expression) Expression {
+	return f(e)
+}
+
+func (e LiteralExpression) String() string {
+	if e.Type == LITERAL_STRING {
+		return strconv.Quote(e.Value)
+	}
+	return e.Value
+}
+
+func mergeVars(a, b []string) []string {
+	for _, v := range b {
+		found := false
+		for _, existing := range a {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a = append(a, v)
+		}
+	}
+	return a
+}
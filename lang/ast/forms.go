@@ -0,0 +1,146 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/gtfierro/hod/turtle"
+)
+
+// CONSTRUCT { template }: the triples that are instantiated with each
+// solution of the WHERE clause
+type ConstructClause struct {
+	Terms []Triple
+}
+
+func NewConstructClause(triples interface{}) (ConstructClause, error) {
+	return ConstructClause{
+		Terms: triples.([]Triple),
+	}, nil
+}
+
+// DESCRIBE ?var <iri> ...: the entities whose edges are returned. Variables
+// are bound by the WHERE clause
+type DescribeClause struct {
+	Terms []turtle.URI
+	// DESCRIBE *
+	AllVars bool
+}
+
+func NewDescribeClause(term interface{}) (DescribeClause, error) {
+	if term == nil {
+		return DescribeClause{AllVars: true}, nil
+	}
+	return DescribeClause{Terms: []turtle.URI{term.(turtle.URI)}}, nil
+}
+
+func AppendDescribeTerm(clause, term interface{}) (DescribeClause, error) {
+	dc := clause.(DescribeClause)
+	dc.Terms = append(dc.Terms, term.(turtle.URI))
+	return dc, nil
+}
+
+func (q Query) IsAsk() bool {
+	return (q.Type & ASK_QUERY) == ASK_QUERY
+}
+
+func (q Query) IsConstruct() bool {
+	return (q.Type & CONSTRUCT_QUERY) == CONSTRUCT_QUERY
+}
+
+func (q Query) IsDescribe() bool {
+	return (q.Type & DESCRIBE_QUERY) == DESCRIBE_QUERY
+}
+
+// ASK [FROM ...] [WHERE] { ... }. The WHERE keyword is optional, so the
+// pattern is either a WhereClause or a GraphGroup
+func NewAskQuery(fromclause, pattern interface{}) (Query, error) {
+	q := Query{
+		Select: SelectClause{AllVars: true},
+		Type:   ASK_QUERY,
+	}
+	if fromclause != nil {
+		q.From = fromclause.(FromClause)
+	}
+	if group, ok := pattern.(GraphGroup); ok {
+		where, err := NewWhereClause(group)
+		if err != nil {
+			return q, err
+		}
+		q.Where = where
+	} else {
+		q.Where = pattern.(WhereClause)
+	}
+	if q.From.Empty() {
+		q.From.AllDBs = true
+	}
+	q.PopulateVars()
+	q.Select.Vars = q.Variables
+	return q, nil
+}
+
+// CONSTRUCT { ... } [FROM ...] WHERE { ... }. Like updates, the query selects
+// the variables bound by the WHERE clause
+func NewConstructQuery(constructclause, fromclause, whereclause, modifier interface{}) (Query, error) {
+	q := Query{
+		Construct:        constructclause.(ConstructClause),
+		Where:            whereclause.(WhereClause),
+		Type:             CONSTRUCT_QUERY,
+		SolutionModifier: modifier.(SolutionModifier),
+	}
+	if fromclause != nil {
+		q.From = fromclause.(FromClause)
+	}
+	if q.From.Empty() {
+		q.From.AllDBs = true
+	}
+	q.PopulateVars()
+	q.selectWhereVars()
+	return q, q.checkAggregates()
+}
+
+// DESCRIBE ... [FROM ...] [WHERE { ... }]. Without a WHERE clause, only IRIs
+// can be described. The query selects the described variables
+func NewDescribeQuery(describeclause, fromclause, whereclause, modifier interface{}) (Query, error) {
+	q := Query{
+		Describe: describeclause.(DescribeClause),
+		Type:     DESCRIBE_QUERY,
+	}
+	if fromclause != nil {
+		q.From = fromclause.(FromClause)
+	}
+	if whereclause != nil {
+		q.Where = whereclause.(WhereClause)
+	}
+	if modifier != nil {
+		q.SolutionModifier = modifier.(SolutionModifier)
+	}
+	if q.From.Empty() {
+		q.From.AllDBs = true
+	}
+	q.PopulateVars()
+	if q.Describe.AllVars {
+		q.Select.Vars = q.Variables
+		return q, q.checkAggregates()
+	}
+	for _, term := range q.Describe.Terms {
+		if !term.IsVariable() {
+			continue
+		}
+		if !containsVar(q.Variables, term.String()) {
+			return q, fmt.Errorf("Variable %s in DESCRIBE is not bound by the WHERE clause", term)
+		}
+		if !containsVar(q.Select.Vars, term.String()) {
+			q.Select.Vars = append(q.Select.Vars, term.String())
+		}
+	}
+	return q, q.checkAggregates()
+}
+
+func containsVar(vars []string, varname string) bool {
+	for _, v := range vars {
+		if v == varname {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"strings"
+)
+
+// LinkSelection selects the links (key/value attributes such as a UUID) of
+// the entities bound to a variable, with ?var[key1,key2] or ?var[*]. Links do
+// not take part in the WHERE clause; they are looked up for the results
+type LinkSelection struct {
+	Var string
+	// the selected keys; nil for ?var[*]
+	Keys []string
+}
+
+// ?var[key,...] or, with nil keys, ?var[*]
+func NewLinkProjection(_var, keys interface{}) (Projection, error) {
+	ls := &LinkSelection{Var: _var.(string)}
+	if keys != nil {
+		ls.Keys = keys.([]string)
+	}
+	return Projection{Var: ls.Var, Links: ls}, nil
+}
+
+// true if the selection includes the link key. Keys are compared without
+// regard to case, so ?sensor[uuid] selects a UUID link
+func (ls LinkSelection) Matches(key string) bool {
+	if ls.Keys == nil {
+		return true
+	}
+	for _, k := range ls.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ls LinkSelection) String() string {
+	if ls.Keys == nil {
+		return ls.Var + "[*]"
+	}
+	return ls.Var + "[" + strings.Join(ls.Keys, ",") + "]"
+}
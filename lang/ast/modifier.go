@@ -0,0 +1,120 @@
+package ast
+
+import (
+	"strconv"
+
+	"github.com/gtfierro/hod/lang/token"
+	"github.com/pkg/errors"
+)
+
+// SolutionModifier holds the GROUP BY, HAVING, ORDER BY, LIMIT and OFFSET
+// clauses of a query
+type SolutionModifier struct {
+	GroupBy []string
+	// conditions on the groups; they may use aggregates
+	Having  []Expression
+	OrderBy []OrderCondition
+	// maximum number of rows to return; only meaningful if HasLimit is true
+	// (LIMIT 0 is a valid limit)
+	Limit    int
+	HasLimit bool
+	// number of rows to skip before returning results
+	Offset int
+}
+
+// OrderCondition is a single key of an ORDER BY clause
+type OrderCondition struct {
+	Expression Expression
+	Descending bool
+}
+
+func (oc OrderCondition) String() string {
+	if oc.Descending {
+		return "DESC(" + oc.Expression.String() + ")"
+	}
+	return "ASC(" + oc.Expression.String() + ")"
+}
+
+// returns the variables that the ORDER BY clause depends on
+func (sm SolutionModifier) OrderVars() []string {
+	var vars []string
+	for _, cond := range sm.OrderBy {
+		vars = mergeVars(vars, cond.Expression.Vars())
+	}
+	return vars
+}
+
+func NewOrderCondition(expr interface{}, descending bool) (OrderCondition, error) {
+	return OrderCondition{Expression: expr.(Expression), Descending: descending}, nil
+}
+
+func NewVarOrderCondition(_var interface{}) (OrderCondition, error) {
+	return OrderCondition{Expression: VarExpression{Name: _var.(string)}}, nil
+}
+
+func NewOrderConditionList(cond interface{}) ([]OrderCondition, error) {
+	return []OrderCondition{cond.(OrderCondition)}, nil
+}
+
+func AppendOrderCondition(list, cond interface{}) ([]OrderCondition, error) {
+	return append(list.([]OrderCondition), cond.(OrderCondition)), nil
+}
+
+func AddOrderBy(modifier, conditions interface{}) (SolutionModifier, error) {
+	sm := modifier.(SolutionModifier)
+	sm.OrderBy = conditions.([]OrderCondition)
+	return sm, nil
+}
+
+func AddGroupBy(modifier, vars interface{}) (SolutionModifier, error) {
+	sm := modifier.(SolutionModifier)
+	sm.GroupBy = vars.([]string)
+	return sm, nil
+}
+
+func AddHaving(modifier, conditions interface{}) (SolutionModifier, error) {
+	sm := modifier.(SolutionModifier)
+	sm.Having = conditions.([]Expression)
+	return sm, nil
+}
+
+// adds the LIMIT/OFFSET part built by NewLimitOffset to the modifier
+func AddLimitOffset(modifier, limitoffset interface{}) (SolutionModifier, error) {
+	sm := modifier.(SolutionModifier)
+	lo := limitoffset.(SolutionModifier)
+	sm.Limit, sm.HasLimit, sm.Offset = lo.Limit, lo.HasLimit, lo.Offset
+	return sm, nil
+}
+
+// builds the LIMIT/OFFSET part of a SolutionModifier. Either argument may be
+// nil if the clause is absent
+func NewLimitOffset(limit, offset interface{}) (SolutionModifier, error) {
+	var (
+		sm  SolutionModifier
+		err error
+	)
+	if limit != nil {
+		sm.HasLimit = true
+		if sm.Limit, err = parseNonNegative(limit); err != nil {
+			return sm, errors.Wrap(err, "Invalid LIMIT")
+		}
+	}
+	if offset != nil {
+		if sm.Offset, err = parseNonNegative(offset); err != nil {
+			return sm, errors.Wrap(err, "Invalid OFFSET")
+		}
+	}
+	return sm, nil
+}
+
+func parseNonNegative(num interface{}) (int, error) {
+	lit := string(num.(*token.Token).Lit)
+	n, err := strconv.Atoi(lit)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, errors.Errorf("%s is negative", lit)
+	}
+	return n, nil
+}
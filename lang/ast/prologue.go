@@ -0,0 +1,158 @@
+package ast
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gtfierro/hod/lang/token"
+	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
+)
+
+// Prologue holds the BASE and PREFIX declarations of a query. Prefixes map
+// to namespaces without the trailing '#', like the namespaces of a dataset
+type Prologue struct {
+	Base     string
+	Prefixes map[string]string
+}
+
+// relative IRIs are kept in a URI with this namespace until the query is
+// complete and they can be resolved against its BASE
+const relativeNamespace = "<>"
+
+func NewPrologue() (Prologue, error) {
+	return Prologue{}, nil
+}
+
+func AddPrefixDecl(prologue, prefix, iri interface{}) (Prologue, error) {
+	p := prologue.(Prologue)
+	name := strings.TrimSuffix(string(prefix.(*token.Token).Lit), ":")
+	namespace, err := p.resolve(string(iri.(*token.Token).Lit))
+	if err != nil {
+		return p, errors.Wrapf(err, "Invalid IRI for prefix %s", name)
+	}
+	// later declarations of the same prefix win; copy so that the prologue
+	// stays a value
+	prefixes := make(map[string]string, len(p.Prefixes)+1)
+	for k, v := range p.Prefixes {
+		prefixes[k] = v
+	}
+	prefixes[name] = strings.TrimRight(namespace, "#")
+	p.Prefixes = prefixes
+	return p, nil
+}
+
+func SetBase(prologue, iri interface{}) (Prologue, error) {
+	p := prologue.(Prologue)
+	base, err := p.resolve(string(iri.(*token.Token).Lit))
+	if err != nil {
+		return p, errors.Wrap(err, "Invalid BASE")
+	}
+	p.Base = base
+	return p, nil
+}
+
+// resolves an IRI in angle brackets against the BASE. Relative IRIs without
+// a BASE are an error
+func (p Prologue) resolve(iri string) (string, error) {
+	ref, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(iri, "<"), ">"))
+	if err != nil {
+		return "", err
+	}
+	if ref.IsAbs() {
+		return ref.String(), nil
+	}
+	if p.Base == "" {
+		return "", errors.Errorf("Relative IRI %s used without a BASE", iri)
+	}
+	base, err := url.Parse(p.Base)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// sets the prologue of the query and resolves its relative IRIs
+func AddPrologue(query, prologue interface{}) (Query, error) {
+	q := query.(Query)
+	q.Prologue = prologue.(Prologue)
+	var err error
+	q.MapURIs(func(uri turtle.URI) turtle.URI {
+		if uri.Namespace != relativeNamespace || err != nil {
+			return uri
+		}
+		var iri string
+		if iri, err = q.resolve("<" + uri.Value + ">"); err != nil {
+			return uri
+		}
+		return turtle.ParseURI(iri)
+	})
+	return q, err
+}
+
+// parses an IRI, a prefixed name or a literal. Relative IRIs are resolved
+// later by AddPrologue
+func parseIRI(value string) turtle.URI {
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		iri := value[1 : len(value)-1]
+		if ref, err := url.Parse(iri); err == nil && !ref.IsAbs() {
+			return turtle.URI{Namespace: relativeNamespace, Value: iri}
+		}
+	}
+	return turtle.ParseURI(value)
+}
+
+// applies f to every IRI and literal in the triples, VALUES, filters, BINDs,
+// projections, HAVING and ORDER BY conditions and DESCRIBE targets of the
+// query and its subqueries. Variables are passed to f too
+func (q Query) MapURIs(f func(turtle.URI) turtle.URI) {
+	q.IterTriples(func(triple Triple) Triple {
+		triple.Subject = f(triple.Subject)
+		triple.Object = f(triple.Object)
+		for idx, pred := range triple.Predicates {
+			triple.Predicates[idx] = pred.MapPredicates(f)
+		}
+		return triple
+	})
+
+	mapTerms := func(expr Expression) Expression {
+		if term, ok := expr.(TermExpression); ok {
+			term.URI = f(term.URI)
+			return term
+		}
+		return expr
+	}
+	q.IterFilters(func(filter Filter) Filter {
+		filter.Expression = filter.Expression.Transform(mapTerms)
+		return filter
+	})
+	q.IterBinds(func(bind Bind) Bind {
+		bind.Expression = bind.Expression.Transform(mapTerms)
+		return bind
+	})
+	for idx, cond := range q.OrderBy {
+		q.OrderBy[idx].Expression = cond.Expression.Transform(mapTerms)
+	}
+	for idx, p := range q.Select.Projections {
+		q.Select.Projections[idx].Expression = p.Expression.Transform(mapTerms)
+	}
+	for idx, expr := range q.Having {
+		q.Having[idx] = expr.Transform(mapTerms)
+	}
+	q.IterValues(func(values ValuesClause) {
+		for _, row := range values.Rows {
+			for idx, value := range row {
+				if value != (turtle.URI{}) {
+					row[idx] = f(value)
+				}
+			}
+		}
+	})
+	for idx, term := range q.Describe.Terms {
+		q.Describe.Terms[idx] = f(term)
+	}
+	q.IterSubqueries(func(sub Query) Query {
+		sub.MapURIs(f)
+		return sub
+	})
+}
@@ -0,0 +1,58 @@
+package ast
+
+// { SELECT ... WHERE { ... } }
+// A subquery is evaluated on its own and its solutions, projected to the
+// variables it selects, are joined with the rest of the enclosing group
+func NewSubqueryGroup(selectclause, whereclause, modifier interface{}) (GraphGroup, error) {
+	q, err := NewQuery(selectclause, whereclause, modifier, false)
+	if err != nil {
+		return GraphGroup{}, err
+	}
+	return GraphGroup{Subqueries: []Query{q}}, nil
+}
+
+// true if the group consists of nothing but subqueries, in which case it can
+// be merged into the enclosing group
+func (grp GraphGroup) onlySubqueries() bool {
+	return len(grp.Subqueries) > 0 && len(grp.Terms) == 0 && len(grp.Filters) == 0 &&
+		len(grp.Optionals) == 0 && len(grp.Unions) == 0 && len(grp.Values) == 0 &&
+		len(grp.Minus) == 0 && len(grp.Exists) == 0 && len(grp.Binds) == 0
+}
+
+// calls f with each subquery of the query, including those nested in its
+// groups. Subqueries of the subqueries are not visited
+func (q Query) IterSubqueries(f func(sub Query) Query) {
+	for idx, sub := range q.Where.Subqueries {
+		q.Where.Subqueries[idx] = f(sub)
+	}
+	for _, optional := range q.Where.Optionals {
+		optional.IterSubqueries(f)
+	}
+	for _, group := range q.Where.Minus {
+		group.IterSubqueries(f)
+	}
+	for idx := range q.Where.Exists {
+		q.Where.Exists[idx].IterSubqueries(f)
+	}
+	if q.Where.GraphGroup != nil {
+		q.Where.GraphGroup.IterSubqueries(f)
+	}
+}
+
+func (grp *GraphGroup) IterSubqueries(f func(sub Query) Query) {
+	for idx, sub := range grp.Subqueries {
+		grp.Subqueries[idx] = f(sub)
+	}
+	for _, optional := range grp.Optionals {
+		optional.IterSubqueries(f)
+	}
+	for _, union := range grp.Unions {
+		union.IterSubqueries(f)
+	}
+	for _, group := range grp.Minus {
+		group.IterSubqueries(f)
+	}
+	for idx := range grp.Exists {
+		grp.Exists[idx].IterSubqueries(f)
+	}
+}
@@ -0,0 +1,154 @@
+package ast
+
+import (
+	"github.com/gtfierro/hod/turtle"
+	"github.com/pkg/errors"
+)
+
+// ValuesClause holds the inline bindings of a VALUES block. Each row has a
+// value for each of Vars; the query is evaluated as if it had been run once
+// for every row. UNDEF values are the zero URI and leave their variable
+// unbound
+type ValuesClause struct {
+	Vars []string
+	Rows [][]turtle.URI
+}
+
+// VALUES ?var { value ... }
+func NewSingleVarValues(_var, values interface{}) (ValuesClause, error) {
+	vc := ValuesClause{Vars: []string{_var.(string)}}
+	for _, value := range values.([]turtle.URI) {
+		vc.Rows = append(vc.Rows, []turtle.URI{value})
+	}
+	return vc, nil
+}
+
+// VALUES (?var ...) { (value ...) ... }
+func NewValuesClause(varlist, rows interface{}) (ValuesClause, error) {
+	vc := ValuesClause{Vars: varlist.([]string), Rows: rows.([][]turtle.URI)}
+	for idx, varname := range vc.Vars {
+		for _, other := range vc.Vars[:idx] {
+			if other == varname {
+				return vc, errors.Errorf("Variable %s is repeated in VALUES", varname)
+			}
+		}
+	}
+	for _, row := range vc.Rows {
+		if len(row) != len(vc.Vars) {
+			return vc, errors.Errorf("VALUES row %v has %d values for %d variables", row, len(row), len(vc.Vars))
+		}
+	}
+	return vc, nil
+}
+
+func NewValuesRow() ([]turtle.URI, error) {
+	return []turtle.URI{}, nil
+}
+
+func AppendValuesRow(row, term interface{}) ([]turtle.URI, error) {
+	return append(row.([]turtle.URI), parseIRI(term.(string))), nil
+}
+
+// UNDEF
+func AppendUndefValue(row interface{}) ([]turtle.URI, error) {
+	return append(row.([]turtle.URI), turtle.URI{}), nil
+}
+
+func NewValuesRows() ([][]turtle.URI, error) {
+	return [][]turtle.URI{}, nil
+}
+
+func AppendValuesRows(rows, row interface{}) ([][]turtle.URI, error) {
+	return append(rows.([][]turtle.URI), row.([]turtle.URI)), nil
+}
+
+// splits the rows by the variables they leave UNDEF. Each of the returned
+// clauses only has the variables that its rows bind, so a variable that is
+// UNDEF in a row is free to take any value in the rest of the query
+func (vc ValuesClause) Partition() []ValuesClause {
+	var (
+		clauses []ValuesClause
+		byBound = make(map[string]int)
+	)
+	for _, row := range vc.Rows {
+		var (
+			key    []byte
+			bound  []string
+			values []turtle.URI
+		)
+		for idx, value := range row {
+			if value == (turtle.URI{}) {
+				key = append(key, 0)
+				continue
+			}
+			key = append(key, 1)
+			bound = append(bound, vc.Vars[idx])
+			values = append(values, value)
+		}
+		pos, found := byBound[string(key)]
+		if !found {
+			pos = len(clauses)
+			byBound[string(key)] = pos
+			clauses = append(clauses, ValuesClause{Vars: bound})
+		}
+		clauses[pos].Rows = append(clauses[pos].Rows, values)
+	}
+	return clauses
+}
+
+// checks that the group and the OPTIONAL, MINUS and EXISTS groups nested in
+// it have at most one VALUES block each, and that no UNION branch has one
+func (grp GraphGroup) checkValues() error {
+	if len(grp.Values) > 1 {
+		return errors.New("Only one VALUES block is supported")
+	}
+	for _, group := range grp.Unions {
+		if group.hasValues() {
+			return errors.New("VALUES is not supported in a UNION")
+		}
+	}
+	for _, group := range grp.Optionals {
+		if err := group.checkValues(); err != nil {
+			return err
+		}
+	}
+	for _, group := range grp.Minus {
+		if err := group.checkValues(); err != nil {
+			return err
+		}
+	}
+	for _, group := range grp.Exists {
+		if err := group.checkValues(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// true if the group or any group nested in it has a VALUES block
+func (grp GraphGroup) hasValues() bool {
+	if len(grp.Values) > 0 {
+		return true
+	}
+	for _, group := range grp.Optionals {
+		if group.hasValues() {
+			return true
+		}
+	}
+	for _, group := range grp.Unions {
+		if group.hasValues() {
+			return true
+		}
+	}
+	for _, group := range grp.Minus {
+		if group.hasValues() {
+			return true
+		}
+	}
+	for _, group := range grp.Exists {
+		if group.hasValues() {
+			return true
+		}
+	}
+	return false
+}
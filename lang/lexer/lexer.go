@@ -3,7 +3,7 @@
 package lexer
 
 import (
-	"io/ioutil"
+	"os"
 	"unicode/utf8"
 
 	"github.com/gtfierro/hod/lang/token"
@@ -11,40 +11,55 @@ import (
 
 const (
 	NoState    = -1
-	NumStates  = 62
-	NumSymbols = 71
+	NumStates  = 252
+	NumSymbols = 316
 )
 
 type Lexer struct {
-	src    []byte
-	pos    int
-	line   int
-	column int
+	src     []byte
+	pos     int
+	line    int
+	column  int
+	Context token.Context
 }
 
 func NewLexer(src []byte) *Lexer {
 	lexer := &Lexer{
-		src:    src,
-		pos:    0,
-		line:   1,
-		column: 1,
+		src:     src,
+		pos:     0,
+		line:    1,
+		column:  1,
+		Context: nil,
 	}
 	return lexer
 }
 
+// SourceContext is a simple instance of a token.Context which
+// contains the name of the source file.
+type SourceContext struct {
+	Filepath string
+}
+
+func (s *SourceContext) Source() string {
+	return s.Filepath
+}
+
 func NewLexerFile(fpath string) (*Lexer, error) {
-	src, err := ioutil.ReadFile(fpath)
+	src, err := os.ReadFile(fpath)
 	if err != nil {
 		return nil, err
 	}
-	return NewLexer(src), nil
+	lexer := NewLexer(src)
+	lexer.Context = &SourceContext{Filepath: fpath}
+	return lexer, nil
 }
 
 func (l *Lexer) Scan() (tok *token.Token) {
-	tok = new(token.Token)
+	tok = &token.Token{}
 	if l.pos >= len(l.src) {
 		tok.Type = token.EOF
 		tok.Pos.Offset, tok.Pos.Line, tok.Pos.Column = l.pos, l.line, l.column
+		tok.Pos.Context = l.Context
 		return
 	}
 	start, startLine, startColumn, end := l.pos, l.line, l.column, 0
@@ -103,6 +118,7 @@ func (l *Lexer) Scan() (tok *token.Token) {
 		tok.Lit = []byte{}
 	}
 	tok.Pos.Offset, tok.Pos.Line, tok.Pos.Column = start, startLine, startColumn
+	tok.Pos.Context = l.Context
 
 	return
 }
@@ -115,73 +131,318 @@ func (l *Lexer) Reset() {
 Lexer symbols:
 0: '?'
 1: ':'
-2: '<'
-3: '>'
-4: ';'
-5: 'S'
-6: 'E'
-7: 'L'
-8: 'E'
-9: 'C'
-10: 'T'
-11: '*'
-12: 'I'
-13: 'N'
-14: 'S'
-15: 'E'
-16: 'R'
-17: 'T'
-18: '{'
-19: '}'
-20: '.'
-21: 'C'
-22: 'O'
-23: 'U'
-24: 'N'
-25: 'T'
-26: 'F'
-27: 'R'
-28: 'O'
-29: 'M'
-30: 'W'
-31: 'H'
+2: ':'
+3: '<'
+4: '>'
+5: '+'
+6: '-'
+7: '+'
+8: '-'
+9: '.'
+10: '@'
+11: '-'
+12: 'N'
+13: 'O'
+14: 'C'
+15: 'A'
+16: 'C'
+17: 'H'
+18: 'E'
+19: 'E'
+20: 'X'
+21: 'P'
+22: 'L'
+23: 'A'
+24: 'I'
+25: 'N'
+26: 'A'
+27: 'N'
+28: 'A'
+29: 'L'
+30: 'Y'
+31: 'Z'
 32: 'E'
-33: 'R'
-34: 'E'
-35: '|'
-36: '/'
-37: 'a'
-38: '('
-39: ')'
-40: '?'
-41: '+'
-42: 'U'
-43: 'N'
-44: 'I'
-45: 'O'
-46: 'N'
-47: '"'
-48: '_'
-49: '-'
-50: '_'
-51: '\'
-52: '-'
-53: '#'
-54: '%'
-55: '$'
-56: '@'
-57: '_'
-58: '-'
-59: ' '
-60: ':'
-61: '"'
-62: '"'
-63: '\t'
-64: '\n'
-65: '\r'
-66: ' '
-67: 'A'-'Z'
-68: 'a'-'z'
-69: '0'-'9'
-70: .
+33: ';'
+34: 'B'
+35: 'A'
+36: 'S'
+37: 'E'
+38: 'P'
+39: 'R'
+40: 'E'
+41: 'F'
+42: 'I'
+43: 'X'
+44: 'A'
+45: 'S'
+46: 'K'
+47: 'S'
+48: 'E'
+49: 'L'
+50: 'E'
+51: 'C'
+52: 'T'
+53: '*'
+54: 'I'
+55: 'N'
+56: 'S'
+57: 'E'
+58: 'R'
+59: 'T'
+60: '{'
+61: '}'
+62: '.'
+63: 'C'
+64: 'O'
+65: 'N'
+66: 'S'
+67: 'T'
+68: 'R'
+69: 'U'
+70: 'C'
+71: 'T'
+72: 'D'
+73: 'E'
+74: 'S'
+75: 'C'
+76: 'R'
+77: 'I'
+78: 'B'
+79: 'E'
+80: 'D'
+81: 'E'
+82: 'L'
+83: 'E'
+84: 'T'
+85: 'E'
+86: 'D'
+87: 'A'
+88: 'T'
+89: 'A'
+90: '['
+91: ']'
+92: '('
+93: 'A'
+94: 'S'
+95: ')'
+96: ','
+97: 'C'
+98: 'O'
+99: 'U'
+100: 'N'
+101: 'T'
+102: 'F'
+103: 'R'
+104: 'O'
+105: 'M'
+106: 'W'
+107: 'H'
+108: 'E'
+109: 'R'
+110: 'E'
+111: 'G'
+112: 'R'
+113: 'O'
+114: 'U'
+115: 'P'
+116: 'B'
+117: 'Y'
+118: 'H'
+119: 'A'
+120: 'V'
+121: 'I'
+122: 'N'
+123: 'G'
+124: 'O'
+125: 'R'
+126: 'D'
+127: 'E'
+128: 'R'
+129: 'A'
+130: 'S'
+131: 'C'
+132: 'D'
+133: 'E'
+134: 'S'
+135: 'C'
+136: 'L'
+137: 'I'
+138: 'M'
+139: 'I'
+140: 'T'
+141: 'O'
+142: 'F'
+143: 'F'
+144: 'S'
+145: 'E'
+146: 'T'
+147: 't'
+148: 'r'
+149: 'u'
+150: 'e'
+151: 'f'
+152: 'a'
+153: 'l'
+154: 's'
+155: 'e'
+156: '^'
+157: '^'
+158: '|'
+159: '/'
+160: '^'
+161: 'a'
+162: '?'
+163: '+'
+164: 'U'
+165: 'N'
+166: 'I'
+167: 'O'
+168: 'N'
+169: 'R'
+170: 'O'
+171: 'U'
+172: 'T'
+173: 'E'
+174: 'B'
+175: 'I'
+176: 'N'
+177: 'D'
+178: 'V'
+179: 'A'
+180: 'L'
+181: 'U'
+182: 'E'
+183: 'S'
+184: 'U'
+185: 'N'
+186: 'D'
+187: 'E'
+188: 'F'
+189: 'O'
+190: 'P'
+191: 'T'
+192: 'I'
+193: 'O'
+194: 'N'
+195: 'A'
+196: 'L'
+197: 'M'
+198: 'I'
+199: 'N'
+200: 'U'
+201: 'S'
+202: 'F'
+203: 'I'
+204: 'L'
+205: 'T'
+206: 'E'
+207: 'R'
+208: 'E'
+209: 'X'
+210: 'I'
+211: 'S'
+212: 'T'
+213: 'S'
+214: 'N'
+215: 'O'
+216: 'T'
+217: '|'
+218: '|'
+219: '&'
+220: '&'
+221: '='
+222: '!'
+223: '='
+224: '<'
+225: '>'
+226: '<'
+227: '='
+228: '>'
+229: '='
+230: '-'
+231: '!'
+232: 'D'
+233: 'I'
+234: 'S'
+235: 'T'
+236: 'I'
+237: 'N'
+238: 'C'
+239: 'T'
+240: 'G'
+241: 'R'
+242: 'O'
+243: 'U'
+244: 'P'
+245: '_'
+246: 'C'
+247: 'O'
+248: 'N'
+249: 'C'
+250: 'A'
+251: 'T'
+252: 'S'
+253: 'E'
+254: 'P'
+255: 'A'
+256: 'R'
+257: 'A'
+258: 'T'
+259: 'O'
+260: 'R'
+261: 'S'
+262: 'U'
+263: 'M'
+264: 'M'
+265: 'I'
+266: 'N'
+267: 'M'
+268: 'A'
+269: 'X'
+270: 'A'
+271: 'V'
+272: 'G'
+273: 'S'
+274: 'A'
+275: 'M'
+276: 'P'
+277: 'L'
+278: 'E'
+279: '"'
+280: '_'
+281: '-'
+282: '_'
+283: '\'
+284: '-'
+285: '#'
+286: '%'
+287: '$'
+288: '@'
+289: '_'
+290: '-'
+291: ' '
+292: ':'
+293: '\'
+294: '"'
+295: '"'
+296: '!'
+297: '='
+298: ']'
+299: '_'
+300: '~'
+301: '\t'
+302: '\n'
+303: '\r'
+304: ' '
+305: 'A'-'Z'
+306: 'a'-'z'
+307: '0'-'9'
+308: \u0000-'!'
+309: '#'-'['
+310: ']'-\U0010ffff
+311: '#'-';'
+312: '?'-'['
+313: 'a'-'z'
+314: \u0080-\U0010ffff
+315: .
 */
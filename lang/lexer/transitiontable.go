@@ -21,68 +21,114 @@ var TransTab = TransitionTable{
 			return 1
 		case r == 32: // [' ',' ']
 			return 1
-		case r == 34: // ['"','"']
+		case r == 33: // ['!','!']
 			return 2
-		case r == 40: // ['(','(']
+		case r == 34: // ['"','"']
 			return 3
-		case r == 41: // [')',')']
+		case r == 38: // ['&','&']
 			return 4
-		case r == 42: // ['*','*']
+		case r == 40: // ['(','(']
 			return 5
-		case r == 43: // ['+','+']
+		case r == 41: // [')',')']
 			return 6
-		case r == 45: // ['-','-']
+		case r == 42: // ['*','*']
 			return 7
-		case r == 46: // ['.','.']
+		case r == 43: // ['+','+']
 			return 8
-		case r == 47: // ['/','/']
+		case r == 44: // [',',',']
 			return 9
-		case 48 <= r && r <= 57: // ['0','9']
+		case r == 45: // ['-','-']
 			return 10
-		case r == 59: // [';',';']
+		case r == 46: // ['.','.']
 			return 11
-		case r == 60: // ['<','<']
+		case r == 47: // ['/','/']
 			return 12
-		case r == 63: // ['?','?']
+		case 48 <= r && r <= 57: // ['0','9']
 			return 13
-		case 65 <= r && r <= 66: // ['A','B']
+		case r == 59: // [';',';']
 			return 14
-		case r == 67: // ['C','C']
+		case r == 60: // ['<','<']
 			return 15
-		case 68 <= r && r <= 69: // ['D','E']
-			return 14
-		case r == 70: // ['F','F']
+		case r == 61: // ['=','=']
 			return 16
-		case 71 <= r && r <= 72: // ['G','H']
-			return 14
-		case r == 73: // ['I','I']
+		case r == 62: // ['>','>']
 			return 17
-		case 74 <= r && r <= 82: // ['J','R']
-			return 14
-		case r == 83: // ['S','S']
+		case r == 63: // ['?','?']
 			return 18
+		case r == 64: // ['@','@']
+			return 19
+		case r == 65: // ['A','A']
+			return 20
+		case r == 66: // ['B','B']
+			return 21
+		case r == 67: // ['C','C']
+			return 22
+		case r == 68: // ['D','D']
+			return 23
+		case r == 69: // ['E','E']
+			return 24
+		case r == 70: // ['F','F']
+			return 25
+		case r == 71: // ['G','G']
+			return 26
+		case r == 72: // ['H','H']
+			return 27
+		case r == 73: // ['I','I']
+			return 28
+		case 74 <= r && r <= 75: // ['J','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 30
+		case r == 77: // ['M','M']
+			return 31
+		case r == 78: // ['N','N']
+			return 32
+		case r == 79: // ['O','O']
+			return 33
+		case r == 80: // ['P','P']
+			return 34
+		case r == 81: // ['Q','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 35
+		case r == 83: // ['S','S']
+			return 36
 		case r == 84: // ['T','T']
-			return 14
+			return 29
 		case r == 85: // ['U','U']
-			return 19
+			return 37
 		case r == 86: // ['V','V']
-			return 14
+			return 38
 		case r == 87: // ['W','W']
-			return 20
+			return 39
 		case 88 <= r && r <= 90: // ['X','Z']
-			return 14
+			return 29
+		case r == 91: // ['[','[']
+			return 40
+		case r == 93: // [']',']']
+			return 41
+		case r == 94: // ['^','^']
+			return 42
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case r == 97: // ['a','a']
-			return 21
-		case 98 <= r && r <= 122: // ['b','z']
-			return 22
+			return 44
+		case 98 <= r && r <= 101: // ['b','e']
+			return 45
+		case r == 102: // ['f','f']
+			return 46
+		case 103 <= r && r <= 115: // ['g','s']
+			return 45
+		case r == 116: // ['t','t']
+			return 47
+		case 117 <= r && r <= 122: // ['u','z']
+			return 45
 		case r == 123: // ['{','{']
-			return 23
+			return 48
 		case r == 124: // ['|','|']
-			return 24
+			return 49
 		case r == 125: // ['}','}']
-			return 25
+			return 50
 		}
 		return NoState
 	},
@@ -95,21 +141,32 @@ var TransTab = TransitionTable{
 	// S2
 	func(r rune) int {
 		switch {
-		case r == 34: // ['"','"']
-			return 26
-		default:
-			return 2
+		case r == 61: // ['=','=']
+			return 51
 		}
+		return NoState
 	},
 	// S3
 	func(r rune) int {
 		switch {
+		case 0 <= r && r <= 33: // [\u0000,'!']
+			return 52
+		case r == 34: // ['"','"']
+			return 53
+		case 35 <= r && r <= 91: // ['#','[']
+			return 52
+		case r == 92: // ['\','\']
+			return 54
+		case 93 <= r && r <= 1114111: // [']',\U0010ffff]
+			return 52
 		}
 		return NoState
 	},
 	// S4
 	func(r rune) int {
 		switch {
+		case r == 38: // ['&','&']
+			return 55
 		}
 		return NoState
 	},
@@ -128,24 +185,16 @@ var TransTab = TransitionTable{
 	// S7
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
-		case r == 95: // ['_','_']
-			return 7
-		case 97 <= r && r <= 122: // ['a','z']
-			return 22
 		}
 		return NoState
 	},
 	// S8
 	func(r rune) int {
 		switch {
+		case r == 46: // ['.','.']
+			return 56
+		case 48 <= r && r <= 57: // ['0','9']
+			return 57
 		}
 		return NoState
 	},
@@ -159,132 +208,99 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
+		case r == 46: // ['.','.']
+			return 56
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 13
 		case r == 58: // [':',':']
-			return 27
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
 	// S11
 	func(r rune) int {
 		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 59
 		}
 		return NoState
 	},
 	// S12
 	func(r rune) int {
 		switch {
-		case r == 62: // ['>','>']
-			return 28
-		default:
-			return 12
 		}
+		return NoState
 	},
 	// S13
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 29
+			return 43
+		case r == 46: // ['.','.']
+			return 56
 		case 48 <= r && r <= 57: // ['0','9']
-			return 30
+			return 13
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 31
-		case r == 95: // ['_','_']
 			return 29
+		case r == 95: // ['_','_']
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 32
+			return 45
 		}
 		return NoState
 	},
 	// S14
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
-		case r == 95: // ['_','_']
-			return 7
-		case 97 <= r && r <= 122: // ['a','z']
-			return 22
 		}
 		return NoState
 	},
 	// S15
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 78: // ['A','N']
-			return 14
-		case r == 79: // ['O','O']
-			return 33
-		case 80 <= r && r <= 90: // ['P','Z']
-			return 14
+		case r == 33: // ['!','!']
+			return 60
+		case 35 <= r && r <= 59: // ['#',';']
+			return 60
+		case r == 61: // ['=','=']
+			return 61
+		case r == 62: // ['>','>']
+			return 62
+		case 63 <= r && r <= 91: // ['?','[']
+			return 60
+		case r == 93: // [']',']']
+			return 60
 		case r == 95: // ['_','_']
-			return 7
+			return 60
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 60
+		case r == 126: // ['~','~']
+			return 60
+		case 128 <= r && r <= 1114111: // [\u0080,\U0010ffff]
+			return 60
 		}
 		return NoState
 	},
 	// S16
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 81: // ['A','Q']
-			return 14
-		case r == 82: // ['R','R']
-			return 34
-		case 83 <= r && r <= 90: // ['S','Z']
-			return 14
-		case r == 95: // ['_','_']
-			return 7
-		case 97 <= r && r <= 122: // ['a','z']
-			return 22
 		}
 		return NoState
 	},
 	// S17
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 77: // ['A','M']
-			return 14
-		case r == 78: // ['N','N']
-			return 35
-		case 79 <= r && r <= 90: // ['O','Z']
-			return 14
-		case r == 95: // ['_','_']
-			return 7
-		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+		case r == 61: // ['=','=']
+			return 63
 		}
 		return NoState
 	},
@@ -292,43 +308,25 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 64
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 68: // ['A','D']
-			return 14
-		case r == 69: // ['E','E']
-			return 36
-		case 70 <= r && r <= 90: // ['F','Z']
-			return 14
+			return 65
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 66
 		case r == 95: // ['_','_']
-			return 7
+			return 64
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 67
 		}
 		return NoState
 	},
 	// S19
 	func(r rune) int {
 		switch {
-		case r == 45: // ['-','-']
-			return 7
-		case 48 <= r && r <= 57: // ['0','9']
-			return 10
-		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 77: // ['A','M']
-			return 14
-		case r == 78: // ['N','N']
-			return 37
-		case 79 <= r && r <= 90: // ['O','Z']
-			return 14
-		case r == 95: // ['_','_']
-			return 7
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 68
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 69
 		}
 		return NoState
 	},
@@ -336,21 +334,29 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 71: // ['A','G']
-			return 14
-		case r == 72: // ['H','H']
-			return 38
-		case 73 <= r && r <= 90: // ['I','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 71
+		case 79 <= r && r <= 82: // ['O','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 72
+		case 84 <= r && r <= 85: // ['T','U']
+			return 29
+		case r == 86: // ['V','V']
+			return 73
+		case 87 <= r && r <= 90: // ['W','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -358,17 +364,27 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case r == 65: // ['A','A']
+			return 74
+		case 66 <= r && r <= 72: // ['B','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 75
+		case 74 <= r && r <= 88: // ['J','X']
+			return 29
+		case r == 89: // ['Y','Y']
+			return 76
+		case r == 90: // ['Z','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -376,41 +392,119 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 77
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
 	// S23
 	func(r rune) int {
 		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 78
+		case 66 <= r && r <= 68: // ['B','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 79
+		case 70 <= r && r <= 72: // ['F','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 80
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
 	// S24
 	func(r rune) int {
 		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 87: // ['A','W']
+			return 29
+		case r == 88: // ['X','X']
+			return 81
+		case 89 <= r && r <= 90: // ['Y','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
 	// S25
 	func(r rune) int {
 		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 82
+		case 74 <= r && r <= 81: // ['J','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 83
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
 	// S26
 	func(r rune) int {
 		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 84
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
@@ -418,21 +512,41 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 39
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 40
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 41
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 85
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 39
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 42
+			return 45
 		}
 		return NoState
 	},
 	// S28
 	func(r rune) int {
 		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 86
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
@@ -440,15 +554,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 29
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 30
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 31
-		case r == 95: // ['_','_']
 			return 29
+		case r == 95: // ['_','_']
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 32
+			return 45
 		}
 		return NoState
 	},
@@ -456,15 +572,21 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 29
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 30
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 31
-		case r == 95: // ['_','_']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 87
+		case 74 <= r && r <= 90: // ['J','Z']
 			return 29
+		case r == 95: // ['_','_']
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 32
+			return 45
 		}
 		return NoState
 	},
@@ -472,15 +594,23 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 29
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 30
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 31
-		case r == 95: // ['_','_']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 88
+		case 66 <= r && r <= 72: // ['B','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 89
+		case 74 <= r && r <= 90: // ['J','Z']
 			return 29
+		case r == 95: // ['_','_']
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 32
+			return 45
 		}
 		return NoState
 	},
@@ -488,15 +618,21 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 29
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 30
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 31
-		case r == 95: // ['_','_']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 90
+		case 80 <= r && r <= 90: // ['P','Z']
 			return 29
+		case r == 95: // ['_','_']
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 32
+			return 45
 		}
 		return NoState
 	},
@@ -504,21 +640,29 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 84: // ['A','T']
-			return 14
-		case r == 85: // ['U','U']
-			return 43
-		case 86 <= r && r <= 90: // ['V','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 69: // ['A','E']
+			return 29
+		case r == 70: // ['F','F']
+			return 91
+		case 71 <= r && r <= 79: // ['G','O']
+			return 29
+		case r == 80: // ['P','P']
+			return 92
+		case r == 81: // ['Q','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 93
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -526,21 +670,21 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 78: // ['A','N']
-			return 14
-		case r == 79: // ['O','O']
-			return 44
-		case 80 <= r && r <= 90: // ['P','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 94
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -548,21 +692,21 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 82: // ['A','R']
-			return 14
-		case r == 83: // ['S','S']
-			return 45
-		case 84 <= r && r <= 90: // ['T','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 95
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -570,523 +714,4240 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 96
+		case 66 <= r && r <= 68: // ['B','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 97
+		case 70 <= r && r <= 84: // ['F','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 98
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S37
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 99
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S38
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 100
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S39
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 71: // ['A','G']
+			return 29
+		case r == 72: // ['H','H']
+			return 101
+		case 73 <= r && r <= 90: // ['I','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S40
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S41
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S42
+	func(r rune) int {
+		switch {
+		case r == 94: // ['^','^']
+			return 102
+		}
+		return NoState
+	},
+	// S43
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S44
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S45
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S46
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case r == 97: // ['a','a']
+			return 103
+		case 98 <= r && r <= 122: // ['b','z']
+			return 45
+		}
+		return NoState
+	},
+	// S47
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 113: // ['a','q']
+			return 45
+		case r == 114: // ['r','r']
+			return 104
+		case 115 <= r && r <= 122: // ['s','z']
+			return 45
+		}
+		return NoState
+	},
+	// S48
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S49
+	func(r rune) int {
+		switch {
+		case r == 124: // ['|','|']
+			return 105
+		}
+		return NoState
+	},
+	// S50
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S51
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S52
+	func(r rune) int {
+		switch {
+		case 0 <= r && r <= 33: // [\u0000,'!']
+			return 52
+		case r == 34: // ['"','"']
+			return 53
+		case 35 <= r && r <= 91: // ['#','[']
+			return 52
+		case r == 92: // ['\','\']
+			return 54
+		case 93 <= r && r <= 1114111: // [']',\U0010ffff]
+			return 52
+		}
+		return NoState
+	},
+	// S53
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S54
+	func(r rune) int {
+		switch {
+		default:
+			return 52
+		}
+	},
+	// S55
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S56
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 59
+		}
+		return NoState
+	},
+	// S57
+	func(r rune) int {
+		switch {
+		case r == 46: // ['.','.']
+			return 56
+		case 48 <= r && r <= 57: // ['0','9']
+			return 57
+		}
+		return NoState
+	},
+	// S58
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 106
+		case 48 <= r && r <= 57: // ['0','9']
+			return 107
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 108
+		case r == 95: // ['_','_']
+			return 106
+		case 97 <= r && r <= 122: // ['a','z']
+			return 109
+		}
+		return NoState
+	},
+	// S59
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 59
+		}
+		return NoState
+	},
+	// S60
+	func(r rune) int {
+		switch {
+		case r == 33: // ['!','!']
+			return 60
+		case 35 <= r && r <= 59: // ['#',';']
+			return 60
+		case r == 61: // ['=','=']
+			return 60
+		case r == 62: // ['>','>']
+			return 62
+		case 63 <= r && r <= 91: // ['?','[']
+			return 60
+		case r == 93: // [']',']']
+			return 60
+		case r == 95: // ['_','_']
+			return 60
+		case 97 <= r && r <= 122: // ['a','z']
+			return 60
+		case r == 126: // ['~','~']
+			return 60
+		case 128 <= r && r <= 1114111: // [\u0080,\U0010ffff]
+			return 60
+		}
+		return NoState
+	},
+	// S61
+	func(r rune) int {
+		switch {
+		case r == 33: // ['!','!']
+			return 60
+		case 35 <= r && r <= 59: // ['#',';']
+			return 60
+		case r == 61: // ['=','=']
+			return 60
+		case r == 62: // ['>','>']
+			return 62
+		case 63 <= r && r <= 91: // ['?','[']
+			return 60
+		case r == 93: // [']',']']
+			return 60
+		case r == 95: // ['_','_']
+			return 60
+		case 97 <= r && r <= 122: // ['a','z']
+			return 60
+		case r == 126: // ['~','~']
+			return 60
+		case 128 <= r && r <= 1114111: // [\u0080,\U0010ffff]
+			return 60
+		}
+		return NoState
+	},
+	// S62
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S63
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S64
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 64
+		case 48 <= r && r <= 57: // ['0','9']
+			return 65
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 66
+		case r == 95: // ['_','_']
+			return 64
+		case 97 <= r && r <= 122: // ['a','z']
+			return 67
+		}
+		return NoState
+	},
+	// S65
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 64
+		case 48 <= r && r <= 57: // ['0','9']
+			return 65
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 66
+		case r == 95: // ['_','_']
+			return 64
+		case 97 <= r && r <= 122: // ['a','z']
+			return 67
+		}
+		return NoState
+	},
+	// S66
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 64
+		case 48 <= r && r <= 57: // ['0','9']
+			return 65
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 66
+		case r == 95: // ['_','_']
+			return 64
+		case 97 <= r && r <= 122: // ['a','z']
+			return 67
+		}
+		return NoState
+	},
+	// S67
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 64
+		case 48 <= r && r <= 57: // ['0','9']
+			return 65
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 66
+		case r == 95: // ['_','_']
+			return 64
+		case 97 <= r && r <= 122: // ['a','z']
+			return 67
+		}
+		return NoState
+	},
+	// S68
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 110
+		case 48 <= r && r <= 57: // ['0','9']
+			return 111
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 68
+		case 97 <= r && r <= 122: // ['a','z']
+			return 69
+		}
+		return NoState
+	},
+	// S69
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 110
+		case 48 <= r && r <= 57: // ['0','9']
+			return 111
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 68
+		case 97 <= r && r <= 122: // ['a','z']
+			return 69
+		}
+		return NoState
+	},
+	// S70
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S71
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 112
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S72
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 113
+		case 68 <= r && r <= 74: // ['D','J']
+			return 29
+		case r == 75: // ['K','K']
+			return 114
+		case 76 <= r && r <= 90: // ['L','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S73
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 70: // ['A','F']
+			return 29
+		case r == 71: // ['G','G']
+			return 115
+		case 72 <= r && r <= 90: // ['H','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S74
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 116
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S75
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 117
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S76
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S77
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 118
+		case 79 <= r && r <= 84: // ['O','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 119
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S78
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 120
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S79
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 121
+		case 77 <= r && r <= 82: // ['M','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 122
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S80
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 123
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S81
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 124
+		case 74 <= r && r <= 79: // ['J','O']
+			return 29
+		case r == 80: // ['P','P']
+			return 125
+		case 81 <= r && r <= 90: // ['Q','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S82
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 126
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S83
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 127
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S84
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 128
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S85
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 85: // ['A','U']
+			return 29
+		case r == 86: // ['V','V']
+			return 129
+		case 87 <= r && r <= 90: // ['W','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S86
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 130
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S87
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 76: // ['A','L']
+			return 29
+		case r == 77: // ['M','M']
+			return 131
+		case 78 <= r && r <= 90: // ['N','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S88
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 87: // ['A','W']
+			return 29
+		case r == 88: // ['X','X']
+			return 132
+		case 89 <= r && r <= 90: // ['Y','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S89
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 133
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S90
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 134
+		case 68 <= r && r <= 83: // ['D','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 135
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S91
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 69: // ['A','E']
+			return 29
+		case r == 70: // ['F','F']
+			return 136
+		case 71 <= r && r <= 90: // ['G','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S92
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 137
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S93
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 67: // ['A','C']
+			return 29
+		case r == 68: // ['D','D']
+			return 138
+		case 69 <= r && r <= 90: // ['E','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S94
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 139
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S95
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 84: // ['A','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 140
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S96
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 76: // ['A','L']
+			return 29
+		case r == 77: // ['M','M']
+			return 141
+		case 78 <= r && r <= 90: // ['N','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S97
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 142
+		case 77 <= r && r <= 79: // ['M','O']
+			return 29
+		case r == 80: // ['P','P']
+			return 143
+		case 81 <= r && r <= 90: // ['Q','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S98
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 76: // ['A','L']
+			return 29
+		case r == 77: // ['M','M']
+			return 144
+		case 78 <= r && r <= 90: // ['N','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S99
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 67: // ['A','C']
+			return 29
+		case r == 68: // ['D','D']
+			return 145
+		case 69 <= r && r <= 72: // ['E','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 146
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S100
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 147
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S101
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 148
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S102
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S103
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 107: // ['a','k']
+			return 45
+		case r == 108: // ['l','l']
+			return 149
+		case 109 <= r && r <= 122: // ['m','z']
+			return 45
+		}
+		return NoState
+	},
+	// S104
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 116: // ['a','t']
+			return 45
+		case r == 117: // ['u','u']
+			return 150
+		case 118 <= r && r <= 122: // ['v','z']
+			return 45
+		}
+		return NoState
+	},
+	// S105
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S106
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 106
+		case 48 <= r && r <= 57: // ['0','9']
+			return 107
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 108
+		case r == 95: // ['_','_']
+			return 106
+		case 97 <= r && r <= 122: // ['a','z']
+			return 109
+		}
+		return NoState
+	},
+	// S107
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 106
+		case 48 <= r && r <= 57: // ['0','9']
+			return 107
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 108
+		case r == 95: // ['_','_']
+			return 106
+		case 97 <= r && r <= 122: // ['a','z']
+			return 109
+		}
+		return NoState
+	},
+	// S108
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 106
+		case 48 <= r && r <= 57: // ['0','9']
+			return 107
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 108
+		case r == 95: // ['_','_']
+			return 106
+		case 97 <= r && r <= 122: // ['a','z']
+			return 109
+		}
+		return NoState
+	},
+	// S109
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 106
+		case 48 <= r && r <= 57: // ['0','9']
+			return 107
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 108
+		case r == 95: // ['_','_']
+			return 106
+		case 97 <= r && r <= 122: // ['a','z']
+			return 109
+		}
+		return NoState
+	},
+	// S110
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 110
+		case 48 <= r && r <= 57: // ['0','9']
+			return 111
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 68
+		case 97 <= r && r <= 122: // ['a','z']
+			return 69
+		}
+		return NoState
+	},
+	// S111
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 110
+		case 48 <= r && r <= 57: // ['0','9']
+			return 111
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 68
+		case 97 <= r && r <= 122: // ['a','z']
+			return 69
+		}
+		return NoState
+	},
+	// S112
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 151
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S113
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S114
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S115
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S116
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 152
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S117
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 67: // ['A','C']
+			return 29
+		case r == 68: // ['D','D']
+			return 153
+		case 69 <= r && r <= 90: // ['E','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S118
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 154
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S119
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 155
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S120
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 156
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S121
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 157
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S122
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 158
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S123
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 159
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S124
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 160
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S125
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 161
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S126
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 162
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S127
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 76: // ['A','L']
+			return 29
+		case r == 77: // ['M','M']
+			return 163
+		case 78 <= r && r <= 90: // ['N','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S128
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 84: // ['A','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 164
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S129
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 165
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S130
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 166
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S131
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 167
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S132
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S133
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 84: // ['A','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 168
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S134
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 169
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S135
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S136
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 170
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S137
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 171
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S138
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 172
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S139
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 69: // ['A','E']
+			return 29
+		case r == 70: // ['F','F']
+			return 173
+		case 71 <= r && r <= 90: // ['G','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S140
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 174
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S141
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 79: // ['A','O']
+			return 29
+		case r == 80: // ['P','P']
+			return 175
+		case 81 <= r && r <= 90: // ['Q','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S142
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 176
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S143
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 177
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S144
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S145
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 178
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S146
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 179
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S147
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 84: // ['A','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 180
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S148
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 181
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S149
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 114: // ['a','r']
+			return 45
+		case r == 115: // ['s','s']
+			return 182
+		case 116 <= r && r <= 122: // ['t','z']
+			return 45
+		}
+		return NoState
+	},
+	// S150
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 100: // ['a','d']
+			return 45
+		case r == 101: // ['e','e']
+			return 183
+		case 102 <= r && r <= 122: // ['f','z']
+			return 45
+		}
+		return NoState
+	},
+	// S151
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 88: // ['A','X']
+			return 29
+		case r == 89: // ['Y','Y']
+			return 184
+		case r == 90: // ['Z','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S152
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S153
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S154
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 185
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S155
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 186
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S156
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S157
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 187
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S158
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 188
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S159
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 189
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S160
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 190
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S161
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 191
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S162
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 192
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S163
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S164
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 79: // ['A','O']
+			return 29
+		case r == 80: // ['P','P']
+			return 193
+		case 81 <= r && r <= 90: // ['Q','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S165
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 194
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S166
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 195
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S167
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 196
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S168
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 197
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S169
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 198
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S170
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 199
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S171
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 200
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S172
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 201
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S173
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 202
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S174
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 203
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S175
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 204
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S176
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 205
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S177
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 206
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S178
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 69: // ['A','E']
+			return 29
+		case r == 70: // ['F','F']
+			return 207
+		case 71 <= r && r <= 90: // ['G','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S179
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 208
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S180
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 209
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S181
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 210
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S182
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 100: // ['a','d']
+			return 45
+		case r == 101: // ['e','e']
+			return 211
+		case 102 <= r && r <= 122: // ['f','z']
+			return 45
+		}
+		return NoState
+	},
+	// S183
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S184
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 89: // ['A','Y']
+			return 29
+		case r == 90: // ['Z','Z']
+			return 212
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S185
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 213
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S186
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S187
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 214
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S188
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 215
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S189
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 216
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S190
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 217
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S191
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 72: // ['A','H']
+			return 29
+		case r == 73: // ['I','I']
+			return 218
+		case 74 <= r && r <= 90: // ['J','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S192
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 219
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S193
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 220
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S194
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 70: // ['A','F']
+			return 29
+		case r == 71: // ['G','G']
+			return 221
+		case 72 <= r && r <= 90: // ['H','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S195
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 222
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S196
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S197
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S198
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 71: // ['A','G']
+			return 29
+		case r == 72: // ['H','H']
+			return 223
+		case 73 <= r && r <= 90: // ['I','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S199
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 224
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S200
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 225
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S201
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S202
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 87: // ['A','W']
+			return 29
+		case r == 88: // ['X','X']
+			return 226
+		case 89 <= r && r <= 90: // ['Y','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S203
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S204
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 227
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S205
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 228
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S206
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 229
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S207
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S208
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S209
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 82: // ['A','R']
+			return 29
+		case r == 83: // ['S','S']
+			return 230
+		case 84 <= r && r <= 90: // ['T','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S210
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S211
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S212
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 68: // ['A','D']
+			return 29
+		case r == 69: // ['E','E']
+			return 231
+		case 70 <= r && r <= 90: // ['F','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S213
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 84: // ['A','T']
+			return 29
+		case r == 85: // ['U','U']
+			return 232
+		case 86 <= r && r <= 90: // ['V','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S214
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S215
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 29
+		case r == 66: // ['B','B']
+			return 233
+		case 67 <= r && r <= 90: // ['C','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S216
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 234
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S217
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S218
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 77: // ['A','M']
+			return 29
+		case r == 78: // ['N','N']
+			return 235
+		case 79 <= r && r <= 90: // ['O','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S219
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S220
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 236
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S221
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 75: // ['A','K']
-			return 14
-		case r == 76: // ['L','L']
-			return 46
-		case 77 <= r && r <= 90: // ['M','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S37
+	// S222
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 72: // ['A','H']
-			return 14
-		case r == 73: // ['I','I']
-			return 47
-		case 74 <= r && r <= 90: // ['J','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S38
+	// S223
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
+			return 58
 		case 65 <= r && r <= 68: // ['A','D']
-			return 14
+			return 29
 		case r == 69: // ['E','E']
-			return 48
+			return 237
 		case 70 <= r && r <= 90: // ['F','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S39
+	// S224
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 39
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 40
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 41
+			return 29
 		case r == 95: // ['_','_']
-			return 39
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 42
+			return 45
 		}
 		return NoState
 	},
-	// S40
+	// S225
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 39
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 40
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case r == 65: // ['A','A']
+			return 238
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S226
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 41
+			return 29
 		case r == 95: // ['_','_']
-			return 39
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 42
+			return 45
 		}
 		return NoState
 	},
-	// S41
+	// S227
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 39
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 40
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 41
+			return 29
 		case r == 95: // ['_','_']
-			return 39
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 42
+			return 45
 		}
 		return NoState
 	},
-	// S42
+	// S228
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 39
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 40
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 41
+			return 29
 		case r == 95: // ['_','_']
-			return 39
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 42
+			return 45
 		}
 		return NoState
 	},
-	// S43
+	// S229
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 77: // ['A','M']
-			return 14
-		case r == 78: // ['N','N']
-			return 49
-		case 79 <= r && r <= 90: // ['O','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 239
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S44
+	// S230
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 76: // ['A','L']
-			return 14
-		case r == 77: // ['M','M']
-			return 50
-		case 78 <= r && r <= 90: // ['N','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S45
+	// S231
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 68: // ['A','D']
-			return 14
-		case r == 69: // ['E','E']
-			return 51
-		case 70 <= r && r <= 90: // ['F','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S46
+	// S232
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 240
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S233
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
 		case 65 <= r && r <= 68: // ['A','D']
-			return 14
+			return 29
 		case r == 69: // ['E','E']
-			return 52
+			return 241
 		case 70 <= r && r <= 90: // ['F','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
+		}
+		return NoState
+	},
+	// S234
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 242
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S235
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
+		}
+		return NoState
+	},
+	// S236
+	func(r rune) int {
+		switch {
+		case r == 45: // ['-','-']
+			return 43
+		case 48 <= r && r <= 57: // ['0','9']
+			return 70
+		case r == 58: // [':',':']
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 243
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
+		case r == 95: // ['_','_']
+			return 43
+		case 97 <= r && r <= 122: // ['a','z']
+			return 45
 		}
 		return NoState
 	},
-	// S47
+	// S237
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 78: // ['A','N']
-			return 14
-		case r == 79: // ['O','O']
-			return 53
-		case 80 <= r && r <= 90: // ['P','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S48
+	// S238
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 81: // ['A','Q']
-			return 14
-		case r == 82: // ['R','R']
-			return 54
-		case 83 <= r && r <= 90: // ['S','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 75: // ['A','K']
+			return 29
+		case r == 76: // ['L','L']
+			return 244
+		case 77 <= r && r <= 90: // ['M','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S49
+	// S239
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 83: // ['A','S']
-			return 14
-		case r == 84: // ['T','T']
-			return 55
-		case 85 <= r && r <= 90: // ['U','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 78: // ['A','N']
+			return 29
+		case r == 79: // ['O','O']
+			return 245
+		case 80 <= r && r <= 90: // ['P','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S50
+	// S240
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 246
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S51
+	// S241
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 81: // ['A','Q']
-			return 14
-		case r == 82: // ['R','R']
-			return 56
-		case 83 <= r && r <= 90: // ['S','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S52
+	// S242
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 66: // ['A','B']
-			return 14
-		case r == 67: // ['C','C']
-			return 57
-		case 68 <= r && r <= 90: // ['D','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S53
+	// S243
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
+			return 58
 		case 65 <= r && r <= 77: // ['A','M']
-			return 14
+			return 29
 		case r == 78: // ['N','N']
-			return 58
+			return 247
 		case 79 <= r && r <= 90: // ['O','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S54
+	// S244
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 68: // ['A','D']
-			return 14
-		case r == 69: // ['E','E']
-			return 59
-		case 70 <= r && r <= 90: // ['F','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S55
+	// S245
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 81: // ['A','Q']
+			return 29
+		case r == 82: // ['R','R']
+			return 248
+		case 83 <= r && r <= 90: // ['S','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S56
+	// S246
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 83: // ['A','S']
-			return 14
-		case r == 84: // ['T','T']
-			return 60
-		case 85 <= r && r <= 90: // ['U','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S57
+	// S247
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 83: // ['A','S']
-			return 14
-		case r == 84: // ['T','T']
-			return 61
-		case 85 <= r && r <= 90: // ['U','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 66: // ['A','B']
+			return 29
+		case r == 67: // ['C','C']
+			return 249
+		case 68 <= r && r <= 90: // ['D','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S58
+	// S248
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S59
+	// S249
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case r == 65: // ['A','A']
+			return 250
+		case 66 <= r && r <= 90: // ['B','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S60
+	// S250
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 58
+		case 65 <= r && r <= 83: // ['A','S']
+			return 29
+		case r == 84: // ['T','T']
+			return 251
+		case 85 <= r && r <= 90: // ['U','Z']
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
-	// S61
+	// S251
 	func(r rune) int {
 		switch {
 		case r == 45: // ['-','-']
-			return 7
+			return 43
 		case 48 <= r && r <= 57: // ['0','9']
-			return 10
+			return 70
 		case r == 58: // [':',':']
-			return 27
+			return 58
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 14
+			return 29
 		case r == 95: // ['_','_']
-			return 7
+			return 43
 		case 97 <= r && r <= 122: // ['a','z']
-			return 22
+			return 45
 		}
 		return NoState
 	},
@@ -3,17 +3,17 @@
 package parser
 
 import (
-	"bytes"
 	"fmt"
+	"strings"
 
 	parseError "github.com/gtfierro/hod/lang/errors"
 	"github.com/gtfierro/hod/lang/token"
 )
 
 const (
-	numProductions = 64
-	numStates      = 151
-	numSymbols     = 55
+	numProductions = 221
+	numStates      = 1204
+	numSymbols     = 161
 )
 
 // Stack
@@ -66,14 +66,19 @@ func (s *stack) popN(items int) []Attrib {
 }
 
 func (s *stack) String() string {
-	w := new(bytes.Buffer)
+	w := new(strings.Builder)
 	fmt.Fprintf(w, "stack:\n")
 	for i, st := range s.state {
-		fmt.Fprintf(w, "\t%d:%d , ", i, st)
+		fmt.Fprintf(w, "\t%d: %d , ", i, st)
 		if s.attrib[i] == nil {
 			fmt.Fprintf(w, "nil")
 		} else {
-			fmt.Fprintf(w, "%v", s.attrib[i])
+			switch attr := s.attrib[i].(type) {
+			case *token.Token:
+				fmt.Fprintf(w, "%s", attr.Lit)
+			default:
+				fmt.Fprintf(w, "%v", attr)
+			}
 		}
 		fmt.Fprintf(w, "\n")
 	}
@@ -86,6 +91,7 @@ type Parser struct {
 	stack     *stack
 	nextToken *token.Token
 	pos       int
+	Context   Context
 }
 
 type Scanner interface {
@@ -137,7 +143,7 @@ func (p *Parser) Error(err error, scanner Scanner) (recovered bool, errorAttrib
 
 func (p *Parser) popNonRecoveryStates() (removedAttribs []parseError.ErrorSymbol) {
 	if rs, ok := p.firstRecoveryState(); ok {
-		errorSymbols := p.stack.popN(int(p.stack.topIndex() - rs))
+		errorSymbols := p.stack.popN(p.stack.topIndex() - rs)
 		removedAttribs = make([]parseError.ErrorSymbol, len(errorSymbols))
 		for i, e := range errorSymbols {
 			removedAttribs[i] = e
@@ -197,7 +203,7 @@ func (p *Parser) Parse(scanner Scanner) (res interface{}, err error) {
 			p.nextToken = scanner.Scan()
 		case reduce:
 			prod := productionsTable[int(act)]
-			attrib, err := prod.ReduceFunc(p.stack.popN(prod.NumSymbols))
+			attrib, err := prod.ReduceFunc(p.stack.popN(prod.NumSymbols), p.Context)
 			if err != nil {
 				return nil, p.newError(err)
 			} else {
@@ -5,7 +5,6 @@ package parser
 import "github.com/gtfierro/hod/lang/ast"
 
 type (
-	//TODO: change type and variable names to be consistent with other tables
 	ProdTab      [numProductions]ProdTabEntry
 	ProdTabEntry struct {
 		String     string
@@ -13,7 +12,7 @@ type (
 		NTType     int
 		Index      int
 		NumSymbols int
-		ReduceFunc func([]Attrib) (Attrib, error)
+		ReduceFunc func([]Attrib, interface{}) (Attrib, error)
 	}
 	Attrib interface {
 	}
@@ -26,638 +25,2208 @@ var productionsTable = ProdTab{
 		NTType:     0,
 		Index:      0,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `QueryUnit : SelectQuery ";"	<< X[0], nil >>`,
+		String: `QueryUnit : Query	<<  >>`,
 		Id:         "QueryUnit",
 		NTType:     1,
 		Index:      1,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `QueryUnit : CountQuery ";"	<< X[0], nil >>`,
+		String: `QueryUnit : "NOCACHE" Query	<< ast.NewNoCacheQuery(X[1]) >>`,
 		Id:         "QueryUnit",
 		NTType:     1,
 		Index:      2,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewNoCacheQuery(X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `QueryUnit : UpdateQuery ";"	<< X[0], nil >>`,
+		String: `QueryUnit : "EXPLAIN" Query	<< ast.NewExplainQuery(X[1], false) >>`,
 		Id:         "QueryUnit",
 		NTType:     1,
 		Index:      3,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExplainQuery(X[1], false)
 		},
 	},
 	ProdTabEntry{
-		String: `SelectQuery : SelectClause DatasetClause WhereClause	<< ast.NewQueryMulti(X[0], X[1], X[2], false) >>`,
-		Id:         "SelectQuery",
-		NTType:     2,
+		String: `QueryUnit : "EXPLAIN" "ANALYZE" Query	<< ast.NewExplainQuery(X[2], true) >>`,
+		Id:         "QueryUnit",
+		NTType:     1,
 		Index:      4,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewQueryMulti(X[0], X[1], X[2], false)
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExplainQuery(X[2], true)
 		},
 	},
 	ProdTabEntry{
-		String: `SelectQuery : SelectClause WhereClause	<< ast.NewQuery(X[0], X[1], false) >>`,
-		Id:         "SelectQuery",
+		String: `Query : Prologue SelectQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
 		NTType:     2,
 		Index:      5,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewQuery(X[0], X[1], false)
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `CountQuery : CountClause DatasetClause WhereClause	<< ast.NewQueryMulti(X[0], X[1], X[2], true) >>`,
-		Id:         "CountQuery",
-		NTType:     3,
+		String: `Query : Prologue CountQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
+		NTType:     2,
 		Index:      6,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewQueryMulti(X[0], X[1], X[2], true)
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `CountQuery : CountClause WhereClause	<< ast.NewQuery(X[0], X[1], true) >>`,
-		Id:         "CountQuery",
-		NTType:     3,
+		String: `Query : Prologue UpdateQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
+		NTType:     2,
 		Index:      7,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewQuery(X[0], X[1], true)
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `UpdateQuery : InsertClause DatasetClause WhereClause	<< ast.NewInsertQueryMulti(X[0], X[1], X[2], false) >>`,
-		Id:         "UpdateQuery",
-		NTType:     4,
+		String: `Query : Prologue AskQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
+		NTType:     2,
 		Index:      8,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewInsertQueryMulti(X[0], X[1], X[2], false)
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `UpdateQuery : InsertClause WhereClause	<< ast.NewInsertQuery(X[0], X[1], false) >>`,
-		Id:         "UpdateQuery",
-		NTType:     4,
+		String: `Query : Prologue ConstructQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
+		NTType:     2,
 		Index:      9,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewInsertQuery(X[0], X[1], false)
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `SelectClause : "SELECT" "*"	<< ast.NewAllSelectClause() >>`,
-		Id:         "SelectClause",
-		NTType:     5,
+		String: `Query : Prologue DescribeQuery ";"	<< ast.AddPrologue(X[1], X[0]) >>`,
+		Id:         "Query",
+		NTType:     2,
 		Index:      10,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewAllSelectClause()
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrologue(X[1], X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `SelectClause : "SELECT" Varlist	<< ast.NewSelectClause(X[1]) >>`,
-		Id:         "SelectClause",
-		NTType:     5,
+		String: `Prologue : empty	<< ast.NewPrologue() >>`,
+		Id:         "Prologue",
+		NTType:     3,
 		Index:      11,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewSelectClause(X[1])
+		NumSymbols: 0,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPrologue()
 		},
 	},
 	ProdTabEntry{
-		String: `InsertClause : "INSERT" "{" TriplesBlock "}"	<< ast.NewInsertClause(X[2]) >>`,
-		Id:         "InsertClause",
-		NTType:     6,
+		String: `Prologue : Prologue "BASE" url	<< ast.SetBase(X[0], X[2]) >>`,
+		Id:         "Prologue",
+		NTType:     3,
 		Index:      12,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewInsertClause(X[2])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.SetBase(X[0], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `InsertClause : "INSERT" "{" TriplesBlock "." "}"	<< ast.NewInsertClause(X[2]) >>`,
-		Id:         "InsertClause",
-		NTType:     6,
+		String: `Prologue : Prologue "PREFIX" pname_ns url	<< ast.AddPrefixDecl(X[0], X[2], X[3]) >>`,
+		Id:         "Prologue",
+		NTType:     3,
 		Index:      13,
-		NumSymbols: 5,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewInsertClause(X[2])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPrefixDecl(X[0], X[2], X[3])
 		},
 	},
 	ProdTabEntry{
-		String: `CountClause : "COUNT" "*"	<< ast.NewAllSelectClause() >>`,
-		Id:         "CountClause",
-		NTType:     7,
+		String: `SelectQuery : SelectClause DatasetClause WhereClause SolutionModifier	<< ast.NewQueryMulti(X[0], X[1], X[2], X[3], false) >>`,
+		Id:         "SelectQuery",
+		NTType:     4,
 		Index:      14,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewAllSelectClause()
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewQueryMulti(X[0], X[1], X[2], X[3], false)
 		},
 	},
 	ProdTabEntry{
-		String: `CountClause : "COUNT" Varlist	<< ast.NewSelectClause(X[1]) >>`,
-		Id:         "CountClause",
-		NTType:     7,
+		String: `SelectQuery : SelectClause WhereClause SolutionModifier	<< ast.NewQuery(X[0], X[1], X[2], false) >>`,
+		Id:         "SelectQuery",
+		NTType:     4,
 		Index:      15,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewSelectClause(X[1])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewQuery(X[0], X[1], X[2], false)
 		},
 	},
 	ProdTabEntry{
-		String: `Varlist : Var	<< ast.NewVarList(X[0]) >>`,
-		Id:         "Varlist",
-		NTType:     8,
+		String: `CountQuery : CountClause DatasetClause WhereClause SolutionModifier	<< ast.NewQueryMulti(X[0], X[1], X[2], X[3], true) >>`,
+		Id:         "CountQuery",
+		NTType:     5,
 		Index:      16,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewVarList(X[0])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewQueryMulti(X[0], X[1], X[2], X[3], true)
 		},
 	},
 	ProdTabEntry{
-		String: `Varlist : Varlist Var	<< ast.AppendVar(X[0], X[1]) >>`,
-		Id:         "Varlist",
-		NTType:     8,
+		String: `CountQuery : CountClause WhereClause SolutionModifier	<< ast.NewQuery(X[0], X[1], X[2], true) >>`,
+		Id:         "CountQuery",
+		NTType:     5,
 		Index:      17,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AppendVar(X[0], X[1])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewQuery(X[0], X[1], X[2], true)
 		},
 	},
 	ProdTabEntry{
-		String: `DBlist : String	<< ast.NewStringList(X[0]) >>`,
-		Id:         "DBlist",
-		NTType:     9,
+		String: `UpdateQuery : InsertClause DatasetClause WhereClause	<< ast.NewInsertQueryMulti(X[0], X[1], X[2], false) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      18,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewStringList(X[0])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInsertQueryMulti(X[0], X[1], X[2], false)
 		},
 	},
 	ProdTabEntry{
-		String: `DBlist : DBlist String	<< ast.AppendString(X[0], X[1]) >>`,
-		Id:         "DBlist",
-		NTType:     9,
+		String: `UpdateQuery : InsertClause WhereClause	<< ast.NewInsertQuery(X[0], X[1], false) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      19,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AppendString(X[0], X[1])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInsertQuery(X[0], X[1], false)
 		},
 	},
 	ProdTabEntry{
-		String: `String : string	<< ast.ParseString(X[0]) >>`,
-		Id:         "String",
-		NTType:     10,
+		String: `UpdateQuery : DeleteClause DatasetClause WhereClause	<< ast.NewUpdateQuery(X[0], nil, X[1], X[2]) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      20,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.ParseString(X[0])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], nil, X[1], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `Var : var	<< ast.ParseString(X[0]) >>`,
-		Id:         "Var",
-		NTType:     11,
+		String: `UpdateQuery : DeleteClause WhereClause	<< ast.NewUpdateQuery(X[0], nil, nil, X[1]) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      21,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.ParseString(X[0])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], nil, nil, X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `DatasetClause : "FROM" DBlist	<< ast.NewFromClause(X[1]) >>`,
-		Id:         "DatasetClause",
-		NTType:     12,
+		String: `UpdateQuery : DeleteClause InsertClause DatasetClause WhereClause	<< ast.NewUpdateQuery(X[0], X[1], X[2], X[3]) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      22,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewFromClause(X[1])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], X[1], X[2], X[3])
 		},
 	},
 	ProdTabEntry{
-		String: `DatasetClause : "FROM" "*"	<< ast.NewAllFromClause() >>`,
-		Id:         "DatasetClause",
-		NTType:     12,
+		String: `UpdateQuery : DeleteClause InsertClause WhereClause	<< ast.NewUpdateQuery(X[0], X[1], nil, X[2]) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      23,
-		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewAllFromClause()
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], X[1], nil, X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" "}"	<< ast.NewWhereClause([]ast.Triple{}) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `UpdateQuery : DeleteDataClause DatasetClause	<< ast.NewUpdateQuery(X[0], nil, X[1], nil) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      24,
-		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClause([]ast.Triple{})
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], nil, X[1], nil)
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" TriplesBlock "}"	<< ast.NewWhereClause(X[2]) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `UpdateQuery : DeleteDataClause	<< ast.NewUpdateQuery(X[0], nil, nil, nil) >>`,
+		Id:         "UpdateQuery",
+		NTType:     6,
 		Index:      25,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClause(X[2])
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUpdateQuery(X[0], nil, nil, nil)
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" TriplesBlock "." "}"	<< ast.NewWhereClause(X[2]) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `AskQuery : "ASK" DatasetClause WhereClause	<< ast.NewAskQuery(X[1], X[2]) >>`,
+		Id:         "AskQuery",
+		NTType:     7,
 		Index:      26,
-		NumSymbols: 5,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClause(X[2])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAskQuery(X[1], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" TriplesBlock RestOfWhereList "}"	<< ast.NewWhereClauseWithGraphGroup(X[2], X[3]) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `AskQuery : "ASK" WhereClause	<< ast.NewAskQuery(nil, X[1]) >>`,
+		Id:         "AskQuery",
+		NTType:     7,
 		Index:      27,
-		NumSymbols: 5,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClauseWithGraphGroup(X[2], X[3])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAskQuery(nil, X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" TriplesBlock "." RestOfWhereList "}"	<< ast.NewWhereClauseWithGraphGroup(X[2], X[4]) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `AskQuery : "ASK" DatasetClause GroupGraphPattern	<< ast.NewAskQuery(X[1], X[2]) >>`,
+		Id:         "AskQuery",
+		NTType:     7,
 		Index:      28,
-		NumSymbols: 6,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClauseWithGraphGroup(X[2], X[4])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAskQuery(X[1], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `WhereClause : "WHERE" "{" RestOfWhereList "}"	<< ast.NewWhereClauseGraphGroup(X[2]) >>`,
-		Id:         "WhereClause",
-		NTType:     13,
+		String: `AskQuery : "ASK" GroupGraphPattern	<< ast.NewAskQuery(nil, X[1]) >>`,
+		Id:         "AskQuery",
+		NTType:     7,
 		Index:      29,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewWhereClauseGraphGroup(X[2])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAskQuery(nil, X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `TriplesBlock : Triple	<< ast.NewTripleBlock(X[0]) >>`,
-		Id:         "TriplesBlock",
-		NTType:     14,
+		String: `ConstructQuery : ConstructClause DatasetClause WhereClause SolutionModifier	<< ast.NewConstructQuery(X[0], X[1], X[2], X[3]) >>`,
+		Id:         "ConstructQuery",
+		NTType:     8,
 		Index:      30,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewTripleBlock(X[0])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewConstructQuery(X[0], X[1], X[2], X[3])
 		},
 	},
 	ProdTabEntry{
-		String: `TriplesBlock : TriplesBlock "." Triple	<< ast.AppendTripleBlock(X[0], X[2]) >>`,
-		Id:         "TriplesBlock",
-		NTType:     14,
+		String: `ConstructQuery : ConstructClause WhereClause SolutionModifier	<< ast.NewConstructQuery(X[0], nil, X[1], X[2]) >>`,
+		Id:         "ConstructQuery",
+		NTType:     8,
 		Index:      31,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AppendTripleBlock(X[0], X[2])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewConstructQuery(X[0], nil, X[1], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `Triple : VarOrTerm Path VarOrTerm	<< ast.NewTriple(X[0], X[1], X[2]) >>`,
-		Id:         "Triple",
-		NTType:     15,
+		String: `DescribeQuery : DescribeClause DatasetClause WhereClause SolutionModifier	<< ast.NewDescribeQuery(X[0], X[1], X[2], X[3]) >>`,
+		Id:         "DescribeQuery",
+		NTType:     9,
 		Index:      32,
-		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewTriple(X[0], X[1], X[2])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeQuery(X[0], X[1], X[2], X[3])
 		},
 	},
 	ProdTabEntry{
-		String: `VarOrTerm : Var	<< ast.NewURI(X[0]) >>`,
-		Id:         "VarOrTerm",
-		NTType:     16,
+		String: `DescribeQuery : DescribeClause WhereClause SolutionModifier	<< ast.NewDescribeQuery(X[0], nil, X[1], X[2]) >>`,
+		Id:         "DescribeQuery",
+		NTType:     9,
 		Index:      33,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewURI(X[0])
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeQuery(X[0], nil, X[1], X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `VarOrTerm : GraphTerm	<< ast.NewURI(X[0]) >>`,
-		Id:         "VarOrTerm",
-		NTType:     16,
+		String: `DescribeQuery : DescribeClause DatasetClause	<< ast.NewDescribeQuery(X[0], X[1], nil, nil) >>`,
+		Id:         "DescribeQuery",
+		NTType:     9,
 		Index:      34,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewURI(X[0])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeQuery(X[0], X[1], nil, nil)
 		},
 	},
 	ProdTabEntry{
-		String: `GraphTerm : uri	<< ast.ParseString(X[0]) >>`,
-		Id:         "GraphTerm",
-		NTType:     17,
+		String: `DescribeQuery : DescribeClause	<< ast.NewDescribeQuery(X[0], nil, nil, nil) >>`,
+		Id:         "DescribeQuery",
+		NTType:     9,
 		Index:      35,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.ParseString(X[0])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeQuery(X[0], nil, nil, nil)
 		},
 	},
 	ProdTabEntry{
-		String: `GraphTerm : quotedstring	<< ast.ParseQuotedString(X[0]) >>`,
-		Id:         "GraphTerm",
-		NTType:     17,
+		String: `SelectClause : "SELECT" "*"	<< ast.NewAllSelectClause() >>`,
+		Id:         "SelectClause",
+		NTType:     10,
 		Index:      36,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.ParseQuotedString(X[0])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAllSelectClause()
 		},
 	},
 	ProdTabEntry{
-		String: `GraphTerm : url	<< ast.ParseString(X[0]) >>`,
-		Id:         "GraphTerm",
-		NTType:     17,
+		String: `SelectClause : "SELECT" ProjectionList	<< ast.NewProjectionSelectClause(X[1]) >>`,
+		Id:         "SelectClause",
+		NTType:     10,
 		Index:      37,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.ParseString(X[0])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewProjectionSelectClause(X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `Path : PathSequence	<< X[0], nil >>`,
-		Id:         "Path",
-		NTType:     18,
+		String: `InsertClause : "INSERT" "{" TriplesBlock "}"	<< ast.NewInsertClause(X[2]) >>`,
+		Id:         "InsertClause",
+		NTType:     11,
 		Index:      38,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInsertClause(X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `Path : Path "|" PathSequence	<<  >>`,
-		Id:         "Path",
-		NTType:     18,
+		String: `InsertClause : "INSERT" "{" TriplesBlock "." "}"	<< ast.NewInsertClause(X[2]) >>`,
+		Id:         "InsertClause",
+		NTType:     11,
 		Index:      39,
-		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInsertClause(X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `Path : Var	<< ast.PathFromVar(X[0]) >>`,
-		Id:         "Path",
-		NTType:     18,
+		String: `ConstructClause : "CONSTRUCT" "{" TriplesBlock "}"	<< ast.NewConstructClause(X[2]) >>`,
+		Id:         "ConstructClause",
+		NTType:     12,
 		Index:      40,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.PathFromVar(X[0])
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewConstructClause(X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `PathSequence : PathElt	<< ast.NewPathSequence(X[0]) >>`,
-		Id:         "PathSequence",
-		NTType:     19,
+		String: `ConstructClause : "CONSTRUCT" "{" TriplesBlock "." "}"	<< ast.NewConstructClause(X[2]) >>`,
+		Id:         "ConstructClause",
+		NTType:     12,
 		Index:      41,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewPathSequence(X[0])
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewConstructClause(X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `PathSequence : PathSequence "/" PathElt	<< ast.AppendPathSequence(X[0], X[2]) >>`,
-		Id:         "PathSequence",
-		NTType:     19,
+		String: `DescribeClause : "DESCRIBE" "*"	<< ast.NewDescribeClause(nil) >>`,
+		Id:         "DescribeClause",
+		NTType:     13,
 		Index:      42,
-		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AppendPathSequence(X[0], X[2])
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeClause(nil)
 		},
 	},
 	ProdTabEntry{
-		String: `PathElt : PathPrimary PathMod	<< ast.AddPathMod(X[0], X[1]) >>`,
-		Id:         "PathElt",
-		NTType:     20,
+		String: `DescribeClause : "DESCRIBE" VarOrIRI	<< ast.NewDescribeClause(X[1]) >>`,
+		Id:         "DescribeClause",
+		NTType:     13,
 		Index:      43,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AddPathMod(X[0], X[1])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDescribeClause(X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `PathElt : PathPrimary	<< X[0], nil >>`,
-		Id:         "PathElt",
-		NTType:     20,
+		String: `DescribeClause : DescribeClause VarOrIRI	<< ast.AppendDescribeTerm(X[0], X[1]) >>`,
+		Id:         "DescribeClause",
+		NTType:     13,
 		Index:      44,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendDescribeTerm(X[0], X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `PathPrimary : uri	<< ast.NewPathPattern(X[0]) >>`,
-		Id:         "PathPrimary",
-		NTType:     21,
+		String: `VarOrIRI : Var	<< ast.NewURI(X[0]) >>`,
+		Id:         "VarOrIRI",
+		NTType:     14,
 		Index:      45,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewPathPattern(X[0])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewURI(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `PathPrimary : "a"	<< ast.NewPathPattern(X[0]) >>`,
-		Id:         "PathPrimary",
-		NTType:     21,
+		String: `VarOrIRI : IRIref	<< ast.NewURI(X[0]) >>`,
+		Id:         "VarOrIRI",
+		NTType:     14,
 		Index:      46,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewPathPattern(X[0])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewURI(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `PathPrimary : url	<< ast.NewPathPattern(X[0]) >>`,
-		Id:         "PathPrimary",
-		NTType:     21,
+		String: `IRIref : uri	<< ast.ParseString(X[0]) >>`,
+		Id:         "IRIref",
+		NTType:     15,
 		Index:      47,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.NewPathPattern(X[0])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `PathPrimary : "(" Path ")"	<< X[1], nil >>`,
-		Id:         "PathPrimary",
-		NTType:     21,
+		String: `IRIref : url	<< ast.ParseString(X[0]) >>`,
+		Id:         "IRIref",
+		NTType:     15,
 		Index:      48,
-		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[1], nil
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `PathMod : "?"	<< ast.Pattern(ast.PATTERN_ZERO_ONE), nil >>`,
-		Id:         "PathMod",
-		NTType:     22,
+		String: `DeleteClause : "DELETE" "{" TriplesBlock "}"	<< ast.NewDeleteClause(X[2]) >>`,
+		Id:         "DeleteClause",
+		NTType:     16,
 		Index:      49,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.Pattern(ast.PATTERN_ZERO_ONE), nil
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDeleteClause(X[2])
 		},
 	},
 	ProdTabEntry{
-		String: `PathMod : "*"	<< ast.Pattern(ast.PATTERN_ZERO_PLUS), nil >>`,
-		Id:         "PathMod",
-		NTType:     22,
+		String: `DeleteClause : "DELETE" "{" TriplesBlock "." "}"	<< ast.NewDeleteClause(X[2]) >>`,
+		Id:         "DeleteClause",
+		NTType:     16,
 		Index:      50,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDeleteClause(X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `DeleteDataClause : "DELETE" "DATA" "{" TriplesBlock "}"	<< ast.NewDeleteClause(X[3]) >>`,
+		Id:         "DeleteDataClause",
+		NTType:     17,
+		Index:      51,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDeleteClause(X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `DeleteDataClause : "DELETE" "DATA" "{" TriplesBlock "." "}"	<< ast.NewDeleteClause(X[3]) >>`,
+		Id:         "DeleteDataClause",
+		NTType:     17,
+		Index:      52,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDeleteClause(X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `ProjectionList : Projection	<< ast.NewProjectionList(X[0]) >>`,
+		Id:         "ProjectionList",
+		NTType:     18,
+		Index:      53,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.Pattern(ast.PATTERN_ZERO_PLUS), nil
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewProjectionList(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `PathMod : "+"	<< ast.Pattern(ast.PATTERN_ONE_PLUS), nil >>`,
-		Id:         "PathMod",
+		String: `ProjectionList : ProjectionList Projection	<< ast.AppendProjectionList(X[0], X[1]) >>`,
+		Id:         "ProjectionList",
+		NTType:     18,
+		Index:      54,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendProjectionList(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `Projection : Var	<< ast.NewProjection(nil, X[0]) >>`,
+		Id:         "Projection",
+		NTType:     19,
+		Index:      55,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewProjection(nil, X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `Projection : Var "[" "*" "]"	<< ast.NewLinkProjection(X[0], nil) >>`,
+		Id:         "Projection",
+		NTType:     19,
+		Index:      56,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLinkProjection(X[0], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `Projection : Var "[" LinkKeys "]"	<< ast.NewLinkProjection(X[0], X[2]) >>`,
+		Id:         "Projection",
+		NTType:     19,
+		Index:      57,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLinkProjection(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Projection : "(" Expression "AS" Var ")"	<< ast.NewProjection(X[1], X[3]) >>`,
+		Id:         "Projection",
+		NTType:     19,
+		Index:      58,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewProjection(X[1], X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `LinkKeys : String	<< ast.NewStringList(X[0]) >>`,
+		Id:         "LinkKeys",
+		NTType:     20,
+		Index:      59,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewStringList(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `LinkKeys : LinkKeys "," String	<< ast.AppendString(X[0], X[2]) >>`,
+		Id:         "LinkKeys",
+		NTType:     20,
+		Index:      60,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendString(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `CountClause : "COUNT" "*"	<< ast.NewAllSelectClause() >>`,
+		Id:         "CountClause",
+		NTType:     21,
+		Index:      61,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAllSelectClause()
+		},
+	},
+	ProdTabEntry{
+		String: `CountClause : "COUNT" Varlist	<< ast.NewSelectClause(X[1]) >>`,
+		Id:         "CountClause",
+		NTType:     21,
+		Index:      62,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSelectClause(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `Varlist : Var	<< ast.NewVarList(X[0]) >>`,
+		Id:         "Varlist",
 		NTType:     22,
-		Index:      51,
+		Index:      63,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.Pattern(ast.PATTERN_ONE_PLUS), nil
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewVarList(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `Varlist : Varlist Var	<< ast.AppendVar(X[0], X[1]) >>`,
+		Id:         "Varlist",
+		NTType:     22,
+		Index:      64,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendVar(X[0], X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `RestOfWhereList : RestOfWhere	<< X[0], nil >>`,
-		Id:         "RestOfWhereList",
+		String: `DBlist : String	<< ast.NewStringList(X[0]) >>`,
+		Id:         "DBlist",
 		NTType:     23,
-		Index:      52,
+		Index:      65,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewStringList(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `RestOfWhereList : RestOfWhereList RestOfWhere	<< ast.MergeGraphGroups(X[0], X[1]) >>`,
-		Id:         "RestOfWhereList",
+		String: `DBlist : DBlist String	<< ast.AppendString(X[0], X[1]) >>`,
+		Id:         "DBlist",
 		NTType:     23,
-		Index:      53,
+		Index:      66,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.MergeGraphGroups(X[0], X[1])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendString(X[0], X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `RestOfWhere : GraphPatternNotTriples Joiner TriplesBlock Joiner	<< ast.AddTriplesToGraphGroup(X[0], X[2]) >>`,
-		Id:         "RestOfWhere",
+		String: `String : string	<< ast.ParseString(X[0]) >>`,
+		Id:         "String",
 		NTType:     24,
-		Index:      54,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AddTriplesToGraphGroup(X[0], X[2])
+		Index:      67,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `RestOfWhere : GraphPatternNotTriples Joiner	<< X[0], nil >>`,
-		Id:         "RestOfWhere",
-		NTType:     24,
-		Index:      55,
+		String: `Var : var	<< ast.ParseString(X[0]) >>`,
+		Id:         "Var",
+		NTType:     25,
+		Index:      68,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `DatasetClause : "FROM" DBlist	<< ast.NewFromClause(X[1]) >>`,
+		Id:         "DatasetClause",
+		NTType:     26,
+		Index:      69,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFromClause(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `DatasetClause : "FROM" "*"	<< ast.NewAllFromClause() >>`,
+		Id:         "DatasetClause",
+		NTType:     26,
+		Index:      70,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAllFromClause()
+		},
+	},
+	ProdTabEntry{
+		String: `WhereClause : "WHERE" GroupGraphPattern	<< ast.NewWhereClause(X[1]) >>`,
+		Id:         "WhereClause",
+		NTType:     27,
+		Index:      71,
 		NumSymbols: 2,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewWhereClause(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `SolutionModifier : OrderModifier	<< X[0], nil >>`,
+		Id:         "SolutionModifier",
+		NTType:     28,
+		Index:      72,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Joiner : "."	<<  >>`,
-		Id:         "Joiner",
-		NTType:     25,
-		Index:      56,
+		String: `SolutionModifier : OrderModifier LimitOffsetClauses	<< ast.AddLimitOffset(X[0], X[1]) >>`,
+		Id:         "SolutionModifier",
+		NTType:     28,
+		Index:      73,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddLimitOffset(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `OrderModifier : HavingModifier	<< X[0], nil >>`,
+		Id:         "OrderModifier",
+		NTType:     29,
+		Index:      74,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Joiner : empty	<<  >>`,
-		Id:         "Joiner",
-		NTType:     25,
-		Index:      57,
-		NumSymbols: 0,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return nil, nil
+		String: `OrderModifier : HavingModifier OrderClause	<< ast.AddOrderBy(X[0], X[1]) >>`,
+		Id:         "OrderModifier",
+		NTType:     29,
+		Index:      75,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddOrderBy(X[0], X[1])
 		},
 	},
 	ProdTabEntry{
-		String: `GraphPatternNotTriples : GroupGraphPattern	<< X[0], nil >>`,
-		Id:         "GraphPatternNotTriples",
-		NTType:     26,
-		Index:      58,
+		String: `HavingModifier : GroupModifier	<< X[0], nil >>`,
+		Id:         "HavingModifier",
+		NTType:     30,
+		Index:      76,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `GraphPatternNotTriples : GraphPatternNotTriples "UNION" GroupGraphPattern	<< ast.GraphGroupUnion(X[0], X[2]) >>`,
-		Id:         "GraphPatternNotTriples",
-		NTType:     26,
-		Index:      59,
+		String: `HavingModifier : GroupModifier HavingClause	<< ast.AddHaving(X[0], X[1]) >>`,
+		Id:         "HavingModifier",
+		NTType:     30,
+		Index:      77,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddHaving(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupModifier : empty	<< ast.SolutionModifier{}, nil >>`,
+		Id:         "GroupModifier",
+		NTType:     31,
+		Index:      78,
+		NumSymbols: 0,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.SolutionModifier{}, nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupModifier : GroupClause	<< ast.AddGroupBy(ast.SolutionModifier{}, X[0]) >>`,
+		Id:         "GroupModifier",
+		NTType:     31,
+		Index:      79,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddGroupBy(ast.SolutionModifier{}, X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupClause : "GROUP" "BY" Varlist	<< X[2], nil >>`,
+		Id:         "GroupClause",
+		NTType:     32,
+		Index:      80,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.GraphGroupUnion(X[0], X[2])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[2], nil
 		},
 	},
 	ProdTabEntry{
-		String: `GroupGraphPattern : "{" GroupGraphPatternSub Joiner "}"	<< X[1], nil >>`,
-		Id:         "GroupGraphPattern",
-		NTType:     27,
-		Index:      60,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
+		String: `HavingClause : "HAVING" HavingConditionList	<< X[1], nil >>`,
+		Id:         "HavingClause",
+		NTType:     33,
+		Index:      81,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[1], nil
 		},
 	},
 	ProdTabEntry{
-		String: `GroupGraphPatternSub : TriplesBlock	<< ast.GraphGroupFromTriples(X[0]) >>`,
-		Id:         "GroupGraphPatternSub",
-		NTType:     28,
-		Index:      61,
+		String: `HavingConditionList : BrackettedExpression	<< ast.NewExpressionList(X[0]) >>`,
+		Id:         "HavingConditionList",
+		NTType:     34,
+		Index:      82,
 		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.GraphGroupFromTriples(X[0])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExpressionList(X[0])
 		},
 	},
 	ProdTabEntry{
-		String: `GroupGraphPatternSub : GraphPatternNotTriples "." TriplesBlock	<< ast.AddTriplesToGraphGroup(X[0], X[2]) >>`,
-		Id:         "GroupGraphPatternSub",
-		NTType:     28,
-		Index:      62,
+		String: `HavingConditionList : HavingConditionList BrackettedExpression	<< ast.AppendExpressionList(X[0], X[1]) >>`,
+		Id:         "HavingConditionList",
+		NTType:     34,
+		Index:      83,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendExpressionList(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `OrderClause : "ORDER" "BY" OrderConditionList	<< X[2], nil >>`,
+		Id:         "OrderClause",
+		NTType:     35,
+		Index:      84,
 		NumSymbols: 3,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return ast.AddTriplesToGraphGroup(X[0], X[2])
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[2], nil
 		},
 	},
 	ProdTabEntry{
-		String: `GroupGraphPatternSub : GroupGraphPatternSub GraphPatternNotTriples "." TriplesBlock	<<  >>`,
-		Id:         "GroupGraphPatternSub",
-		NTType:     28,
-		Index:      63,
-		NumSymbols: 4,
-		ReduceFunc: func(X []Attrib) (Attrib, error) {
-			return X[0], nil
+		String: `OrderConditionList : OrderCondition	<< ast.NewOrderConditionList(X[0]) >>`,
+		Id:         "OrderConditionList",
+		NTType:     36,
+		Index:      85,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOrderConditionList(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `OrderConditionList : OrderConditionList OrderCondition	<< ast.AppendOrderCondition(X[0], X[1]) >>`,
+		Id:         "OrderConditionList",
+		NTType:     36,
+		Index:      86,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendOrderCondition(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `OrderCondition : "ASC" BrackettedExpression	<< ast.NewOrderCondition(X[1], false) >>`,
+		Id:         "OrderCondition",
+		NTType:     37,
+		Index:      87,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOrderCondition(X[1], false)
+		},
+	},
+	ProdTabEntry{
+		String: `OrderCondition : "DESC" BrackettedExpression	<< ast.NewOrderCondition(X[1], true) >>`,
+		Id:         "OrderCondition",
+		NTType:     37,
+		Index:      88,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOrderCondition(X[1], true)
+		},
+	},
+	ProdTabEntry{
+		String: `OrderCondition : BrackettedExpression	<< ast.NewOrderCondition(X[0], false) >>`,
+		Id:         "OrderCondition",
+		NTType:     37,
+		Index:      89,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOrderCondition(X[0], false)
+		},
+	},
+	ProdTabEntry{
+		String: `OrderCondition : FunctionCall	<< ast.NewOrderCondition(X[0], false) >>`,
+		Id:         "OrderCondition",
+		NTType:     37,
+		Index:      90,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOrderCondition(X[0], false)
+		},
+	},
+	ProdTabEntry{
+		String: `OrderCondition : Var	<< ast.NewVarOrderCondition(X[0]) >>`,
+		Id:         "OrderCondition",
+		NTType:     37,
+		Index:      91,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewVarOrderCondition(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `LimitOffsetClauses : LimitClause	<< ast.NewLimitOffset(X[0], nil) >>`,
+		Id:         "LimitOffsetClauses",
+		NTType:     38,
+		Index:      92,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLimitOffset(X[0], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `LimitOffsetClauses : OffsetClause	<< ast.NewLimitOffset(nil, X[0]) >>`,
+		Id:         "LimitOffsetClauses",
+		NTType:     38,
+		Index:      93,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLimitOffset(nil, X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `LimitOffsetClauses : LimitClause OffsetClause	<< ast.NewLimitOffset(X[0], X[1]) >>`,
+		Id:         "LimitOffsetClauses",
+		NTType:     38,
+		Index:      94,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLimitOffset(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `LimitOffsetClauses : OffsetClause LimitClause	<< ast.NewLimitOffset(X[1], X[0]) >>`,
+		Id:         "LimitOffsetClauses",
+		NTType:     38,
+		Index:      95,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLimitOffset(X[1], X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `LimitClause : "LIMIT" integer	<< X[1], nil >>`,
+		Id:         "LimitClause",
+		NTType:     39,
+		Index:      96,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String: `OffsetClause : "OFFSET" integer	<< X[1], nil >>`,
+		Id:         "OffsetClause",
+		NTType:     40,
+		Index:      97,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String: `TriplesBlock : Triple	<< ast.NewTripleBlock(X[0]) >>`,
+		Id:         "TriplesBlock",
+		NTType:     41,
+		Index:      98,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTripleBlock(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `TriplesBlock : TriplesBlock "." Triple	<< ast.AppendTripleBlock(X[0], X[2]) >>`,
+		Id:         "TriplesBlock",
+		NTType:     41,
+		Index:      99,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendTripleBlock(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Triple : VarOrTerm Path VarOrTerm	<< ast.NewTriple(X[0], X[1], X[2]) >>`,
+		Id:         "Triple",
+		NTType:     42,
+		Index:      100,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTriple(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `VarOrTerm : Var	<< ast.NewURI(X[0]) >>`,
+		Id:         "VarOrTerm",
+		NTType:     43,
+		Index:      101,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewURI(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `VarOrTerm : GraphTerm	<< ast.NewURI(X[0]) >>`,
+		Id:         "VarOrTerm",
+		NTType:     43,
+		Index:      102,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewURI(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : uri	<< ast.ParseString(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      103,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : RDFLiteral	<< X[0], nil >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      104,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : url	<< ast.ParseString(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      105,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseString(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : integer	<< ast.NewIntegerTerm(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      106,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIntegerTerm(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : decimal	<< ast.NewDecimalTerm(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      107,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDecimalTerm(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : "true"	<< ast.NewBooleanTerm(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      108,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBooleanTerm(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphTerm : "false"	<< ast.NewBooleanTerm(X[0]) >>`,
+		Id:         "GraphTerm",
+		NTType:     44,
+		Index:      109,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBooleanTerm(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `RDFLiteral : quotedstring	<< ast.ParseQuotedString(X[0]) >>`,
+		Id:         "RDFLiteral",
+		NTType:     45,
+		Index:      110,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.ParseQuotedString(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `RDFLiteral : quotedstring langtag	<< ast.NewLangLiteral(X[0], X[1]) >>`,
+		Id:         "RDFLiteral",
+		NTType:     45,
+		Index:      111,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLangLiteral(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `RDFLiteral : quotedstring "^^" uri	<< ast.NewTypedLiteral(X[0], X[2]) >>`,
+		Id:         "RDFLiteral",
+		NTType:     45,
+		Index:      112,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTypedLiteral(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RDFLiteral : quotedstring "^^" url	<< ast.NewTypedLiteral(X[0], X[2]) >>`,
+		Id:         "RDFLiteral",
+		NTType:     45,
+		Index:      113,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTypedLiteral(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Path : PathAlternative	<< ast.NewPath(X[0]) >>`,
+		Id:         "Path",
+		NTType:     46,
+		Index:      114,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPath(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathAlternative : PathSequence	<< ast.NewPathAlternatives(X[0]) >>`,
+		Id:         "PathAlternative",
+		NTType:     47,
+		Index:      115,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathAlternatives(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathAlternative : PathAlternative "|" PathSequence	<< ast.AppendPathAlternative(X[0], X[2]) >>`,
+		Id:         "PathAlternative",
+		NTType:     47,
+		Index:      116,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendPathAlternative(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `PathSequence : PathEltOrInverse	<< ast.NewPathSequence(X[0]) >>`,
+		Id:         "PathSequence",
+		NTType:     48,
+		Index:      117,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathSequence(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathSequence : PathSequence "/" PathEltOrInverse	<< ast.AppendPathSequence(X[0], X[2]) >>`,
+		Id:         "PathSequence",
+		NTType:     48,
+		Index:      118,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendPathSequence(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `PathEltOrInverse : PathElt	<< X[0], nil >>`,
+		Id:         "PathEltOrInverse",
+		NTType:     49,
+		Index:      119,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `PathEltOrInverse : "^" PathElt	<< ast.InvertPathPattern(X[1]) >>`,
+		Id:         "PathEltOrInverse",
+		NTType:     49,
+		Index:      120,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.InvertPathPattern(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `PathElt : PathPrimary PathMod	<< ast.AddPathMod(X[0], X[1]) >>`,
+		Id:         "PathElt",
+		NTType:     50,
+		Index:      121,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddPathMod(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `PathElt : PathPrimary	<< X[0], nil >>`,
+		Id:         "PathElt",
+		NTType:     50,
+		Index:      122,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `PathPrimary : uri	<< ast.NewPathPattern(X[0]) >>`,
+		Id:         "PathPrimary",
+		NTType:     51,
+		Index:      123,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathPattern(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathPrimary : "a"	<< ast.NewPathPattern(X[0]) >>`,
+		Id:         "PathPrimary",
+		NTType:     51,
+		Index:      124,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathPattern(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathPrimary : url	<< ast.NewPathPattern(X[0]) >>`,
+		Id:         "PathPrimary",
+		NTType:     51,
+		Index:      125,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathPattern(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathPrimary : Var	<< ast.NewVarPathPattern(X[0]) >>`,
+		Id:         "PathPrimary",
+		NTType:     51,
+		Index:      126,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewVarPathPattern(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PathPrimary : "(" PathAlternative ")"	<< ast.NewPathGroup(X[1]) >>`,
+		Id:         "PathPrimary",
+		NTType:     51,
+		Index:      127,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathGroup(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "?"	<< ast.Pattern(ast.PATTERN_ZERO_ONE), nil >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      128,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.Pattern(ast.PATTERN_ZERO_ONE), nil
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "*"	<< ast.Pattern(ast.PATTERN_ZERO_PLUS), nil >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      129,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.Pattern(ast.PATTERN_ZERO_PLUS), nil
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "+"	<< ast.Pattern(ast.PATTERN_ONE_PLUS), nil >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      130,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.Pattern(ast.PATTERN_ONE_PLUS), nil
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "{" integer "}"	<< ast.NewPathRepeat(X[1], X[1]) >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      131,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathRepeat(X[1], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "{" integer "," "}"	<< ast.NewPathRepeat(X[1], nil) >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      132,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathRepeat(X[1], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "{" integer "," integer "}"	<< ast.NewPathRepeat(X[1], X[3]) >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      133,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathRepeat(X[1], X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `PathMod : "{" "," integer "}"	<< ast.NewPathRepeat(nil, X[2]) >>`,
+		Id:         "PathMod",
+		NTType:     52,
+		Index:      134,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPathRepeat(nil, X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `GraphPatternNotTriples : GroupGraphPattern	<< X[0], nil >>`,
+		Id:         "GraphPatternNotTriples",
+		NTType:     53,
+		Index:      135,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GraphPatternNotTriples : GraphPatternNotTriples "UNION" GroupGraphPattern	<< ast.GraphGroupUnion(X[0], X[2]) >>`,
+		Id:         "GraphPatternNotTriples",
+		NTType:     53,
+		Index:      136,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.GraphGroupUnion(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupGraphPattern : "{" "}"	<< ast.GraphGroup{}, nil >>`,
+		Id:         "GroupGraphPattern",
+		NTType:     54,
+		Index:      137,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.GraphGroup{}, nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupGraphPattern : "{" GroupGraphPatternSub "}"	<< X[1], nil >>`,
+		Id:         "GroupGraphPattern",
+		NTType:     54,
+		Index:      138,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupGraphPattern : "{" SubSelect "}"	<< X[1], nil >>`,
+		Id:         "GroupGraphPattern",
+		NTType:     54,
+		Index:      139,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String: `SubSelect : SelectClause WhereClause SolutionModifier	<< ast.NewSubqueryGroup(X[0], X[1], X[2]) >>`,
+		Id:         "SubSelect",
+		NTType:     55,
+		Index:      140,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSubqueryGroup(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupGraphPatternSub : GroupElement	<< ast.AddToGraphGroup(ast.GraphGroup{}, X[0]) >>`,
+		Id:         "GroupGraphPatternSub",
+		NTType:     56,
+		Index:      141,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddToGraphGroup(ast.GraphGroup{}, X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupGraphPatternSub : GroupGraphPatternSub GroupElement	<< ast.AddToGraphGroup(X[0], X[1]) >>`,
+		Id:         "GroupGraphPatternSub",
+		NTType:     56,
+		Index:      142,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AddToGraphGroup(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : Triple	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      143,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : Triple "ROUTE" Var	<< ast.NewRouteTriple(X[0], X[2]) >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      144,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewRouteTriple(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : "."	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      145,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : GraphPatternNotTriples	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      146,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : Filter	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      147,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : OptionalGraphPattern	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      148,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : MinusGraphPattern	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      149,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : InlineData	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      150,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `GroupElement : Bind	<< X[0], nil >>`,
+		Id:         "GroupElement",
+		NTType:     57,
+		Index:      151,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `Bind : "BIND" "(" Expression "AS" Var ")"	<< ast.NewBind(X[2], X[4]) >>`,
+		Id:         "Bind",
+		NTType:     58,
+		Index:      152,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBind(X[2], X[4])
+		},
+	},
+	ProdTabEntry{
+		String: `InlineData : "VALUES" Var "{" DataBlockValues "}"	<< ast.NewSingleVarValues(X[1], X[3]) >>`,
+		Id:         "InlineData",
+		NTType:     59,
+		Index:      153,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSingleVarValues(X[1], X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `InlineData : "VALUES" "(" Varlist ")" "{" DataBlockRows "}"	<< ast.NewValuesClause(X[2], X[5]) >>`,
+		Id:         "InlineData",
+		NTType:     59,
+		Index:      154,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewValuesClause(X[2], X[5])
+		},
+	},
+	ProdTabEntry{
+		String: `DataBlockRows : empty	<< ast.NewValuesRows() >>`,
+		Id:         "DataBlockRows",
+		NTType:     60,
+		Index:      155,
+		NumSymbols: 0,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewValuesRows()
+		},
+	},
+	ProdTabEntry{
+		String: `DataBlockRows : DataBlockRows "(" DataBlockValues ")"	<< ast.AppendValuesRows(X[0], X[2]) >>`,
+		Id:         "DataBlockRows",
+		NTType:     60,
+		Index:      156,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendValuesRows(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `DataBlockValues : empty	<< ast.NewValuesRow() >>`,
+		Id:         "DataBlockValues",
+		NTType:     61,
+		Index:      157,
+		NumSymbols: 0,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewValuesRow()
+		},
+	},
+	ProdTabEntry{
+		String: `DataBlockValues : DataBlockValues GraphTerm	<< ast.AppendValuesRow(X[0], X[1]) >>`,
+		Id:         "DataBlockValues",
+		NTType:     61,
+		Index:      158,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendValuesRow(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `DataBlockValues : DataBlockValues "UNDEF"	<< ast.AppendUndefValue(X[0]) >>`,
+		Id:         "DataBlockValues",
+		NTType:     61,
+		Index:      159,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendUndefValue(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `OptionalGraphPattern : "OPTIONAL" GroupGraphPattern	<< ast.NewOptionalGroup(X[1]) >>`,
+		Id:         "OptionalGraphPattern",
+		NTType:     62,
+		Index:      160,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewOptionalGroup(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `MinusGraphPattern : "MINUS" GroupGraphPattern	<< ast.NewMinusGroup(X[1]) >>`,
+		Id:         "MinusGraphPattern",
+		NTType:     63,
+		Index:      161,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewMinusGroup(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `Filter : "FILTER" BrackettedExpression	<< ast.NewFilter(X[1]) >>`,
+		Id:         "Filter",
+		NTType:     64,
+		Index:      162,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFilter(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `Filter : "FILTER" FunctionCall	<< ast.NewFilter(X[1]) >>`,
+		Id:         "Filter",
+		NTType:     64,
+		Index:      163,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFilter(X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `Filter : "FILTER" "EXISTS" GroupGraphPattern	<< ast.NewExistsGroup(X[2], false) >>`,
+		Id:         "Filter",
+		NTType:     64,
+		Index:      164,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExistsGroup(X[2], false)
+		},
+	},
+	ProdTabEntry{
+		String: `Filter : "FILTER" "NOT" "EXISTS" GroupGraphPattern	<< ast.NewExistsGroup(X[3], true) >>`,
+		Id:         "Filter",
+		NTType:     64,
+		Index:      165,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExistsGroup(X[3], true)
+		},
+	},
+	ProdTabEntry{
+		String: `Expression : ConditionalOrExpression	<< X[0], nil >>`,
+		Id:         "Expression",
+		NTType:     65,
+		Index:      166,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `ConditionalOrExpression : ConditionalAndExpression	<< X[0], nil >>`,
+		Id:         "ConditionalOrExpression",
+		NTType:     66,
+		Index:      167,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `ConditionalOrExpression : ConditionalOrExpression "||" ConditionalAndExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "ConditionalOrExpression",
+		NTType:     66,
+		Index:      168,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `ConditionalAndExpression : RelationalExpression	<< X[0], nil >>`,
+		Id:         "ConditionalAndExpression",
+		NTType:     67,
+		Index:      169,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `ConditionalAndExpression : ConditionalAndExpression "&&" RelationalExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "ConditionalAndExpression",
+		NTType:     67,
+		Index:      170,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression	<< X[0], nil >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      171,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression "=" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      172,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression "!=" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      173,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression "<" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      174,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression ">" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      175,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression "<=" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      176,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `RelationalExpression : AdditiveExpression ">=" AdditiveExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "RelationalExpression",
+		NTType:     68,
+		Index:      177,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `AdditiveExpression : MultiplicativeExpression	<< X[0], nil >>`,
+		Id:         "AdditiveExpression",
+		NTType:     69,
+		Index:      178,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `AdditiveExpression : AdditiveExpression "+" MultiplicativeExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "AdditiveExpression",
+		NTType:     69,
+		Index:      179,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `AdditiveExpression : AdditiveExpression "-" MultiplicativeExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "AdditiveExpression",
+		NTType:     69,
+		Index:      180,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `MultiplicativeExpression : UnaryExpression	<< X[0], nil >>`,
+		Id:         "MultiplicativeExpression",
+		NTType:     70,
+		Index:      181,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `MultiplicativeExpression : MultiplicativeExpression "*" UnaryExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "MultiplicativeExpression",
+		NTType:     70,
+		Index:      182,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `MultiplicativeExpression : MultiplicativeExpression "/" UnaryExpression	<< ast.NewBinaryExpression(X[0], X[1], X[2]) >>`,
+		Id:         "MultiplicativeExpression",
+		NTType:     70,
+		Index:      183,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBinaryExpression(X[0], X[1], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `UnaryExpression : PrimaryExpression	<< X[0], nil >>`,
+		Id:         "UnaryExpression",
+		NTType:     71,
+		Index:      184,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `UnaryExpression : "!" PrimaryExpression	<< ast.NewUnaryExpression(X[0], X[1]) >>`,
+		Id:         "UnaryExpression",
+		NTType:     71,
+		Index:      185,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUnaryExpression(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `UnaryExpression : "-" PrimaryExpression	<< ast.NewUnaryExpression(X[0], X[1]) >>`,
+		Id:         "UnaryExpression",
+		NTType:     71,
+		Index:      186,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUnaryExpression(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `UnaryExpression : "+" PrimaryExpression	<< ast.NewUnaryExpression(X[0], X[1]) >>`,
+		Id:         "UnaryExpression",
+		NTType:     71,
+		Index:      187,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewUnaryExpression(X[0], X[1])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : BrackettedExpression	<< X[0], nil >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      188,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : FunctionCall	<< X[0], nil >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      189,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : Aggregate	<< X[0], nil >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      190,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : Var	<< ast.NewVarExpression(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      191,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewVarExpression(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : uri	<< ast.NewTermExpression(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      192,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTermExpression(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : url	<< ast.NewTermExpression(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      193,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTermExpression(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : quotedstring	<< ast.NewStringLiteral(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      194,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewStringLiteral(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : quotedstring langtag	<< ast.NewLiteralTermExpression(X[0], X[1], nil) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      195,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLiteralTermExpression(X[0], X[1], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : quotedstring "^^" uri	<< ast.NewLiteralTermExpression(X[0], nil, X[2]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      196,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLiteralTermExpression(X[0], nil, X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : quotedstring "^^" url	<< ast.NewLiteralTermExpression(X[0], nil, X[2]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      197,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewLiteralTermExpression(X[0], nil, X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : integer	<< ast.NewIntegerLiteral(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      198,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIntegerLiteral(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : decimal	<< ast.NewDecimalLiteral(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      199,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewDecimalLiteral(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : "true"	<< ast.NewBooleanLiteral(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      200,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBooleanLiteral(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `PrimaryExpression : "false"	<< ast.NewBooleanLiteral(X[0]) >>`,
+		Id:         "PrimaryExpression",
+		NTType:     72,
+		Index:      201,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewBooleanLiteral(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `BrackettedExpression : "(" Expression ")"	<< X[1], nil >>`,
+		Id:         "BrackettedExpression",
+		NTType:     73,
+		Index:      202,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String: `FunctionCall : string "(" ")"	<< ast.NewFunctionCall(X[0], nil) >>`,
+		Id:         "FunctionCall",
+		NTType:     74,
+		Index:      203,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFunctionCall(X[0], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `FunctionCall : string "(" ExpressionList ")"	<< ast.NewFunctionCall(X[0], X[2]) >>`,
+		Id:         "FunctionCall",
+		NTType:     74,
+		Index:      204,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFunctionCall(X[0], X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "COUNT" "(" "*" ")"	<< ast.NewAggregate(X[0], false, nil) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      205,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAggregate(X[0], false, nil)
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "COUNT" "(" Expression ")"	<< ast.NewAggregate(X[0], false, X[2]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      206,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAggregate(X[0], false, X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "COUNT" "(" "DISTINCT" Expression ")"	<< ast.NewAggregate(X[0], true, X[3]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      207,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAggregate(X[0], true, X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : AggregateName "(" Expression ")"	<< ast.NewAggregate(X[0], false, X[2]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      208,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAggregate(X[0], false, X[2])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : AggregateName "(" "DISTINCT" Expression ")"	<< ast.NewAggregate(X[0], true, X[3]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      209,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAggregate(X[0], true, X[3])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "GROUP_CONCAT" "(" Expression ")"	<< ast.NewGroupConcat(false, X[2], nil) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      210,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewGroupConcat(false, X[2], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "GROUP_CONCAT" "(" "DISTINCT" Expression ")"	<< ast.NewGroupConcat(true, X[3], nil) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      211,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewGroupConcat(true, X[3], nil)
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "GROUP_CONCAT" "(" Expression ";" "SEPARATOR" "=" quotedstring ")"	<< ast.NewGroupConcat(false, X[2], X[6]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      212,
+		NumSymbols: 8,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewGroupConcat(false, X[2], X[6])
+		},
+	},
+	ProdTabEntry{
+		String: `Aggregate : "GROUP_CONCAT" "(" "DISTINCT" Expression ";" "SEPARATOR" "=" quotedstring ")"	<< ast.NewGroupConcat(true, X[3], X[7]) >>`,
+		Id:         "Aggregate",
+		NTType:     75,
+		Index:      213,
+		NumSymbols: 9,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewGroupConcat(true, X[3], X[7])
+		},
+	},
+	ProdTabEntry{
+		String: `AggregateName : "SUM"	<< X[0], nil >>`,
+		Id:         "AggregateName",
+		NTType:     76,
+		Index:      214,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `AggregateName : "MIN"	<< X[0], nil >>`,
+		Id:         "AggregateName",
+		NTType:     76,
+		Index:      215,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `AggregateName : "MAX"	<< X[0], nil >>`,
+		Id:         "AggregateName",
+		NTType:     76,
+		Index:      216,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `AggregateName : "AVG"	<< X[0], nil >>`,
+		Id:         "AggregateName",
+		NTType:     76,
+		Index:      217,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `AggregateName : "SAMPLE"	<< X[0], nil >>`,
+		Id:         "AggregateName",
+		NTType:     76,
+		Index:      218,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String: `ExpressionList : Expression	<< ast.NewExpressionList(X[0]) >>`,
+		Id:         "ExpressionList",
+		NTType:     77,
+		Index:      219,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExpressionList(X[0])
+		},
+	},
+	ProdTabEntry{
+		String: `ExpressionList : ExpressionList "," Expression	<< ast.AppendExpressionList(X[0], X[2]) >>`,
+		Id:         "ExpressionList",
+		NTType:     77,
+		Index:      220,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendExpressionList(X[0], X[2])
 		},
 	},
 }
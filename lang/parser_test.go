@@ -2,7 +2,11 @@ package sparql
 
 import (
 	"fmt"
+	"github.com/gtfierro/hod/lang/ast"
+	"github.com/gtfierro/hod/turtle"
 	"github.com/kr/pretty"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -46,6 +50,72 @@ func TestQueryParse(t *testing.T) {
 		{
 			"SELECT ?x ?y ?z WHERE { ?y rdf:type brick:VAV { ?y bf:isFedBy ?x . ?y bf:hasPoint ?z } UNION { ?y bf:feeds ?x } };",
 		},
+		{
+			"SELECT ?x WHERE { { ?x rdf:type brick:VAV } UNION { ?x rdf:type brick:AHU } . { ?x bf:feeds ?y } UNION { ?x bf:isFedBy ?y } };",
+		},
+		{
+			"SELECT ?x WHERE { ?x rdf:type brick:VAV . FILTER(regex(?x, \"vav_[0-9]+\", \"i\")) };",
+		},
+		{
+			"SELECT ?x ?y WHERE { ?x bf:feeds ?y FILTER (?x != ?y && !(?y = bldg:vav_1)) };",
+		},
+		{
+			"SELECT ?x WHERE { ?x rdfs:label ?l . FILTER STRSTARTS(STR(?l), \"Room\") };",
+		},
+		{
+			"SELECT ?x WHERE { ?x bf:hasPoint ?p . FILTER(?p > 1.5 || ?p <= -2 * (3 + 4) || true) };",
+		},
+		{
+			"SELECT ?x ?u WHERE { ?x rdf:type brick:Sensor . OPTIONAL { ?x bf:hasUnit ?u } };",
+		},
+		{
+			"SELECT ?x ?u ?l WHERE { ?x rdf:type brick:Sensor OPTIONAL { ?x bf:hasUnit ?u OPTIONAL { ?u rdfs:label ?l } } FILTER(!BOUND(?u)) };",
+		},
+		{
+			"SELECT ?x ?y WHERE { ?x (brick:feeds|brick:hasPart)+ ?y };",
+		},
+		{
+			"SELECT ?sensor WHERE { ?room ^bf:isLocatedIn ?sensor };",
+		},
+		{
+			"SELECT ?x ?y WHERE { ?x bf:feeds|^bf:isFedBy/bf:feeds* ?y };",
+		},
+		{
+			"SELECT ?x ?y WHERE { ?x (^(bf:feeds/bf:hasPart)|rdf:type)?/rdfs:subClassOf* ?y };",
+		},
+		{
+			"SELECT ?x WHERE { ?x rdf:type brick:VAV } ORDER BY ?x;",
+		},
+		{
+			"SELECT ?x ?y WHERE { ?x bf:feeds ?y } ORDER BY DESC(?y) ?x LIMIT 10 OFFSET 20;",
+		},
+		{
+			"SELECT ?x WHERE { ?x rdfs:label ?l } ORDER BY ASC(STR(?l)) OFFSET 5 LIMIT 5;",
+		},
+		{
+			"COUNT ?x FROM soda WHERE { ?x rdf:type brick:VAV } LIMIT 0;",
+		},
+		{
+			"PREFIX ex: <http://example.com/building#> SELECT ?x WHERE { ?x rdf:type ex:Room };",
+		},
+		{
+			"BASE <http://example.com/building> PREFIX ex: <#> COUNT ?x FROM soda WHERE { ?x rdf:type <#Room> };",
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:area \"800\"^^xsd:double . ?x rdfs:label \"Floor\"@en-US };",
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:rooms 12 . ?x bldg:height 3.5 . ?x bldg:occupied false };",
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:commissioned ?d . FILTER(?d > \"2017-01-01T00:00:00Z\"^^<http://www.w3.org/2001/XMLSchema#dateTime> && LANG(?l) = \"en\") };",
+		},
+		{
+			"SELECT ?floor (COUNT(?vav) AS ?n) WHERE { ?vav bf:isPartOf ?floor } GROUP BY ?floor HAVING (COUNT(?vav) > 2) ORDER BY DESC(?n) LIMIT 5;",
+		},
+		{
+			"SELECT (AVG(?a) AS ?avg) (GROUP_CONCAT(DISTINCT ?l; SEPARATOR=\", \") AS ?labels) WHERE { ?x bldg:area ?a . ?x rdfs:label ?l };",
+		},
 	} {
 		q, err := Parse(test.str)
 		if err != nil {
@@ -55,6 +125,419 @@ func TestQueryParse(t *testing.T) {
 	}
 }
 
+func TestPathParse(t *testing.T) {
+	for _, test := range []struct {
+		str  string
+		path string
+	}{
+		{"SELECT ?x WHERE { ?x bf:feeds/bf:hasPart+ ?y };", "bf#feeds bf#hasPart+"},
+		{"SELECT ?x WHERE { ?x (bf:feeds) ?y };", "bf#feeds"},
+		{"SELECT ?x WHERE { ?x bf:feeds|bf:hasPart ?y };", "(bf#feeds|bf#hasPart)"},
+		{"SELECT ?x WHERE { ?x (bf:feeds|bf:hasPart)+ ?y };", "(bf#feeds|bf#hasPart)+"},
+		{"SELECT ?x WHERE { ?x ^bf:isLocatedIn ?y };", "^bf#isLocatedIn"},
+		{"SELECT ?x WHERE { ?x ^(bf:feeds/bf:hasPart)* ?y };", "^(bf#feeds/bf#hasPart)*"},
+		{"SELECT ?x WHERE { ?x (bf:feeds+)* ?y };", "(bf#feeds+)*"},
+		{"SELECT ?x WHERE { ?x bf:feeds{1,3} ?y };", "bf#feeds{1,3}"},
+		{"SELECT ?x WHERE { ?x bf:feeds{2} ?y };", "bf#feeds{2}"},
+		{"SELECT ?x WHERE { ?x ^bf:feeds{2,} ?y };", "^bf#feeds{2,}"},
+		{"SELECT ?x WHERE { ?x (bf:feeds|bf:hasPart){,2} ?y };", "(bf#feeds|bf#hasPart){0,2}"},
+		{"SELECT ?x WHERE { ?x bf:feeds{1,} ?y };", "bf#feeds+"},
+		{"SELECT ?x WHERE { ?x bf:feeds{0,1} ?y };", "bf#feeds?"},
+	} {
+		q, err := Parse(test.str)
+		if err != nil {
+			t.Error(test.str, err)
+			continue
+		}
+		var parts []string
+		for _, pp := range q.Where.Terms[0].Predicates {
+			parts = append(parts, pp.String())
+		}
+		if path := strings.Join(parts, " "); path != test.path {
+			t.Errorf("Path for %s was %s, expected %s", test.str, path, test.path)
+		}
+	}
+}
+
+func TestPathRepeatParse(t *testing.T) {
+	q, err := Parse("SELECT ?x WHERE { ?x bf:feeds{1,3} ?y };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pp := q.Where.Terms[0].Predicates[0]; pp.Pattern != ast.PATTERN_BOUNDED || pp.Min != 1 || pp.Max != 3 {
+		t.Errorf("Wrong repetition %+v", pp)
+	}
+	for _, bad := range []string{
+		"SELECT ?x WHERE { ?x bf:feeds{3,1} ?y };",
+		"SELECT ?x WHERE { ?x bf:feeds{-1} ?y };",
+		"SELECT ?x WHERE { ?x ?p{1,2} ?y };",
+	} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("%s should not parse", bad)
+		}
+	}
+}
+
+func TestRouteParse(t *testing.T) {
+	q, err := Parse("SELECT * WHERE { ?ahu rdf:type brick:AHU . ?ahu bf:feeds+ ?zone ROUTE ?route };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if q.Where.Terms[0].Route != "" || q.Where.Terms[1].Route != "?route" {
+		t.Errorf("Wrong routes %v", q.Where.Terms)
+	}
+	if vars := strings.Join(q.Variables, " "); len(q.Variables) != 3 || !strings.Contains(vars, "?route") {
+		t.Errorf("Wrong variables %v", q.Variables)
+	}
+	for _, bad := range []string{
+		"SELECT ?r WHERE { ?x bf:feeds/?p ?y ROUTE ?r };",
+		"SELECT ?r WHERE { ?x ?p ?y ROUTE ?r };",
+		"CONSTRUCT { ?x bf:feeds ?y ROUTE ?r } WHERE { ?x bf:feeds ?y };",
+	} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("%s should not parse", bad)
+		}
+	}
+}
+
+func TestSolutionModifierParse(t *testing.T) {
+	q, err := Parse("SELECT ?x ?y WHERE { ?x bf:feeds ?y } ORDER BY DESC(?y) ?x OFFSET 20 LIMIT 10;")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.OrderBy) != 2 || !q.OrderBy[0].Descending || q.OrderBy[1].Descending {
+		t.Errorf("Wrong ORDER BY %v", q.OrderBy)
+	}
+	if !q.HasLimit || q.Limit != 10 || q.Offset != 20 {
+		t.Errorf("Wrong LIMIT %d/%v or OFFSET %d", q.Limit, q.HasLimit, q.Offset)
+	}
+	if _, err := Parse("SELECT ?x WHERE { ?x bf:feeds ?y } LIMIT -1;"); err == nil {
+		t.Error("Negative LIMIT should not parse")
+	}
+}
+
+func TestGroupByParse(t *testing.T) {
+	q, err := Parse("SELECT ?floor (SUM(?a) / COUNT(*) AS ?avg) (MAX(?a) AS ?max) WHERE { ?room bf:isPartOf ?floor . ?room bldg:area ?a } GROUP BY ?floor HAVING (SUM(?a) > 100) (COUNT(*) > 1);")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.GroupBy) != 1 || q.GroupBy[0] != "?floor" {
+		t.Errorf("Wrong GROUP BY %v", q.GroupBy)
+	}
+	if len(q.Select.Vars) != 3 || len(q.Select.Projections) != 2 || q.Select.Projection("?max") == nil {
+		t.Errorf("Wrong SELECT %v", q.Select)
+	}
+	if len(q.Having) != 2 {
+		t.Errorf("Wrong HAVING %v", q.Having)
+	}
+	// SUM(?a) is used twice but only computed once
+	if aggs := q.Aggregates(); len(aggs) != 3 {
+		t.Errorf("Wrong aggregates %v", aggs)
+	}
+
+	for _, str := range []string{
+		"SELECT ?room (COUNT(?a) AS ?n) WHERE { ?room bldg:area ?a } GROUP BY ?floor;",
+		"SELECT * WHERE { ?room bldg:area ?a } GROUP BY ?room;",
+		"SELECT ?room WHERE { ?room bldg:area ?a . FILTER(COUNT(?a) > 1) };",
+		"SELECT (SUM(COUNT(?a)) AS ?n) WHERE { ?room bldg:area ?a };",
+		"SELECT (?a AS ?b) WHERE { ?room bldg:area ?a };",
+		"SELECT ?room (COUNT(?a) AS ?room) WHERE { ?room bldg:area ?a } GROUP BY ?room;",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestValuesParse(t *testing.T) {
+	q, err := Parse("SELECT ?room ?floor WHERE { VALUES (?room ?floor) { (bldg:room_1 bldg:floor_1) (bldg:room_2 \"2\") } ?room bf:isPartOf ?floor };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	values := q.Where.Values
+	if len(values.Vars) != 2 || len(values.Rows) != 2 {
+		t.Errorf("Wrong VALUES %v", values)
+		return
+	}
+	if values.Rows[1][0] != turtle.ParseURI("bldg:room_2") || values.Rows[1][1] != (turtle.URI{Value: "2"}) {
+		t.Errorf("Wrong VALUES row %v", values.Rows[1])
+	}
+
+	q, err = Parse("SELECT ?x WHERE { ?x rdf:type brick:Room . VALUES ?x { bldg:room_1 bldg:room_2 bldg:room_3 } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Values.Rows) != 3 || len(q.Where.Terms) != 1 {
+		t.Errorf("Wrong VALUES %v", q.Where.Values)
+	}
+
+	// UNDEF leaves the variable unbound, and rows are partitioned by the
+	// variables they bind
+	q, err = Parse("SELECT ?x ?y WHERE { ?x bf:feeds ?y . VALUES (?x ?y) { (bldg:ahu_1 UNDEF) (UNDEF bldg:vav_1) (bldg:ahu_2 UNDEF) } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if q.Where.Values.Rows[0][1] != (turtle.URI{}) {
+		t.Errorf("Wrong VALUES row %v", q.Where.Values.Rows[0])
+	}
+	partitions := q.Where.Values.Partition()
+	if len(partitions) != 2 || !reflect.DeepEqual(partitions[0].Vars, []string{"?x"}) || len(partitions[0].Rows) != 2 ||
+		!reflect.DeepEqual(partitions[1].Vars, []string{"?y"}) || len(partitions[1].Rows) != 1 {
+		t.Errorf("Wrong VALUES partitions %v", partitions)
+	}
+
+	// nested groups can have their own VALUES block
+	q, err = Parse("SELECT ?x ?tag WHERE { ?x bf:feeds ?y . OPTIONAL { VALUES ?tag { \"north\" UNDEF } } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Optionals) != 1 || len(q.Where.Optionals[0].Values) != 1 || len(q.Where.Optionals[0].Values[0].Rows) != 2 {
+		t.Errorf("Wrong OPTIONAL %v", q.Where.Optionals)
+	}
+	if !reflect.DeepEqual(q.Variables, []string{"?tag", "?x", "?y"}) {
+		t.Errorf("Wrong variables %v", q.Variables)
+	}
+
+	for _, str := range []string{
+		"SELECT ?x WHERE { VALUES (?x ?y) { (bldg:room_1) } ?x bf:feeds ?y };",
+		"SELECT ?x WHERE { VALUES (?x ?x) { (bldg:room_1 bldg:room_2) } ?x bf:feeds ?y };",
+		"SELECT ?x WHERE { VALUES ?x { bldg:room_1 } VALUES ?y { bldg:room_2 } ?x bf:feeds ?y };",
+		"SELECT ?x WHERE { ?x bf:feeds ?y . OPTIONAL { VALUES ?y { bldg:room_1 } VALUES ?z { bldg:room_2 } } };",
+		"SELECT ?x WHERE { { ?x bf:feeds ?y } UNION { VALUES ?y { bldg:room_1 } } };",
+		"SELECT ?x WHERE { ?x bf:feeds ?y . OPTIONAL { { ?y bf:feeds ?z } UNION { VALUES ?z { bldg:room_1 } } } };",
+		"SELECT ?x WHERE { VALUES ?x { ?y } ?x bf:feeds ?y };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestNegationParse(t *testing.T) {
+	q, err := Parse("SELECT ?vav WHERE { ?vav rdf:type brick:VAV . FILTER NOT EXISTS { ?s bf:isPointOf ?vav . ?s rdf:type brick:Zone_Temperature_Sensor } MINUS { ?vav bf:isFedBy bldg:ahu_1 } FILTER EXISTS { ?vav bf:feeds ?zone } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Terms) != 1 || len(q.Where.Filters) != 0 {
+		t.Errorf("Wrong WHERE clause %v", q.Where)
+	}
+	if len(q.Where.Minus) != 1 || len(q.Where.Minus[0].Terms) != 1 {
+		t.Errorf("Wrong MINUS %v", q.Where.Minus)
+	}
+	if len(q.Where.Exists) != 2 || !q.Where.Exists[0].Negated || len(q.Where.Exists[0].Terms) != 2 || q.Where.Exists[1].Negated {
+		t.Errorf("Wrong EXISTS %v", q.Where.Exists)
+	}
+	// variables that only appear in MINUS or EXISTS are not in scope
+	if len(q.Variables) != 1 {
+		t.Errorf("Wrong variables %v", q.Variables)
+	}
+
+	q, err = Parse("SELECT ?x WHERE { { ?x bf:feeds ?y } UNION { ?x bf:isPointOf ?y MINUS { ?x rdf:type brick:AHU } } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	branches := q.Where.GraphGroup.Expand()
+	if len(branches) != 2 || len(branches[0].Minus) != 0 || len(branches[1].Minus) != 1 {
+		t.Errorf("Wrong UNION branches %v", branches)
+	}
+
+	q, err = Parse("SELECT ?x WHERE { ?x bf:feeds ?y . MINUS { VALUES ?y { bldg:room_1 } } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Minus) != 1 || len(q.Where.Minus[0].Values) != 1 {
+		t.Errorf("Wrong MINUS %v", q.Where.Minus)
+	}
+}
+
+func TestBindParse(t *testing.T) {
+	q, err := Parse("SELECT ?name WHERE { ?x rdf:type brick:AHU . BIND(STRAFTER(STR(?x), \"#\") AS ?name) . OPTIONAL { ?x rdfs:label ?l . BIND(LCASE(?l) AS ?lower) } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Binds) != 1 || q.Where.Binds[0].Var != "?name" || q.Where.Binds[0].Expression.String() != "STRAFTER(STR(?x), \"#\")" {
+		t.Errorf("Wrong BIND %v", q.Where.Binds)
+	}
+	if len(q.Where.Optionals) != 1 || len(q.Where.Optionals[0].Binds) != 1 {
+		t.Errorf("Wrong OPTIONAL %v", q.Where.Optionals)
+	}
+	vars := make(map[string]bool)
+	for _, varname := range q.Variables {
+		vars[varname] = true
+	}
+	if !vars["?name"] || !vars["?lower"] {
+		t.Errorf("BIND variables missing from %v", q.Variables)
+	}
+
+	for _, str := range []string{
+		"SELECT ?x WHERE { ?x rdf:type brick:AHU . BIND(STR(?x) AS ?x) };",
+		"SELECT ?x WHERE { BIND(\"a\" AS ?y) . ?x rdf:type ?y };",
+		"SELECT ?x WHERE { ?x rdf:type brick:AHU . BIND(1 AS ?y) . BIND(2 AS ?y) };",
+		"SELECT ?x WHERE { ?x rdf:type brick:AHU . BIND(COUNT(?x) AS ?y) };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestSubqueryParse(t *testing.T) {
+	q, err := Parse("BASE <http://example.com/> SELECT ?ahu ?n WHERE { ?ahu rdf:type brick:AHU . { SELECT ?ahu (COUNT(?vav) AS ?n) WHERE { ?ahu bf:feeds ?vav . ?vav rdf:type <vav> } GROUP BY ?ahu } OPTIONAL { { SELECT ?ahu WHERE { ?ahu rdfs:label ?l } LIMIT 1 } } };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Where.Terms) != 1 || len(q.Where.Subqueries) != 1 {
+		t.Errorf("Wrong WHERE clause %v", q.Where)
+		return
+	}
+	sub := q.Where.Subqueries[0]
+	if !reflect.DeepEqual(sub.Select.Vars, []string{"?ahu", "?n"}) || !reflect.DeepEqual(sub.GroupBy, []string{"?ahu"}) || !sub.IsAggregate() {
+		t.Errorf("Wrong subquery %v", sub)
+	}
+	if sub.Where.Terms[1].Object.IRI() != "http://example.com/vav" {
+		t.Errorf("Relative IRI not resolved in subquery: %v", sub.Where.Terms[1])
+	}
+	if len(q.Where.Optionals) != 1 || len(q.Where.Optionals[0].Subqueries) != 1 || !q.Where.Optionals[0].Subqueries[0].HasLimit {
+		t.Errorf("Wrong OPTIONAL %v", q.Where.Optionals)
+	}
+	vars := make(map[string]bool)
+	for _, varname := range q.Variables {
+		vars[varname] = true
+	}
+	if !vars["?n"] || vars["?vav"] || vars["?l"] {
+		t.Errorf("Wrong variables %v", q.Variables)
+	}
+
+	for _, str := range []string{
+		"SELECT ?x WHERE { { SELECT ?x (COUNT(?y) AS ?n) WHERE { ?x bf:feeds ?y } } };",
+		"SELECT ?x WHERE { { SELECT ?x WHERE { ?x bf:feeds ?y } } GROUP BY ?x };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestPathVariableParse(t *testing.T) {
+	q, err := Parse("SELECT ?p ?q WHERE { ?s ?p/bf:hasPoint/^?q ?o };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	path := q.Where.Terms[0].Predicates
+	if len(path) != 3 || path[0].Predicate.String() != "?p" || path[2].Predicate.String() != "?q" || !path[2].Inverse {
+		t.Errorf("Wrong path %v", path)
+	}
+	vars := make(map[string]bool)
+	for _, varname := range q.Variables {
+		vars[varname] = true
+	}
+	if !vars["?p"] || !vars["?q"] {
+		t.Errorf("Path variables missing from %v", q.Variables)
+	}
+
+	for _, str := range []string{
+		"SELECT ?p WHERE { ?s ?p+ ?o };",
+		"SELECT ?p WHERE { ?s (?p|bf:feeds) ?o };",
+		"SELECT ?p WHERE { ?s bf:feeds/(?p/bf:feeds)* ?o };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestLinkParse(t *testing.T) {
+	q, err := Parse("SELECT ?sensor[uuid] ?vav[*] ?zone ?room[uri,uuid] WHERE { ?sensor bf:isPointOf ?vav . ?vav bf:feeds ?zone . ?room bf:isPartOf ?zone };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(q.Select.Vars) != 4 || q.Select.Vars[0] != "?sensor" || q.Select.Vars[1] != "?vav" {
+		t.Errorf("Wrong select vars %v", q.Select.Vars)
+	}
+	links := q.Select.Links
+	if len(links) != 3 {
+		t.Errorf("Wrong links %v", links)
+		return
+	}
+	if links[0].Var != "?sensor" || !links[0].Matches("UUID") || links[0].Matches("URI") {
+		t.Errorf("Wrong link selection %v", links[0])
+	}
+	if links[1].Keys != nil || !links[1].Matches("anything") {
+		t.Errorf("Wrong link selection %v", links[1])
+	}
+	if links[2].Var != "?room" || len(links[2].Keys) != 2 {
+		t.Errorf("Wrong link selection %v", links[2])
+	}
+
+	if _, err := Parse("SELECT ?x ?x[uuid] WHERE { ?x rdf:type brick:Room };"); err == nil {
+		t.Error("Repeated variable should not parse")
+	}
+}
+
+func TestQueryFormsParse(t *testing.T) {
+	for _, test := range []struct {
+		str      string
+		isAsk    bool
+		describe int
+		vars     []string
+	}{
+		{"ASK WHERE { ?x bf:feeds bldg:vav_1 };", true, 0, []string{"?x"}},
+		{"ASK FROM test { ?x bf:feeds ?y };", true, 0, []string{"?x", "?y"}},
+		{"DESCRIBE bldg:vav_1;", false, 1, nil},
+		{"DESCRIBE bldg:vav_1 <http://buildsys.org/ontologies/building_example#ahu_1> FROM test;", false, 2, nil},
+		{"DESCRIBE ?x bldg:vav_1 WHERE { ?x bf:feeds bldg:vav_1 } LIMIT 2;", false, 2, []string{"?x"}},
+		{"DESCRIBE * WHERE { ?x bf:feeds ?y };", false, 0, []string{"?x", "?y"}},
+	} {
+		q, err := Parse(test.str)
+		if err != nil {
+			t.Error(test.str, err)
+			continue
+		}
+		if q.IsAsk() != test.isAsk || q.IsDescribe() == test.isAsk {
+			t.Errorf("%s had the wrong query type %v", test.str, q.Type)
+		}
+		if len(q.Describe.Terms) != test.describe {
+			t.Errorf("%s had DESCRIBE terms %v", test.str, q.Describe.Terms)
+		}
+		if !reflect.DeepEqual(q.Select.Vars, test.vars) {
+			t.Errorf("%s selected %v, expected %v", test.str, q.Select.Vars, test.vars)
+		}
+	}
+
+	q, err := Parse("CONSTRUCT { ?y bf:isFedBy ?x . ?y rdf:type brick:Equipment } FROM test WHERE { ?x bf:feeds ?y } LIMIT 10;")
+	if err != nil {
+		t.Error(err)
+	} else if !q.IsConstruct() || len(q.Construct.Terms) != 2 || len(q.Select.Vars) != 2 || q.Limit != 10 {
+		t.Errorf("Wrong CONSTRUCT query %v %v", q.Construct.Terms, q.Select.Vars)
+	}
+
+	for _, str := range []string{
+		"DESCRIBE ?x;",
+		"DESCRIBE ?z WHERE { ?x bf:feeds ?y };",
+		"CONSTRUCT { ?x bf:feeds ?y };",
+		"ASK SELECT ?x WHERE { ?x bf:feeds ?y };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
 func TestInsertQueryParse(t *testing.T) {
 	for _, test := range []struct {
 		str string
@@ -79,3 +562,214 @@ func TestInsertQueryParse(t *testing.T) {
 		fmt.Printf("%# v", pretty.Formatter(q))
 	}
 }
+
+func TestDeleteQueryParse(t *testing.T) {
+	for _, test := range []struct {
+		str    string
+		delete int
+		insert int
+	}{
+		{
+			"DELETE { ?x bf:feeds ?y } WHERE { ?x bf:feeds ?y };",
+			1, 0,
+		},
+		{
+			"DELETE { ?x bf:feeds ?y } FROM ciee WHERE { ?x bf:feeds ?y . ?x rdf:type brick:AHU };",
+			1, 0,
+		},
+		{
+			"DELETE DATA { bldg:ahu_1 bf:feeds bldg:vav_1 . bldg:vav_1 bf:feeds bldg:hvaczone_1 };",
+			2, 0,
+		},
+		{
+			"DELETE { ?z bf:isPointOf ?v } INSERT { ?z bf:isPointOf bldg:ahu_1 } WHERE { ?z bf:isPointOf ?v };",
+			1, 1,
+		},
+	} {
+		q, err := Parse(test.str)
+		if err != nil {
+			t.Error(test.str, err)
+			continue
+		}
+		if !q.IsDelete() || !q.IsUpdate() {
+			t.Errorf("%s should be a DELETE query", test.str)
+		}
+		if len(q.Delete.Terms) != test.delete || len(q.Insert.Terms) != test.insert {
+			t.Errorf("%s had %d DELETE and %d INSERT terms", test.str, len(q.Delete.Terms), len(q.Insert.Terms))
+		}
+	}
+	if _, err := Parse("DELETE DATA { ?x bf:feeds bldg:vav_1 };"); err == nil {
+		t.Error("DELETE DATA with variables should not parse")
+	}
+}
+
+func TestLiteralParse(t *testing.T) {
+	for _, test := range []struct {
+		str    string
+		object turtle.URI
+	}{
+		{
+			"SELECT ?x WHERE { ?x rdfs:label \"Room 1\" };",
+			turtle.URI{Value: "Room 1"},
+		},
+		{
+			"SELECT ?x WHERE { ?x rdfs:label \"Raum \\\"1\\\"\"@DE };",
+			turtle.LangLiteral("Raum \"1\"", "de"),
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:area \"800\"^^xsd:double };",
+			turtle.TypedLiteral("800", "xsd#double"),
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:area \"800\"^^<http://www.w3.org/2001/XMLSchema#double> };",
+			turtle.TypedLiteral("800", turtle.XSDDouble),
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:name \"AHU\"^^<http://www.w3.org/2001/XMLSchema#string> };",
+			turtle.URI{Value: "AHU"},
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:rooms -12 };",
+			turtle.TypedLiteral("-12", turtle.XSDInteger),
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:height 3.5 };",
+			turtle.TypedLiteral("3.5", turtle.XSDDecimal),
+		},
+		{
+			"SELECT ?x WHERE { ?x bldg:occupied true };",
+			turtle.TypedLiteral("true", turtle.XSDBoolean),
+		},
+	} {
+		q, err := Parse(test.str)
+		if err != nil {
+			t.Error(test.str, err)
+			continue
+		}
+		if object := q.Where.Terms[0].Object; object != test.object {
+			t.Errorf("%s had object %#v, expected %#v", test.str, object, test.object)
+		}
+		// literals survive the round trip through their key encoding
+		if parsed := turtle.ParseURI(test.object.String()); parsed != test.object {
+			t.Errorf("%#v was parsed back as %#v", test.object, parsed)
+		}
+	}
+}
+
+func TestPrologueParse(t *testing.T) {
+	q, err := Parse("BASE <http://example.com/building/> PREFIX ex: <http://example.com/ns#> PREFIX rel: <rel#> SELECT ?x WHERE { ?x ex:feeds <vav_1> . <#ahu_1> rel:feeds ?x };")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if q.Base != "http://example.com/building/" {
+		t.Errorf("Wrong BASE %s", q.Base)
+	}
+	if q.Prefixes["ex"] != "http://example.com/ns" || q.Prefixes["rel"] != "http://example.com/building/rel" {
+		t.Errorf("Wrong prefixes %v", q.Prefixes)
+	}
+	if object := q.Where.Terms[0].Object; object != turtle.ParseURI("http://example.com/building/vav_1") {
+		t.Errorf("Relative IRI was resolved to %#v", object)
+	}
+	if subject := q.Where.Terms[1].Subject; subject != turtle.ParseURI("http://example.com/building/#ahu_1") {
+		t.Errorf("Relative IRI was resolved to %#v", subject)
+	}
+
+	for _, str := range []string{
+		"SELECT ?x WHERE { ?x bf:feeds <vav_1> };",
+		"PREFIX ex: <ns#> SELECT ?x WHERE { ?x ex:feeds ?y };",
+		"PREFIX ex <http://example.com/ns#> SELECT ?x WHERE { ?x ex:feeds ?y };",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%s should not parse", str)
+		}
+	}
+}
+
+func TestExplainParse(t *testing.T) {
+	for _, test := range []struct {
+		query   string
+		explain ast.ExplainMode
+	}{
+		{"SELECT ?x WHERE { ?x rdf:type brick:AHU };", ast.EXPLAIN_NONE},
+		{"EXPLAIN SELECT ?x WHERE { ?x rdf:type brick:AHU };", ast.EXPLAIN_PLAN},
+		{"EXPLAIN ANALYZE SELECT ?x WHERE { ?x rdf:type brick:AHU };", ast.EXPLAIN_ANALYZE},
+		{"EXPLAIN PREFIX brick: <https://brickschema.org/schema/1.0.3/Brick#> ASK { ?x rdf:type brick:AHU };", ast.EXPLAIN_PLAN},
+		{"EXPLAIN ANALYZE COUNT ?x WHERE { ?x rdf:type brick:AHU };", ast.EXPLAIN_ANALYZE},
+	} {
+		q, err := Parse(test.query)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if q.Explain != test.explain {
+			t.Errorf("Query %s should have EXPLAIN mode %v, got %v", test.query, test.explain, q.Explain)
+		}
+	}
+	for _, bad := range []string{
+		"EXPLAIN INSERT { ?x rdf:type brick:AHU } WHERE { ?x rdf:type brick:VAV };",
+		"EXPLAIN EXPLAIN SELECT ?x WHERE { ?x rdf:type brick:AHU };",
+		"ANALYZE SELECT ?x WHERE { ?x rdf:type brick:AHU };",
+	} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("%s should not parse", bad)
+		}
+	}
+}
+
+func TestNoCacheParse(t *testing.T) {
+	for _, test := range []struct {
+		query   string
+		nocache bool
+	}{
+		{"SELECT ?x WHERE { ?x rdf:type brick:AHU };", false},
+		{"NOCACHE SELECT ?x WHERE { ?x rdf:type brick:AHU };", true},
+		{"NOCACHE COUNT ?x WHERE { ?x rdf:type brick:AHU };", true},
+		{"NOCACHE PREFIX brick: <https://brickschema.org/schema/1.0.3/Brick#> SELECT ?x WHERE { ?x rdf:type brick:AHU };", true},
+	} {
+		q, err := Parse(test.query)
+		if err != nil {
+			t.Error(test.query, err)
+			continue
+		}
+		if q.NoCache != test.nocache {
+			t.Errorf("Query %s should have NOCACHE %v, got %v", test.query, test.nocache, q.NoCache)
+		}
+		if q.Copy().NoCache != test.nocache {
+			t.Errorf("Copy of query %s should have NOCACHE %v", test.query, test.nocache)
+		}
+	}
+	if _, err := Parse("SELECT NOCACHE ?x WHERE { ?x rdf:type brick:AHU };"); err == nil {
+		t.Error("NOCACHE should only be allowed before the query")
+	}
+}
+
+func TestQueryDeepCopy(t *testing.T) {
+	for _, querystring := range []string{
+		"SELECT ?x WHERE { ?x rdf:type/rdfs:subClassOf* brick:AHU . FILTER(?x != bldg:ahu_1) };",
+		"SELECT ?x WHERE { { ?x rdf:type brick:AHU } UNION { ?x rdf:type brick:VAV } OPTIONAL { ?x bf:feeds bldg:vav_1 } VALUES ?x { bldg:ahu_1 } };",
+		"SELECT ?x WHERE { ?x rdf:type brick:AHU MINUS { ?x bf:feeds bldg:vav_1 } FILTER NOT EXISTS { ?x bf:isFedBy bldg:ahu_2 } } ORDER BY DESC(?x);",
+		"SELECT ?x WHERE { ?x rdf:type brick:AHU { SELECT ?x WHERE { ?x bf:feeds/bf:hasPart+ bldg:room_1 } } };",
+		"INSERT { ?x rdf:type brick:AHU } WHERE { ?x rdf:type brick:VAV };",
+	} {
+		q, err := Parse(querystring)
+		if err != nil {
+			t.Error(querystring, err)
+			continue
+		}
+		original, _ := Parse(querystring)
+		copied := q.DeepCopy()
+		copied.MapURIs(func(uri turtle.URI) turtle.URI {
+			if !uri.IsVariable() {
+				uri.Namespace = "http://example.com/copy#"
+			}
+			return uri
+		})
+		if !reflect.DeepEqual(q, original) {
+			t.Errorf("Expanding a copy of %s changed the query", querystring)
+		}
+		if reflect.DeepEqual(*copied, original) {
+			t.Errorf("Copy of %s was not expanded", querystring)
+		}
+	}
+}
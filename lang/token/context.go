@@ -0,0 +1,14 @@
+// Code generated by gocc; DO NOT EDIT.
+
+package token
+
+// Context allows user-defined data to be associated with the
+// lexer/scanner to be associated with each token that lexer
+// produces.
+type Context interface{}
+
+// Sourcer is a Context interface which presents a Source() method
+// identifying e.g the filename for the current code.
+type Sourcer interface {
+	Source() string
+}
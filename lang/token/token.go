@@ -3,7 +3,10 @@
 package token
 
 import (
+	"bytes"
 	"fmt"
+	"strconv"
+	"unicode/utf8"
 )
 
 type Token struct {
@@ -20,13 +23,20 @@ const (
 )
 
 type Pos struct {
-	Offset int
-	Line   int
-	Column int
+	Offset  int
+	Line    int
+	Column  int
+	Context Context
 }
 
 func (p Pos) String() string {
-	return fmt.Sprintf("Pos(offset=%d, line=%d, column=%d)", p.Offset, p.Line, p.Column)
+	// If the context provides a filename, provide a human-readable File:Line:Column representation.
+	switch src := p.Context.(type) {
+	case Sourcer:
+		return fmt.Sprintf("%s:%d:%d", src.Source(), p.Line, p.Column)
+	default:
+		return fmt.Sprintf("Pos(offset=%d, line=%d, column=%d)", p.Offset, p.Line, p.Column)
+	}
 }
 
 type TokenMap struct {
@@ -49,7 +59,6 @@ func (m TokenMap) Type(tok string) Type {
 }
 
 func (m TokenMap) TokenString(tok *Token) string {
-	//TODO: refactor to print pos & token string properly
 	return fmt.Sprintf("%s(%d,%s)", m.Id(tok.Type), tok.Type, tok.Lit)
 }
 
@@ -57,60 +66,244 @@ func (m TokenMap) StringType(typ Type) string {
 	return fmt.Sprintf("%s(%d)", m.Id(typ), typ)
 }
 
+// Equals returns returns true if the token Type and Lit are matches.
+func (t *Token) Equals(rhs interface{}) bool {
+	switch rhsT := rhs.(type) {
+	case *Token:
+		return t == rhsT || (t.Type == rhsT.Type && bytes.Equal(t.Lit, rhsT.Lit))
+	default:
+		return false
+	}
+}
+
+// CharLiteralValue returns the string value of the char literal.
+func (t *Token) CharLiteralValue() string {
+	return string(t.Lit[1 : len(t.Lit)-1])
+}
+
+// Float32Value returns the float32 value of the token or an error if the token literal does not
+// denote a valid float32.
+func (t *Token) Float32Value() (float32, error) {
+	if v, err := strconv.ParseFloat(string(t.Lit), 32); err != nil {
+		return 0, err
+	} else {
+		return float32(v), nil
+	}
+}
+
+// Float64Value returns the float64 value of the token or an error if the token literal does not
+// denote a valid float64.
+func (t *Token) Float64Value() (float64, error) {
+	return strconv.ParseFloat(string(t.Lit), 64)
+}
+
+// IDValue returns the string representation of an identifier token.
+func (t *Token) IDValue() string {
+	return string(t.Lit)
+}
+
+// Int32Value returns the int32 value of the token or an error if the token literal does not
+// denote a valid float64.
+func (t *Token) Int32Value() (int32, error) {
+	if v, err := strconv.ParseInt(string(t.Lit), 10, 64); err != nil {
+		return 0, err
+	} else {
+		return int32(v), nil
+	}
+}
+
+// Int64Value returns the int64 value of the token or an error if the token literal does not
+// denote a valid float64.
+func (t *Token) Int64Value() (int64, error) {
+	return strconv.ParseInt(string(t.Lit), 10, 64)
+}
+
+// UTF8Rune decodes the UTF8 rune in the token literal. It returns utf8.RuneError if
+// the token literal contains an invalid rune.
+func (t *Token) UTF8Rune() (rune, error) {
+	r, _ := utf8.DecodeRune(t.Lit)
+	if r == utf8.RuneError {
+		err := fmt.Errorf("Invalid rune")
+		return r, err
+	}
+	return r, nil
+}
+
+// StringValue returns the string value of the token literal.
+func (t *Token) StringValue() string {
+	return string(t.Lit[1 : len(t.Lit)-1])
+}
+
 var TokMap = TokenMap{
 	typeMap: []string{
 		"INVALID",
-		"$",
+		"␚",
+		"NOCACHE",
+		"EXPLAIN",
+		"ANALYZE",
 		";",
+		"empty",
+		"BASE",
+		"url",
+		"PREFIX",
+		"pname_ns",
+		"ASK",
 		"SELECT",
 		"*",
+		"INSERT",
+		"{",
+		"}",
+		".",
+		"CONSTRUCT",
+		"DESCRIBE",
+		"uri",
+		"DELETE",
+		"DATA",
+		"[",
+		"]",
+		"(",
+		"AS",
+		")",
+		",",
 		"COUNT",
 		"string",
 		"var",
 		"FROM",
 		"WHERE",
-		"{",
-		"}",
-		".",
-		"uri",
+		"GROUP",
+		"BY",
+		"HAVING",
+		"ORDER",
+		"ASC",
+		"DESC",
+		"LIMIT",
+		"integer",
+		"OFFSET",
+		"decimal",
+		"true",
+		"false",
 		"quotedstring",
-		"url",
+		"langtag",
+		"^^",
 		"|",
 		"/",
+		"^",
 		"a",
-		"(",
-		")",
 		"?",
 		"+",
-		"empty",
 		"UNION",
+		"ROUTE",
+		"BIND",
+		"VALUES",
+		"UNDEF",
+		"OPTIONAL",
+		"MINUS",
+		"FILTER",
+		"EXISTS",
+		"NOT",
+		"||",
+		"&&",
+		"=",
+		"!=",
+		"<",
+		">",
+		"<=",
+		">=",
+		"-",
+		"!",
+		"DISTINCT",
+		"GROUP_CONCAT",
+		"SEPARATOR",
+		"SUM",
+		"MIN",
+		"MAX",
+		"AVG",
+		"SAMPLE",
 	},
 
 	idMap: map[string]Type{
 		"INVALID":      0,
-		"$":            1,
-		";":            2,
-		"SELECT":       3,
-		"*":            4,
-		"COUNT":        5,
-		"string":       6,
-		"var":          7,
-		"FROM":         8,
-		"WHERE":        9,
-		"{":            10,
-		"}":            11,
-		".":            12,
-		"uri":          13,
-		"quotedstring": 14,
-		"url":          15,
-		"|":            16,
-		"/":            17,
-		"a":            18,
-		"(":            19,
-		")":            20,
-		"?":            21,
-		"+":            22,
-		"empty":        23,
-		"UNION":        24,
+		"␚":            1,
+		"NOCACHE":      2,
+		"EXPLAIN":      3,
+		"ANALYZE":      4,
+		";":            5,
+		"empty":        6,
+		"BASE":         7,
+		"url":          8,
+		"PREFIX":       9,
+		"pname_ns":     10,
+		"ASK":          11,
+		"SELECT":       12,
+		"*":            13,
+		"INSERT":       14,
+		"{":            15,
+		"}":            16,
+		".":            17,
+		"CONSTRUCT":    18,
+		"DESCRIBE":     19,
+		"uri":          20,
+		"DELETE":       21,
+		"DATA":         22,
+		"[":            23,
+		"]":            24,
+		"(":            25,
+		"AS":           26,
+		")":            27,
+		",":            28,
+		"COUNT":        29,
+		"string":       30,
+		"var":          31,
+		"FROM":         32,
+		"WHERE":        33,
+		"GROUP":        34,
+		"BY":           35,
+		"HAVING":       36,
+		"ORDER":        37,
+		"ASC":          38,
+		"DESC":         39,
+		"LIMIT":        40,
+		"integer":      41,
+		"OFFSET":       42,
+		"decimal":      43,
+		"true":         44,
+		"false":        45,
+		"quotedstring": 46,
+		"langtag":      47,
+		"^^":           48,
+		"|":            49,
+		"/":            50,
+		"^":            51,
+		"a":            52,
+		"?":            53,
+		"+":            54,
+		"UNION":        55,
+		"ROUTE":        56,
+		"BIND":         57,
+		"VALUES":       58,
+		"UNDEF":        59,
+		"OPTIONAL":     60,
+		"MINUS":        61,
+		"FILTER":       62,
+		"EXISTS":       63,
+		"NOT":          64,
+		"||":           65,
+		"&&":           66,
+		"=":            67,
+		"!=":           68,
+		"<":            69,
+		">":            70,
+		"<=":           71,
+		">=":           72,
+		"-":            73,
+		"!":            74,
+		"DISTINCT":     75,
+		"GROUP_CONCAT": 76,
+		"SEPARATOR":    77,
+		"SUM":          78,
+		"MIN":          79,
+		"MAX":          80,
+		"AVG":          81,
+		"SAMPLE":       82,
 	},
 }
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gtfierro/hod/turtle"
+)
+
+const (
+	turtleContentType   = "text/turtle; charset=utf-8"
+	nTriplesContentType = "application/n-triples; charset=utf-8"
+)
+
+// prefixed names are only written for local names that need no escaping
+var localName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// returns the content type to serialize triples as: N-Triples if the request
+// asks for it with ?format=ntriples or its Accept header, otherwise Turtle
+func graphContentType(req *http.Request) string {
+	format := req.URL.Query().Get("format")
+	if format == "ntriples" || format == "nt" || strings.Contains(req.Header.Get("Accept"), "application/n-triples") {
+		return nTriplesContentType
+	}
+	return turtleContentType
+}
+
+// writes the triples in the format named by the content type
+func writeGraph(w io.Writer, contentType string, namespaces map[string]string, triples []turtle.Triple) error {
+	if contentType == nTriplesContentType {
+		return writeNTriples(w, triples)
+	}
+	return writeTurtle(w, namespaces, triples)
+}
+
+// writes one triple per line with full IRIs
+func writeNTriples(w io.Writer, triples []turtle.Triple) error {
+	bw := bufio.NewWriter(w)
+	for _, triple := range triples {
+		bw.WriteString(ntriplesTerm(triple.Subject))
+		bw.WriteByte(' ')
+		bw.WriteString(ntriplesTerm(triple.Predicate))
+		bw.WriteByte(' ')
+		bw.WriteString(ntriplesTerm(triple.Object))
+		bw.WriteString(" .\n")
+	}
+	return bw.Flush()
+}
+
+// writes the triples as Turtle, grouped by subject and abbreviated with the
+// namespace prefixes. The triples are expected to be sorted by subject
+func writeTurtle(w io.Writer, namespaces map[string]string, triples []turtle.Triple) error {
+	bw := bufio.NewWriter(w)
+	prefixes := make(map[string]string, len(namespaces))
+	var abbrs []string
+	for abbr, full := range namespaces {
+		prefixes[full] = abbr
+		abbrs = append(abbrs, abbr)
+	}
+	sort.Strings(abbrs)
+	for _, abbr := range abbrs {
+		bw.WriteString("@prefix " + abbr + ": <" + namespaces[abbr] + "#> .\n")
+	}
+	if len(abbrs) > 0 {
+		bw.WriteByte('\n')
+	}
+
+	term := func(uri turtle.URI) string {
+		if abbr, found := prefixes[uri.Namespace]; found && uri.Namespace != "" && localName.MatchString(uri.Value) {
+			return abbr + ":" + uri.Value
+		}
+		return ntriplesTerm(uri)
+	}
+	for idx, triple := range triples {
+		switch {
+		case idx > 0 && triples[idx-1].Subject == triple.Subject:
+			bw.WriteString(" ;\n    ")
+		case idx > 0:
+			bw.WriteString(" .\n")
+			fallthrough
+		default:
+			bw.WriteString(term(triple.Subject) + "\n    ")
+		}
+		bw.WriteString(term(triple.Predicate) + " " + term(triple.Object))
+	}
+	if len(triples) > 0 {
+		bw.WriteString(" .\n")
+	}
+	return bw.Flush()
+}
+
+// returns the N-Triples form of an IRI or literal
+func ntriplesTerm(uri turtle.URI) string {
+	switch {
+	case uri.Namespace != "":
+		return "<" + uri.IRI() + ">"
+	case uri.Datatype != "":
+		return strconv.Quote(uri.Value) + "^^<" + uri.Datatype + ">"
+	case uri.Lang != "":
+		return strconv.Quote(uri.Value) + "@" + uri.Lang
+	}
+	return strconv.Quote(uri.Value)
+}
@@ -2,20 +2,25 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"io"
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gtfierro/hod/config"
 	hod "github.com/gtfierro/hod/db"
 	query "github.com/gtfierro/hod/lang"
+	sparql "github.com/gtfierro/hod/lang/ast"
 	_ "github.com/gtfierro/hod/server/statik"
+	"github.com/gtfierro/hod/turtle"
 	"github.com/rakyll/statik/fs"
 
 	"github.com/op/go-logging"
+	"github.com/pkg/errors"
 	"github.com/pkg/profile"
 	"golang.org/x/crypto/acme/autocert"
 )
@@ -86,6 +91,7 @@ func StartHodServer(db *hod.HodDB, cfg *config.Config) *http.Server {
 	http.HandleFunc("/api/querydot", server.handleQueryDot)
 	http.HandleFunc("/api/queryclassdot", server.handleQueryClassDot)
 	http.HandleFunc("/api/search", server.handleSearch)
+	http.HandleFunc("/api/querycache", server.handleQueryCache)
 	log.Notice("Starting HTTP Server on ", addrString)
 
 	var srv *http.Server
@@ -135,11 +141,62 @@ func (srv *hodServer) handleQuery(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// evaluate query
-	res, err := srv.db.RunQuery(parsed)
+	// ?timeout=10s cancels the query if it runs longer than that. The
+	// QueryTimeout of the config still applies
+	goctx := req.Context()
+	if timeout := req.URL.Query().Get("timeout"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			log.Error(err)
+			rw.WriteHeader(400)
+			rw.Write([]byte(err.Error()))
+			return
+		}
+		var cancel context.CancelFunc
+		goctx, cancel = context.WithTimeout(goctx, d)
+		defer cancel()
+	}
+
+	// ?explain=true or ?explain=analyze explain the query like the EXPLAIN
+	// and EXPLAIN ANALYZE prefixes
+	switch req.URL.Query().Get("explain") {
+	case "":
+	case "analyze":
+		parsed.Explain = sparql.EXPLAIN_ANALYZE
+	default:
+		parsed.Explain = sparql.EXPLAIN_PLAN
+	}
+
+	// evaluate query. ASK results and query plans are returned as JSON like
+	// SELECT results; CONSTRUCT and DESCRIBE return a graph. SELECT results
+	// may be streamed one row per line
+	var res interface{}
+	switch {
+	case parsed.IsExplain():
+		res, err = srv.db.ExplainContext(goctx, parsed)
+	case parsed.IsAsk():
+		res, err = srv.db.AskContext(goctx, parsed)
+	case parsed.IsConstruct():
+		var graph hod.ConstructResult
+		if graph, err = srv.db.ConstructContext(goctx, parsed); err == nil {
+			srv.writeGraph(rw, req, graph.Namespaces, graph.Triples)
+			return
+		}
+	case parsed.IsDescribe():
+		var graph hod.DescribeResult
+		if graph, err = srv.db.DescribeContext(goctx, parsed); err == nil {
+			srv.writeGraph(rw, req, graph.Namespaces, graph.Triples)
+			return
+		}
+	case streamRequested(req) && !parsed.IsUpdate() && !parsed.Count:
+		srv.streamRows(rw, goctx, parsed)
+		return
+	default:
+		res, err = srv.db.RunQueryContext(goctx, parsed)
+	}
 	if err != nil {
 		log.Error(err)
-		rw.WriteHeader(500)
+		rw.WriteHeader(queryErrorStatus(err))
 		rw.Write([]byte(err.Error()))
 		return
 	}
@@ -156,6 +213,29 @@ func (srv *hodServer) handleQuery(rw http.ResponseWriter, req *http.Request) {
 	return
 }
 
+// returns the HTTP status for an error from running a query. Queries that
+// run past their deadline get 408; those canceled for another reason, e.g.
+// because the server is shutting down, get 503
+func queryErrorStatus(err error) int {
+	if canceled, ok := errors.Cause(err).(*hod.QueryCanceledError); ok {
+		if canceled.Timeout() {
+			return http.StatusRequestTimeout
+		}
+		return http.StatusServiceUnavailable
+	}
+	return 500
+}
+
+// serializes the triples of a CONSTRUCT or DESCRIBE query as Turtle or
+// N-Triples
+func (srv *hodServer) writeGraph(rw http.ResponseWriter, req *http.Request, namespaces map[string]string, triples []turtle.Triple) {
+	contentType := graphContentType(req)
+	rw.Header().Set("Content-Type", contentType)
+	if err := writeGraph(rw, contentType, namespaces, triples); err != nil {
+		log.Error(err)
+	}
+}
+
 func (srv *hodServer) handleSearch(rw http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
@@ -184,6 +264,17 @@ func (srv *hodServer) handleSearch(rw http.ResponseWriter, req *http.Request) {
 	return
 }
 
+// reports the hits and misses of the query cache of each database
+func (srv *hodServer) handleQueryCache(rw http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(rw).Encode(srv.db.QueryCacheStats()); err != nil {
+		log.Error(err)
+		rw.WriteHeader(500)
+		rw.Write([]byte(err.Error()))
+	}
+}
+
 func (srv *hodServer) handleQueryDot(rw http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 	log.Infof("QueryDot from %s", req.RemoteAddr)
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	sparql "github.com/gtfierro/hod/lang/ast"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// the number of rows written between flushes of a streamed response
+const streamFlushRows = 256
+
+// true if the rows of the query should be streamed as newline-delimited JSON,
+// which is asked for with ?stream=true or by accepting application/x-ndjson
+func streamRequested(req *http.Request) bool {
+	return req.URL.Query().Get("stream") == "true" || strings.Contains(req.Header.Get("Accept"), ndjsonContentType)
+}
+
+// writes each row of the query as a line of JSON as soon as it is found. If
+// the query fails before any row is written, the response has the status of
+// the error; afterwards, or if some databases could not be queried, the last
+// line is {"Errors": [...]}
+func (srv *hodServer) streamRows(rw http.ResponseWriter, goctx context.Context, q *sparql.Query) {
+	iter, err := srv.db.QueryIter(goctx, q)
+	if err != nil {
+		log.Error(err)
+		rw.WriteHeader(400)
+		rw.Write([]byte(err.Error()))
+		return
+	}
+	defer iter.Close()
+
+	var (
+		encoder    = json.NewEncoder(rw)
+		flusher, _ = rw.(http.Flusher)
+		written    int
+	)
+	for iter.Next() {
+		if written == 0 {
+			rw.Header().Set("Content-Type", ndjsonContentType)
+		}
+		if err := encoder.Encode(iter.Row()); err != nil {
+			// the client has gone away
+			log.Error(err)
+			return
+		}
+		written++
+		if flusher != nil && written%streamFlushRows == 0 {
+			flusher.Flush()
+		}
+	}
+
+	errs := iter.Errors()
+	if err := iter.Err(); err != nil {
+		log.Error(err)
+		if written == 0 {
+			rw.WriteHeader(queryErrorStatus(err))
+			rw.Write([]byte(err.Error()))
+			return
+		}
+		errs = append(errs, err.Error())
+	}
+	if written == 0 {
+		rw.Header().Set("Content-Type", ndjsonContentType)
+	}
+	if len(errs) > 0 {
+		if err := encoder.Encode(struct{ Errors []string }{errs}); err != nil {
+			log.Error(err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -27,27 +28,79 @@ type Parser struct {
 type URI struct {
 	Namespace string `msg:"n"`
 	Value     string `msg:"v"`
+	// Datatype is the full IRI of the datatype of a typed literal, and Lang
+	// is the language tag of a literal. Both are empty for IRIs and for plain
+	// literals
+	Datatype string `msg:"d" json:",omitempty"`
+	Lang     string `msg:"l" json:",omitempty"`
+}
+
+const XSDNamespace = "http://www.w3.org/2001/XMLSchema"
+
+// the XML Schema datatypes with special handling in queries
+const (
+	XSDString   = XSDNamespace + "#string"
+	XSDInteger  = XSDNamespace + "#integer"
+	XSDDecimal  = XSDNamespace + "#decimal"
+	XSDDouble   = XSDNamespace + "#double"
+	XSDBoolean  = XSDNamespace + "#boolean"
+	XSDDateTime = XSDNamespace + "#dateTime"
+)
+
+// Returns a literal with the given datatype IRI
+func TypedLiteral(value, datatype string) URI {
+	return URI{Value: value, Datatype: datatype}
+}
+
+// Returns a literal with the given language tag
+func LangLiteral(value, lang string) URI {
+	return URI{Value: value, Lang: strings.ToLower(lang)}
 }
 
 func (u URI) String() string {
-	if u.Namespace != "" {
+	switch {
+	case u.Namespace != "":
 		return u.Namespace + "#" + u.Value
+	case u.Datatype != "":
+		return strconv.Quote(u.Value) + "^^<" + u.Datatype + ">"
+	case u.Lang != "":
+		return strconv.Quote(u.Value) + "@" + u.Lang
 	}
 	return u.Value
 }
 
-func (u URI) Bytes() []byte {
-	if u.Namespace != "" {
-		return []byte(u.Namespace + "#" + u.Value)
+// Returns the full form of an IRI. ParseURI splits IRIs without a fragment,
+// like http://example.com/a or urn:a:b, at the end of their scheme, so those
+// are joined with ':' instead of '#'
+func (u URI) IRI() string {
+	if !strings.Contains(u.Namespace, "/") {
+		return u.Namespace + ":" + u.Value
 	}
-	return []byte(u.Value)
+	return u.Namespace + "#" + u.Value
+}
+
+// The key of the URI in the entity index. Typed and language-tagged literals
+// are encoded in their N-Triples form so that ParseURI can recover them;
+// IRIs and plain literals keep their original encoding
+func (u URI) Bytes() []byte {
+	return []byte(u.String())
 }
 
 func (u URI) IsVariable() bool {
 	return strings.HasPrefix(u.Value, "?")
 }
 
+// Returns true if the URI is a literal rather than an IRI or a variable
+func (u URI) IsLiteral() bool {
+	return u.Namespace == "" && !u.IsVariable()
+}
+
 func ParseURI(uri string) URI {
+	if strings.HasPrefix(uri, "\"") {
+		if lit, ok := parseLiteral(uri); ok {
+			return lit
+		}
+	}
 	uri = strings.TrimLeft(uri, "<")
 	uri = strings.TrimRight(uri, ">")
 	parts := strings.Split(uri, "#")
@@ -70,6 +123,51 @@ func ParseURI(uri string) URI {
 	return URI{Namespace: parts[0], Value: parts[1]}
 }
 
+// parses a literal in N-Triples or Turtle form: "value", "value"@lang,
+// "value"^^<datatype> or "value"^^prefix:datatype. Prefixed datatypes are
+// left for the caller to expand
+func parseLiteral(lit string) (URI, bool) {
+	// find the closing quote, skipping escaped characters
+	end := -1
+	for idx := 1; idx < len(lit); idx++ {
+		if lit[idx] == '\\' {
+			idx++
+		} else if lit[idx] == '"' {
+			end = idx
+			break
+		}
+	}
+	if end < 0 {
+		return URI{}, false
+	}
+	value, err := strconv.Unquote(lit[:end+1])
+	if err != nil {
+		value = lit[1:end]
+	}
+	suffix := lit[end+1:]
+	switch {
+	case suffix == "":
+		return URI{Value: value}, true
+	case strings.HasPrefix(suffix, "@"):
+		return LangLiteral(value, suffix[1:]), true
+	case strings.HasPrefix(suffix, "^^<") && strings.HasSuffix(suffix, ">"):
+		datatype := suffix[3 : len(suffix)-1]
+		if datatype == XSDString {
+			return URI{Value: value}, true
+		}
+		return TypedLiteral(value, datatype), true
+	case strings.HasPrefix(suffix, "^^"):
+		// keep the prefixed name in the same "prefix#name" form as other
+		// unexpanded URIs
+		parts := strings.SplitN(suffix[2:], ":", 2)
+		if len(parts) != 2 {
+			return URI{}, false
+		}
+		return TypedLiteral(value, parts[0]+"#"+parts[1]), true
+	}
+	return URI{}, false
+}
+
 type Triple struct {
 	Subject   URI `msg:"s"`
 	Predicate URI `msg:"p"`
@@ -49,6 +49,16 @@ func (z *Triple) DecodeMsg(dc *msgp.Reader) (err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Subject.Datatype, err = dc.ReadString()
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Subject.Lang, err = dc.ReadString()
+					if err != nil {
+						return
+					}
 				default:
 					err = dc.Skip()
 					if err != nil {
@@ -79,6 +89,16 @@ func (z *Triple) DecodeMsg(dc *msgp.Reader) (err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Predicate.Datatype, err = dc.ReadString()
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Predicate.Lang, err = dc.ReadString()
+					if err != nil {
+						return
+					}
 				default:
 					err = dc.Skip()
 					if err != nil {
@@ -109,6 +129,16 @@ func (z *Triple) DecodeMsg(dc *msgp.Reader) (err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Object.Datatype, err = dc.ReadString()
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Object.Lang, err = dc.ReadString()
+					if err != nil {
+						return
+					}
 				default:
 					err = dc.Skip()
 					if err != nil {
@@ -130,9 +160,9 @@ func (z *Triple) DecodeMsg(dc *msgp.Reader) (err error) {
 func (z *Triple) EncodeMsg(en *msgp.Writer) (err error) {
 	// map header, size 3
 	// write "s"
-	// map header, size 2
+	// map header, size 4
 	// write "n"
-	err = en.Append(0x83, 0xa1, 0x73, 0x82, 0xa1, 0x6e)
+	err = en.Append(0x83, 0xa1, 0x73, 0x84, 0xa1, 0x6e)
 	if err != nil {
 		return err
 	}
@@ -149,10 +179,28 @@ func (z *Triple) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
+	// write "d"
+	err = en.Append(0xa1, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Subject.Datatype)
+	if err != nil {
+		return
+	}
+	// write "l"
+	err = en.Append(0xa1, 0x6c)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Subject.Lang)
+	if err != nil {
+		return
+	}
 	// write "p"
-	// map header, size 2
+	// map header, size 4
 	// write "n"
-	err = en.Append(0xa1, 0x70, 0x82, 0xa1, 0x6e)
+	err = en.Append(0xa1, 0x70, 0x84, 0xa1, 0x6e)
 	if err != nil {
 		return err
 	}
@@ -169,10 +217,28 @@ func (z *Triple) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
+	// write "d"
+	err = en.Append(0xa1, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Predicate.Datatype)
+	if err != nil {
+		return
+	}
+	// write "l"
+	err = en.Append(0xa1, 0x6c)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Predicate.Lang)
+	if err != nil {
+		return
+	}
 	// write "o"
-	// map header, size 2
+	// map header, size 4
 	// write "n"
-	err = en.Append(0xa1, 0x6f, 0x82, 0xa1, 0x6e)
+	err = en.Append(0xa1, 0x6f, 0x84, 0xa1, 0x6e)
 	if err != nil {
 		return err
 	}
@@ -189,6 +255,24 @@ func (z *Triple) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
+	// write "d"
+	err = en.Append(0xa1, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Object.Datatype)
+	if err != nil {
+		return
+	}
+	// write "l"
+	err = en.Append(0xa1, 0x6c)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Object.Lang)
+	if err != nil {
+		return
+	}
 	return
 }
 
@@ -197,29 +281,47 @@ func (z *Triple) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 3
 	// string "s"
-	// map header, size 2
+	// map header, size 4
 	// string "n"
-	o = append(o, 0x83, 0xa1, 0x73, 0x82, 0xa1, 0x6e)
+	o = append(o, 0x83, 0xa1, 0x73, 0x84, 0xa1, 0x6e)
 	o = msgp.AppendString(o, z.Subject.Namespace)
 	// string "v"
 	o = append(o, 0xa1, 0x76)
 	o = msgp.AppendString(o, z.Subject.Value)
+	// string "d"
+	o = append(o, 0xa1, 0x64)
+	o = msgp.AppendString(o, z.Subject.Datatype)
+	// string "l"
+	o = append(o, 0xa1, 0x6c)
+	o = msgp.AppendString(o, z.Subject.Lang)
 	// string "p"
-	// map header, size 2
+	// map header, size 4
 	// string "n"
-	o = append(o, 0xa1, 0x70, 0x82, 0xa1, 0x6e)
+	o = append(o, 0xa1, 0x70, 0x84, 0xa1, 0x6e)
 	o = msgp.AppendString(o, z.Predicate.Namespace)
 	// string "v"
 	o = append(o, 0xa1, 0x76)
 	o = msgp.AppendString(o, z.Predicate.Value)
+	// string "d"
+	o = append(o, 0xa1, 0x64)
+	o = msgp.AppendString(o, z.Predicate.Datatype)
+	// string "l"
+	o = append(o, 0xa1, 0x6c)
+	o = msgp.AppendString(o, z.Predicate.Lang)
 	// string "o"
-	// map header, size 2
+	// map header, size 4
 	// string "n"
-	o = append(o, 0xa1, 0x6f, 0x82, 0xa1, 0x6e)
+	o = append(o, 0xa1, 0x6f, 0x84, 0xa1, 0x6e)
 	o = msgp.AppendString(o, z.Object.Namespace)
 	// string "v"
 	o = append(o, 0xa1, 0x76)
 	o = msgp.AppendString(o, z.Object.Value)
+	// string "d"
+	o = append(o, 0xa1, 0x64)
+	o = msgp.AppendString(o, z.Object.Datatype)
+	// string "l"
+	o = append(o, 0xa1, 0x6c)
+	o = msgp.AppendString(o, z.Object.Lang)
 	return
 }
 
@@ -262,6 +364,16 @@ func (z *Triple) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Subject.Datatype, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Subject.Lang, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
 				default:
 					bts, err = msgp.Skip(bts)
 					if err != nil {
@@ -292,6 +404,16 @@ func (z *Triple) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Predicate.Datatype, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Predicate.Lang, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
 				default:
 					bts, err = msgp.Skip(bts)
 					if err != nil {
@@ -322,6 +444,16 @@ func (z *Triple) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					if err != nil {
 						return
 					}
+				case "d":
+					z.Object.Datatype, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
+				case "l":
+					z.Object.Lang, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						return
+					}
 				default:
 					bts, err = msgp.Skip(bts)
 					if err != nil {
@@ -341,7 +473,7 @@ func (z *Triple) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 func (z *Triple) Msgsize() (s int) {
-	s = 1 + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Subject.Namespace) + 2 + msgp.StringPrefixSize + len(z.Subject.Value) + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Predicate.Namespace) + 2 + msgp.StringPrefixSize + len(z.Predicate.Value) + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Object.Namespace) + 2 + msgp.StringPrefixSize + len(z.Object.Value)
+	s = 1 + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Subject.Namespace) + 2 + msgp.StringPrefixSize + len(z.Subject.Value) + 2 + msgp.StringPrefixSize + len(z.Subject.Datatype) + 2 + msgp.StringPrefixSize + len(z.Subject.Lang) + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Predicate.Namespace) + 2 + msgp.StringPrefixSize + len(z.Predicate.Value) + 2 + msgp.StringPrefixSize + len(z.Predicate.Datatype) + 2 + msgp.StringPrefixSize + len(z.Predicate.Lang) + 2 + 1 + 2 + msgp.StringPrefixSize + len(z.Object.Namespace) + 2 + msgp.StringPrefixSize + len(z.Object.Value) + 2 + msgp.StringPrefixSize + len(z.Object.Datatype) + 2 + msgp.StringPrefixSize + len(z.Object.Lang)
 	return
 }
 
@@ -371,6 +503,16 @@ func (z *URI) DecodeMsg(dc *msgp.Reader) (err error) {
 			if err != nil {
 				return
 			}
+		case "d":
+			z.Datatype, err = dc.ReadString()
+			if err != nil {
+				return
+			}
+		case "l":
+			z.Lang, err = dc.ReadString()
+			if err != nil {
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -383,9 +525,9 @@ func (z *URI) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z URI) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 4
 	// write "n"
-	err = en.Append(0x82, 0xa1, 0x6e)
+	err = en.Append(0x84, 0xa1, 0x6e)
 	if err != nil {
 		return err
 	}
@@ -402,19 +544,43 @@ func (z URI) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
+	// write "d"
+	err = en.Append(0xa1, 0x64)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Datatype)
+	if err != nil {
+		return
+	}
+	// write "l"
+	err = en.Append(0xa1, 0x6c)
+	if err != nil {
+		return err
+	}
+	err = en.WriteString(z.Lang)
+	if err != nil {
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z URI) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 4
 	// string "n"
-	o = append(o, 0x82, 0xa1, 0x6e)
+	o = append(o, 0x84, 0xa1, 0x6e)
 	o = msgp.AppendString(o, z.Namespace)
 	// string "v"
 	o = append(o, 0xa1, 0x76)
 	o = msgp.AppendString(o, z.Value)
+	// string "d"
+	o = append(o, 0xa1, 0x64)
+	o = msgp.AppendString(o, z.Datatype)
+	// string "l"
+	o = append(o, 0xa1, 0x6c)
+	o = msgp.AppendString(o, z.Lang)
 	return
 }
 
@@ -444,6 +610,16 @@ func (z *URI) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			if err != nil {
 				return
 			}
+		case "d":
+			z.Datatype, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
+		case "l":
+			z.Lang, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -456,6 +632,6 @@ func (z *URI) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 func (z URI) Msgsize() (s int) {
-	s = 1 + 2 + msgp.StringPrefixSize + len(z.Namespace) + 2 + msgp.StringPrefixSize + len(z.Value)
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.Namespace) + 2 + msgp.StringPrefixSize + len(z.Value) + 2 + msgp.StringPrefixSize + len(z.Datatype) + 2 + msgp.StringPrefixSize + len(z.Lang)
 	return
 }